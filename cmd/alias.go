@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/20uf/devcli/internal/alias"
+	"github.com/20uf/devcli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var aliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "Save frequently used command invocations",
+	Long: `Save frequently used devcli invocations under a short name.
+
+Aliases are stored in ~/.devcli/aliases.json.
+
+Examples:
+  devcli alias add prod-deploy "deploy --repo owner/api --workflow deploy.yml --branch main"
+  devcli alias run prod-deploy                           Run the saved invocation
+  devcli alias run prod-deploy --watch                   Extra args are appended
+  devcli alias list
+  devcli alias remove prod-deploy`,
+}
+
+var aliasAddCmd = &cobra.Command{
+	Use:                "add <name> <args...>",
+	Short:              "Save a command invocation under a name",
+	Args:               cobra.MinimumNArgs(2),
+	DisableFlagParsing: true,
+	RunE:               runAliasAdd,
+}
+
+var aliasListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved aliases",
+	Args:  cobra.NoArgs,
+	RunE:  runAliasList,
+}
+
+var aliasRemoveCmd = &cobra.Command{
+	Use:               "remove <name>",
+	Short:             "Remove a saved alias",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeAliasNames,
+	RunE:              runAliasRemove,
+}
+
+var aliasRunCmd = &cobra.Command{
+	Use:                "run <name> [args...]",
+	Short:              "Run a saved alias",
+	Args:               cobra.MinimumNArgs(1),
+	ValidArgsFunction:  completeAliasNames,
+	DisableFlagParsing: true,
+	RunE:               runAliasRun,
+}
+
+func init() {
+	aliasCmd.AddCommand(aliasAddCmd, aliasListCmd, aliasRemoveCmd, aliasRunCmd)
+	rootCmd.AddCommand(aliasCmd)
+}
+
+func runAliasAdd(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	stored := args[1:]
+	if len(stored) == 1 {
+		split, err := alias.SplitArgs(stored[0])
+		if err != nil {
+			return err
+		}
+		stored = split
+	}
+
+	if len(stored) == 0 {
+		return fmt.Errorf("alias %s has no command", name)
+	}
+	if stored[0] == "alias" {
+		return fmt.Errorf("an alias cannot run another alias command")
+	}
+	if _, _, err := rootCmd.Find(stored); err != nil {
+		return fmt.Errorf("unknown command %q", stored[0])
+	}
+
+	store, err := alias.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load aliases: %w", err)
+	}
+
+	store.Set(name, stored)
+	if err := store.Save(); err != nil {
+		return fmt.Errorf("failed to save aliases: %w", err)
+	}
+
+	ui.PrintSuccess(fmt.Sprintf("Alias %s → devcli %s", name, strings.Join(stored, " ")))
+	return nil
+}
+
+func runAliasList(cmd *cobra.Command, args []string) error {
+	store, err := alias.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load aliases: %w", err)
+	}
+
+	if len(store.Aliases) == 0 {
+		ui.PrintWarning("No aliases saved")
+		fmt.Println(ui.MutedStyle.Render("  Create one with `devcli alias add <name> <args...>`."))
+		return nil
+	}
+
+	maxNameLen := len("NAME")
+	for _, a := range store.Aliases {
+		if len(a.Name) > maxNameLen {
+			maxNameLen = len(a.Name)
+		}
+	}
+
+	fmt.Println(ui.TitleStyle.Render(fmt.Sprintf("%-*s  %s", maxNameLen, "NAME", "COMMAND")))
+	for _, a := range store.Aliases {
+		name := ui.SuccessStyle.Render(fmt.Sprintf("%-*s", maxNameLen, a.Name))
+		fmt.Printf("%s  %s\n", name, ui.MutedStyle.Render("devcli "+strings.Join(a.Args, " ")))
+	}
+	return nil
+}
+
+func runAliasRemove(cmd *cobra.Command, args []string) error {
+	store, err := alias.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load aliases: %w", err)
+	}
+
+	if !store.Remove(args[0]) {
+		return fmt.Errorf("alias %s not found", args[0])
+	}
+
+	if err := store.Save(); err != nil {
+		return fmt.Errorf("failed to save aliases: %w", err)
+	}
+
+	ui.PrintStep("⊘", fmt.Sprintf("Alias %s removed", args[0]))
+	return nil
+}
+
+func runAliasRun(cmd *cobra.Command, args []string) error {
+	store, err := alias.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load aliases: %w", err)
+	}
+
+	a := store.Get(args[0])
+	if a == nil {
+		return fmt.Errorf("alias %s not found", args[0])
+	}
+
+	expanded := append(append([]string{}, a.Args...), args[1:]...)
+
+	target, rest, err := rootCmd.Find(expanded)
+	if err != nil {
+		return fmt.Errorf("alias %s: %w", a.Name, err)
+	}
+	if target == rootCmd || target.RunE == nil {
+		return fmt.Errorf("alias %s does not resolve to a runnable command", a.Name)
+	}
+	if err := target.ParseFlags(rest); err != nil {
+		return fmt.Errorf("alias %s: %w", a.Name, err)
+	}
+	// The root pre-run has already run, before the alias flags were parsed
+	if err := applyLogLevel(flagLogLevel, flagVerbose); err != nil {
+		return err
+	}
+
+	targetArgs := target.Flags().Args()
+	if err := target.ValidateArgs(targetArgs); err != nil {
+		return fmt.Errorf("alias %s: %w", a.Name, err)
+	}
+
+	ui.PrintStep("↻", fmt.Sprintf("devcli %s", strings.Join(expanded, " ")))
+	target.SetContext(cmd.Context())
+	return target.RunE(target, targetArgs)
+}
+
+// completeAliasNames provides shell completion for saved alias names.
+func completeAliasNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	store, err := alias.Load()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []string
+	for _, name := range store.Names() {
+		if strings.HasPrefix(name, toComplete) {
+			names = append(names, name)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
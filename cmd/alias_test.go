@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/20uf/devcli/internal/alias"
+	"github.com/20uf/devcli/internal/verbose"
+)
+
+// Test: Output flags stored in an alias apply to the aliased command
+func TestRunAliasRun_LogLevel(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	defer func() {
+		flagLogLevel = ""
+		verbose.SetLevel(verbose.LevelNormal)
+	}()
+
+	store, err := alias.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Set("v", []string{"version", "--log-level", "debug"})
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runAliasRun(aliasRunCmd, []string{"v"}); err != nil {
+		t.Fatalf("runAliasRun() error = %v", err)
+	}
+	if got := verbose.CurrentLevel(); got != verbose.LevelDebug {
+		t.Errorf("Level after the alias = %s, want debug", got)
+	}
+
+	t.Log("✓ Alias flags applied")
+}
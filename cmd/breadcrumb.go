@@ -0,0 +1,43 @@
+package cmd
+
+import "github.com/20uf/devcli/internal/ui"
+
+// breadcrumb builds the steps shown above a selection prompt: the completed
+// steps, given as label/value pairs, then the current one. Steps skipped
+// through flags have no value and are left out; those whose value a flag
+// gives are marked fixed.
+func breadcrumb(current string, completed ...string) []ui.BreadcrumbStep {
+	var steps []ui.BreadcrumbStep
+	for i := 0; i+1 < len(completed); i += 2 {
+		if completed[i+1] != "" {
+			steps = append(steps, ui.BreadcrumbStep{Label: completed[i], Value: completed[i+1], Fixed: stepFixed(completed[i])})
+		}
+	}
+	return append(steps, ui.BreadcrumbStep{Label: current})
+}
+
+// autoPicked holds the steps last resolved without a prompt because there
+// was a single option. Going back to them would pick the same value again.
+var autoPicked = map[string]bool{}
+
+// stepFixed reports whether the step of a breadcrumb label was given by a
+// flag or picked as the only option rather than chosen.
+func stepFixed(label string) bool {
+	if autoPicked[label] {
+		return true
+	}
+	switch label {
+	case "profile":
+		// --profile auto still prompts among the profiles with valid credentials
+		return flagProfile != "" && flagProfile != profileAuto
+	case "cluster":
+		return flagCluster != ""
+	case "service":
+		return flagService != ""
+	case "owner", "repo":
+		return flagRepo != ""
+	case "workflow":
+		return flagWorkflow != ""
+	}
+	return false
+}
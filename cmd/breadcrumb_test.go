@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/20uf/devcli/internal/ui"
+)
+
+// Test: Breadcrumbs keep the completed steps, skipping those given by flags
+func TestBreadcrumb(t *testing.T) {
+	got := breadcrumb("workflow", "owner", "", "repo", "owner/api")
+	want := []ui.BreadcrumbStep{
+		{Label: "repo", Value: "owner/api"},
+		{Label: "workflow"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("breadcrumb() = %+v, want %+v", got, want)
+	}
+
+	if got := breadcrumb("profile"); len(got) != 1 || got[0].Label != "profile" {
+		t.Errorf("breadcrumb(profile) = %+v", got)
+	}
+
+	flagProfile = "dev"
+	defer func() { flagProfile = "" }()
+	if got := breadcrumb("cluster", "profile", "dev"); !got[0].Fixed {
+		t.Errorf("breadcrumb(cluster) = %+v, want the --profile step fixed", got)
+	}
+
+	flagProfile = profileAuto
+	if got := breadcrumb("cluster", "profile", "dev"); got[0].Fixed {
+		t.Errorf("breadcrumb(cluster) = %+v, want the --profile auto step selectable", got)
+	}
+
+	// A step picked as the only option cannot be gone back to either
+	autoPicked["owner"] = true
+	defer delete(autoPicked, "owner")
+	if got := breadcrumb("repo", "owner", "acme"); !got[0].Fixed {
+		t.Errorf("breadcrumb(repo) = %+v, want the single owner fixed", got)
+	}
+
+	t.Log("✓ Breadcrumb steps built")
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -13,13 +14,18 @@ import (
 var flagSetup bool
 
 var completionCmd = &cobra.Command{
-	Use:   "completion [bash|zsh|fish]",
+	Use:   "completion [bash|zsh|fish|powershell]",
 	Short: "Generate or install shell completion",
 	Long: `Generate shell completion script.
 
 By default, prints the completion script to stdout.
-Use --setup to automatically install it for your shell.`,
-	ValidArgs: []string{"bash", "zsh", "fish"},
+Use --setup to automatically install it for your shell.
+
+Examples:
+  devcli completion zsh --setup
+  devcli completion powershell | Out-String | Invoke-Expression
+  devcli completion powershell --setup      Append to your $PROFILE`,
+	ValidArgs: []string{"bash", "zsh", "fish", "powershell"},
 	Args:      cobra.ExactArgs(1),
 	RunE:      runCompletion,
 }
@@ -65,8 +71,16 @@ func printCompletion(cmd *cobra.Command, shell string) error {
 		fmt.Println("#   devcli completion fish --setup")
 		fmt.Println()
 		return rootCmd.GenFishCompletion(os.Stdout, true)
+	case "powershell":
+		fmt.Println("# Add this to your PowerShell profile ($PROFILE):")
+		fmt.Println("#   devcli completion powershell | Out-String | Invoke-Expression")
+		fmt.Println("#")
+		fmt.Println("# Or install automatically:")
+		fmt.Println("#   devcli completion powershell --setup")
+		fmt.Println()
+		return rootCmd.GenPowerShellCompletion(os.Stdout)
 	default:
-		return fmt.Errorf("unsupported shell: %s (use bash, zsh, or fish)", shell)
+		return fmt.Errorf("unsupported shell: %s (use bash, zsh, fish, or powershell)", shell)
 	}
 }
 
@@ -78,6 +92,8 @@ func setupCompletion(cmd *cobra.Command, shell string) error {
 		return setupZsh()
 	case "fish":
 		return setupFish()
+	case "powershell":
+		return setupPowerShell()
 	default:
 		return fmt.Errorf("unsupported shell: %s", shell)
 	}
@@ -188,6 +204,49 @@ func setupFish() error {
 	return writeCompletionFile(completionFile, "fish")
 }
 
+func setupPowerShell() error {
+	profile, err := powerShellProfilePath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(profile), 0755); err != nil {
+		return fmt.Errorf("failed to create profile directory: %w", err)
+	}
+
+	line := "devcli completion powershell | Out-String | Invoke-Expression"
+	if err := ensureLineInFile(profile, line, "devcli completion"); err != nil {
+		return err
+	}
+
+	fmt.Printf("Added to %s: %s\n", profile, line)
+	fmt.Println("Restart PowerShell or run: . $PROFILE")
+	return nil
+}
+
+// powerShellProfilePath asks PowerShell for $PROFILE, falling back to the
+// default CurrentUserCurrentHost location when no shell is available.
+func powerShellProfilePath() (string, error) {
+	for _, bin := range []string{"pwsh", "powershell"} {
+		out, err := exec.Command(bin, "-NoProfile", "-NoLogo", "-Command", "$PROFILE").Output()
+		if err == nil {
+			if p := strings.TrimSpace(string(out)); p != "" {
+				return p, nil
+			}
+		}
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	if runtime.GOOS == "windows" {
+		return filepath.Join(home, "Documents", "PowerShell", "Microsoft.PowerShell_profile.ps1"), nil
+	}
+	return filepath.Join(home, ".config", "powershell", "Microsoft.PowerShell_profile.ps1"), nil
+}
+
 func writeCompletionFile(path, shell string) error {
 	// Check if file already exists
 	if _, err := os.Stat(path); err == nil {
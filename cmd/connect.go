@@ -1,13 +1,21 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	awsutil "github.com/20uf/devcli/internal/aws"
+	"github.com/20uf/devcli/internal/config"
+	"github.com/20uf/devcli/internal/connection/infra"
 	"github.com/20uf/devcli/internal/ecs"
 	"github.com/20uf/devcli/internal/history"
+	"github.com/20uf/devcli/internal/retry"
+	"github.com/20uf/devcli/internal/telemetry"
+	"github.com/20uf/devcli/internal/terminal"
 	"github.com/20uf/devcli/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -30,34 +38,126 @@ Examples:
   devcli connect                                         Interactive selection
   devcli connect --profile dev --cluster my-cluster      Partial flags
   devcli connect --profile dev --cluster c --service s   Full non-interactive
-  devcli connect --shell /bin/bash                       Custom shell`,
+  devcli connect --env prod                              Use the profile mapped to prod in config
+  devcli connect --shell /bin/bash                       Custom shell
+  devcli connect --cluster c --service s --command "php bin/console cache:clear"  Run one command and exit with its status
+  devcli connect --shell-detect                          Use the best shell found in the container
+  devcli connect --profile dev --all-regions             Find clusters in every region
+  devcli connect --container all                         Run ps in every container
+  devcli connect --container all --report-command "df -h"  Custom diagnostic report
+  devcli connect --cluster c --service s --json-task     List running tasks as JSON
+  devcli connect --profile dev --cluster c --list -o json  List the services of a cluster
+  devcli connect --role-arn arn:aws:iam::1234:role/ops   Assume a role before connecting
+  devcli connect --template clear-cache                  Run a command template from config
+  devcli connect --last --auto-reconnect                 Replay and reconnect on drops
+  devcli connect --tunnel                                SOCKS5 proxy through the container
+  devcli connect --copy-env DATABASE_URL,APP_ENV         Save container env vars to source locally
+  devcli connect --record onboarding.cast                Record the session with asciinema
+  devcli connect --new-tab                               Open the session in a new terminal tab
+  devcli connect --provider k8s --profile kind-dev       Shell into a Kubernetes pod with kubectl exec`,
 	RunE: runConnect,
 }
 
-var flagConnectLast bool
+var (
+	flagConnectLast         bool
+	flagSelectAllContainers bool
+	flagReportCommand       string
+	flagTunnel              bool
+	flagNewTab              bool
+	flagJSONTask            bool
+	flagConnectList         bool
+	flagExecCommand         string
+	flagConnectOutput       string
+	flagRoleARN             string
+	flagSaveRole            bool
+	flagAutoReconnect       bool
+	flagMaxRetries          int
+	flagTemplate            string
+	flagAllRegions          bool
+	flagShellDetect         bool
+	flagConnectProvider     string
+	flagConnectEnv          string
+	flagCopyEnv             []string
+	flagAllProfiles         bool
+	flagRecord              string
+	flagTimeout             time.Duration
+	flagTimeoutWarnBefore   time.Duration
+)
 
 func init() {
 	connectCmd.Flags().StringVar(&flagCluster, "cluster", "", "ECS cluster name or ARN (skip selection)")
 	connectCmd.Flags().StringVar(&flagService, "service", "", "ECS service name (skip selection)")
-	connectCmd.Flags().StringVar(&flagContainer, "container", "", "Container name (skip selection)")
+	connectCmd.Flags().StringVar(&flagContainer, "container", "", "Container name (skip selection), or \"all\" for an exec report")
 	connectCmd.Flags().StringVar(&flagShell, "shell", "", "Shell command (default: auto-detect)")
-	connectCmd.Flags().StringVar(&flagProfile, "profile", "", "AWS profile to use")
+	connectCmd.Flags().StringVar(&flagExecCommand, "command", "", "Run a single command, print its output and exit with its status")
+	connectCmd.Flags().BoolVar(&flagShellDetect, "shell-detect", false, "Probe the shells available in the container and use the best one")
+	connectCmd.Flags().StringVar(&flagProfile, "profile", "", "AWS profile to use, auto for the one with valid credentials (kubectl context with --provider k8s)")
+	connectCmd.Flags().StringVar(&flagConnectProvider, "provider", infra.ProviderECS, "Container platform: ecs or k8s")
+	connectCmd.Flags().BoolVar(&flagAllProfiles, "all-profiles", false, "Offer every AWS profile, not only those with SSO or credentials")
+	connectCmd.Flags().StringVar(&flagConnectEnv, "env", "", "Environment whose AWS profile to use (config: environments.<env>)")
 	connectCmd.Flags().StringVar(&flagRegion, "region", "", "AWS region to use")
+	connectCmd.Flags().BoolVar(&flagAllRegions, "all-regions", false, "List clusters from every enabled region")
 	connectCmd.Flags().BoolVar(&flagConnectLast, "last", false, "Replay last connection")
+	connectCmd.Flags().BoolVar(&flagSelectAllContainers, "select-all-containers", false, "Run a diagnostic command in every container and print a report")
+	connectCmd.Flags().StringVar(&flagReportCommand, "report-command", "", "Command executed by the exec report (default: ps)")
+	connectCmd.Flags().StringSliceVar(&flagCopyEnv, "copy-env", nil, "Write the given container env vars (KEY,KEY2) to ~/.devcli/env-<session>.sh before opening the shell")
+	connectCmd.Flags().StringVar(&flagRecord, "record", "", "Record the session to an asciinema .cast file (a timestamped text log without asciinema)")
+	connectCmd.Flags().DurationVar(&flagTimeout, "timeout", 0, "Close the session this long after it started, a key pressed after the warning extends it (e.g. 2h)")
+	connectCmd.Flags().DurationVar(&flagTimeoutWarnBefore, "timeout-warn-before", 5*time.Minute, "Warn this long before --timeout closes the session")
+	connectCmd.Flags().BoolVar(&flagTunnel, "tunnel", false, "Open a SOCKS5 proxy through the container instead of a shell")
+	connectCmd.Flags().StringVar(&flagRoleARN, "role-arn", "", "IAM role to assume before calling ECS (config: connect.profiles.<profile>.role_arn)")
+	connectCmd.Flags().BoolVar(&flagSaveRole, "save-role", false, "Remember --role-arn for the selected profile")
+	connectCmd.Flags().StringVar(&flagTemplate, "template", "", "Run a command template from config instead of a shell")
+	connectCmd.Flags().BoolVar(&flagAutoReconnect, "auto-reconnect", false, "Reconnect when the session drops, following replacement tasks")
+	connectCmd.Flags().IntVar(&flagMaxRetries, "max-retries", 3, "Reconnect attempts with --auto-reconnect")
+	connectCmd.Flags().BoolVar(&flagJSONTask, "json-task", false, "Print the running tasks of the selected service as JSON instead of connecting")
+	connectCmd.Flags().BoolVar(&flagConnectList, "list", false, "List the services of the selected cluster instead of connecting")
+	addOutputFlag(connectCmd, &flagConnectOutput)
+	connectCmd.Flags().BoolVar(&flagNewTab, "new-tab", false, "Open the session in a new terminal tab")
+	connectCmd.Flags().MarkHidden("new-tab") //nolint:errcheck
 	rootCmd.AddCommand(connectCmd)
 }
 
 func runConnect(cmd *cobra.Command, args []string) error {
+	if err := resolveEnvProfile(); err != nil {
+		return err
+	}
+
+	switch flagConnectProvider {
+	case infra.ProviderECS:
+	case infra.ProviderK8s:
+		return runK8sConnect(cmd)
+	default:
+		return fmt.Errorf("unknown provider %q\n  Available: %s, %s", flagConnectProvider, infra.ProviderECS, infra.ProviderK8s)
+	}
+
 	if err := awsutil.CheckDependencies(); err != nil {
 		return err
 	}
 
+	format, err := ui.ParseOutputFormat(flagConnectOutput)
+	if err != nil {
+		return err
+	}
+	if err := checkCommandFlags(); err != nil {
+		return err
+	}
+	if err := checkRecordFlags(); err != nil {
+		return err
+	}
+	if err := checkTimeoutFlags(); err != nil {
+		return err
+	}
+	if err := checkCopyEnvKeys(flagCopyEnv); err != nil {
+		return err
+	}
+
 	if flagConnectLast {
 		return replayLastConnect()
 	}
 
 	// Show history if no flags
-	if flagProfile == "" && flagCluster == "" && flagService == "" {
+	if flagProfile == "" && flagCluster == "" && flagService == "" && !flagJSONTask && !flagAllRegions && !flagConnectList {
 		entry, err := showConnectHistory()
 		if err != nil {
 			return err
@@ -75,6 +175,9 @@ func runConnect(cmd *cobra.Command, args []string) error {
 	for {
 		switch step {
 		case 0: // Select profile
+			if flagProfile == "" {
+				ui.PrintBreadcrumb(breadcrumb("profile"))
+			}
 			p, err := selectProfile()
 			if err != nil {
 				return err // ESC at first step → back to home
@@ -86,7 +189,7 @@ func runConnect(cmd *cobra.Command, args []string) error {
 			if err := awsutil.EnsureSSOLogin(profile); err != nil {
 				return err
 			}
-			c, err := ecs.NewClient(profile, flagRegion)
+			c, err := newConnectClient(profile)
 			if err != nil {
 				return fmt.Errorf("failed to create AWS client: %w", err)
 			}
@@ -94,7 +197,18 @@ func runConnect(cmd *cobra.Command, args []string) error {
 			step++
 
 		case 2: // Select cluster
-			c, err := selectCluster(client)
+			nav := breadcrumb("cluster", "profile", profile)
+			var c string
+			var err error
+			if flagAllRegions && flagCluster == "" {
+				var regional *ecs.Client
+				c, regional, err = selectClusterAllRegions(profile, nav...)
+				if err == nil {
+					client = regional
+				}
+			} else {
+				c, err = selectCluster(client, nav...)
+			}
 			if err != nil {
 				if isCredentialError(err) {
 					ui.PrintWarning("Credentials expired, re-authenticating...")
@@ -111,7 +225,11 @@ func runConnect(cmd *cobra.Command, args []string) error {
 			step++
 
 		case 3: // Select service
-			s, err := selectService(client, cluster)
+			if flagConnectList {
+				return printServiceList(cmd.Context(), client, cluster, format)
+			}
+
+			s, err := selectService(client, cluster, breadcrumb("service", "profile", profile, "cluster", cluster)...)
 			if err != nil {
 				if isCredentialError(err) {
 					ui.PrintWarning("Credentials expired, re-authenticating...")
@@ -128,7 +246,16 @@ func runConnect(cmd *cobra.Command, args []string) error {
 			step++
 
 		case 4: // Get task + select container
-			t, err := client.GetRunningTask(cmd.Context(), cluster, service)
+			if flagJSONTask {
+				return printTaskList(cmd.Context(), client, cluster, service)
+			}
+
+			spin := ui.StartSpinner("Loading tasks…")
+			ctx, span := telemetry.Start(cmd.Context(), "task-lookup",
+				telemetry.ClusterKey.String(cluster), telemetry.ServiceKey.String(service))
+			t, err := client.GetRunningTask(ctx, cluster, service)
+			telemetry.End(span, err)
+			spin.Stop()
 			if err != nil {
 				if isCredentialError(err) {
 					ui.PrintWarning("Credentials expired, re-authenticating...")
@@ -144,7 +271,16 @@ func runConnect(cmd *cobra.Command, args []string) error {
 			}
 			task = t
 
-			cont, err := selectContainer(client, cmd, cluster, task)
+			if err := client.CheckExecEnabled(cmd.Context(), cluster, service, task); err != nil {
+				return err
+			}
+
+			if isReportMode() {
+				return runContainerReport(cmd.Context(), client, profile, cluster, service, task)
+			}
+
+			nav := breadcrumb("container", "profile", profile, "cluster", cluster, "service", service)
+			cont, err := selectContainer(client, cmd, cluster, task, nav...)
 			if err != nil {
 				step = 3 // ESC → back to service
 				continue
@@ -153,30 +289,67 @@ func runConnect(cmd *cobra.Command, args []string) error {
 			step++
 
 		case 5: // Execute
-			shell := resolveShell()
+			target := connectTarget{
+				Profile: profile, Cluster: cluster, Service: service, Task: task, Container: container,
+				OSFamily: taskOSFamily(cmd.Context(), client, cluster, task),
+			}
+			if flagExecCommand != "" {
+				return runOneShotCommand(cmd.Context(), client, target)
+			}
+			shell, err := sessionCommand(target)
+			if err != nil {
+				return err
+			}
+			if shouldDetectShell() {
+				shell = detectShell(cmd.Context(), client, target, shell)
+			}
+			if len(flagCopyEnv) > 0 && !flagTunnel {
+				copyContainerEnv(cmd.Context(), client, target, flagCopyEnv)
+			}
 
 			hist, _ := history.Load()
-			if hist != nil {
-				label := fmt.Sprintf("%s → %s/%s/%s", profile, cluster, service, container)
-				hist.Add("connect", label, []string{
-					"--profile", profile, "--cluster", cluster,
-					"--service", service, "--container", container,
+			label := fmt.Sprintf("%s → %s/%s/%s", profile, cluster, service, container)
+			histArgs := []string{
+				"--profile", profile, "--cluster", cluster,
+				"--service", service, "--container", container,
+			}
+			if flagRegion != "" {
+				histArgs = append(histArgs, "--region", flagRegion)
+			}
+
+			if flagTunnel {
+				ui.PrintStep("▶", fmt.Sprintf("Opening tunnel through %s/%s/%s", cluster, service, container))
+				return execAndRecord(hist, label, histArgs, func() error {
+					return runTunnel(cmd.Context(), client, profile, cluster, task, container)
 				})
-				hist.Save() //nolint:errcheck
 			}
 
 			ui.PrintStep("▶", fmt.Sprintf("Connecting to %s/%s/%s", cluster, service, container))
-			return client.ExecInteractive(cmd.Context(), cluster, task, container, shell, profile)
+			return execAndRecord(hist, label, histArgs, func() error {
+				if flagNewTab {
+					return openSessionTab(client.ExecCommandLine(cluster, task, container, shell, profile))
+				}
+				if flagRecord != "" {
+					return execRecorded(cmd.Context(), client, profile, cluster, service, task, container, shell)
+				}
+				return execInteractiveWithReconnect(cmd.Context(), client, profile, cluster, service, task, container, shell)
+			})
 		}
 	}
 }
 
-func selectCluster(client *ecs.Client) (string, error) {
+// selectCluster, selectService and selectContainer show steps, the
+// breadcrumb of the interactive flow, above their prompt.
+func selectCluster(client *ecs.Client, steps ...ui.BreadcrumbStep) (string, error) {
 	if flagCluster != "" {
 		return flagCluster, nil
 	}
 
-	clusters, err := client.ListClusters(rootCmd.Context())
+	spin := ui.StartSpinner("Loading clusters…")
+	ctx, span := telemetry.Start(rootCmd.Context(), "cluster-list")
+	clusters, err := client.ListClusters(ctx)
+	telemetry.End(span, err)
+	spin.Stop()
 	if err != nil {
 		return "", fmt.Errorf("failed to list clusters: %w", err)
 	}
@@ -185,15 +358,19 @@ func selectCluster(client *ecs.Client) (string, error) {
 		return "", fmt.Errorf("no ECS clusters found")
 	}
 
-	return ui.Select("Select cluster", clusters)
+	return ui.SelectWithContext("Select cluster", steps, ui.StringOptions(clusters))
 }
 
-func selectService(client *ecs.Client, cluster string) (string, error) {
+func selectService(client *ecs.Client, cluster string, steps ...ui.BreadcrumbStep) (string, error) {
 	if flagService != "" {
 		return flagService, nil
 	}
 
-	services, err := client.ListServices(rootCmd.Context(), cluster)
+	spin := ui.StartSpinner("Loading services…")
+	ctx, span := telemetry.Start(rootCmd.Context(), "service-list", telemetry.ClusterKey.String(cluster))
+	services, err := client.ListServices(ctx, cluster)
+	telemetry.End(span, err)
+	spin.Stop()
 	if err != nil {
 		return "", fmt.Errorf("failed to list services: %w", err)
 	}
@@ -202,45 +379,140 @@ func selectService(client *ecs.Client, cluster string) (string, error) {
 		return "", fmt.Errorf("no services found in cluster %s", cluster)
 	}
 
-	return ui.Select("Select service", services)
+	return ui.SelectWithContext("Select service", steps, serviceOptions(services))
+}
+
+// serviceRow is a service as listed by `connect --list`.
+type serviceRow struct {
+	Name           string `json:"name"`
+	TaskDefinition string `json:"task_definition"`
+	Image          string `json:"image"`
+}
+
+// printServiceList prints the services of a cluster without connecting.
+func printServiceList(ctx context.Context, client *ecs.Client, cluster string, format ui.OutputFormat) error {
+	services, err := client.ListServices(ctx, cluster)
+	if err != nil {
+		return fmt.Errorf("failed to list services: %w", err)
+	}
+
+	rows := make([]serviceRow, len(services))
+	for i, s := range services {
+		rows[i] = serviceRow{Name: s.Name, TaskDefinition: s.TaskDefinition, Image: s.Image}
+	}
+	return ui.RenderList(os.Stdout, format, rows)
+}
+
+// serviceOptions renders services with their deployed version as a secondary column.
+func serviceOptions(services []ecs.ServiceInfo) []ui.SelectOption {
+	width := 0
+	for _, s := range services {
+		width = max(width, len(s.Name))
+	}
+
+	options := make([]ui.SelectOption, len(services))
+	for i, s := range services {
+		display := s.Name
+		if detail := s.Detail(); detail != "" {
+			display = fmt.Sprintf("%-*s  %s", width, s.Name, ui.MutedStyle.Render(detail))
+		}
+		options[i] = ui.SelectOption{Display: display, Value: s.Name}
+	}
+	return options
 }
 
-func selectContainer(client *ecs.Client, cmd *cobra.Command, cluster, task string) (string, error) {
+func selectContainer(client *ecs.Client, cmd *cobra.Command, cluster, task string, steps ...ui.BreadcrumbStep) (string, error) {
 	if flagContainer != "" {
 		return flagContainer, nil
 	}
 
-	containers, err := client.ListContainers(cmd.Context(), cluster, task)
+	all, err := client.ListContainers(cmd.Context(), cluster, task)
 	if err != nil {
 		return "", fmt.Errorf("failed to list containers: %w", err)
 	}
 
-	if len(containers) == 0 {
-		return "", fmt.Errorf("no containers found in task %s", task)
+	containers, err := execContainers(all, task)
+	if err != nil {
+		return "", err
 	}
 
-	// Auto-select "php" if present
-	for _, c := range containers {
-		if c == "php" {
-			fmt.Println("Auto-selected container: php")
-			return "php", nil
-		}
+	if c, ok := pickPreferredContainer(containers, preferredContainers()); ok {
+		fmt.Printf("Auto-selected container: %s\n", c.Label())
+		return c.Name, nil
 	}
 
 	if len(containers) == 1 {
-		fmt.Printf("Auto-selected container: %s\n", containers[0])
-		return containers[0], nil
+		fmt.Printf("Auto-selected container: %s\n", containers[0].Label())
+		return containers[0].Name, nil
 	}
 
-	return ui.Select("Select container", containers)
+	options := make([]ui.SelectOption, len(containers))
+	for i, c := range containers {
+		options[i] = ui.SelectOption{Display: c.Label(), Value: c.Name}
+	}
+	return ui.SelectWithContext("Select container", steps, options)
+}
+
+// pickPreferredContainer returns the container named after the first of
+// preferred found in the task.
+func pickPreferredContainer(containers []ecs.ContainerInfo, preferred []string) (ecs.ContainerInfo, bool) {
+	for _, name := range preferred {
+		for _, c := range containers {
+			if c.Name == name {
+				return c, true
+			}
+		}
+	}
+	return ecs.ContainerInfo{}, false
+}
+
+// execContainers returns the running containers of a task, listing those
+// skipped, or an error when none is running.
+func execContainers(all []ecs.ContainerInfo, task string) ([]ecs.ContainerInfo, error) {
+	if len(all) == 0 {
+		return nil, fmt.Errorf("no containers found in task %s", task)
+	}
+
+	containers, stopped := runningContainers(all)
+	if len(stopped) > 0 {
+		fmt.Println(ui.MutedStyle.Render(fmt.Sprintf("  Skipping %d container(s) not running: %s", len(stopped), strings.Join(stopped, ", "))))
+	}
+	if len(containers) == 0 {
+		return nil, fmt.Errorf("no running containers in task %s", task)
+	}
+	return containers, nil
+}
+
+// runningContainers splits containers into those accepting execute-command
+// and the labels of the others.
+func runningContainers(containers []ecs.ContainerInfo) (running []ecs.ContainerInfo, stopped []string) {
+	for _, c := range containers {
+		if c.Running() {
+			running = append(running, c)
+		} else {
+			stopped = append(stopped, c.Label())
+		}
+	}
+	return running, stopped
 }
 
+// profileAuto is the --profile value that picks among the profiles whose
+// credentials currently work.
+const profileAuto = "auto"
+
 func selectProfile() (string, error) {
+	if flagProfile == profileAuto {
+		return selectValidProfile()
+	}
 	if flagProfile != "" {
 		return flagProfile, nil
 	}
 
-	profiles, err := awsutil.ListProfiles()
+	listProfiles := awsutil.ListUsableProfiles
+	if flagAllProfiles {
+		listProfiles = awsutil.ListProfiles
+	}
+	profiles, err := listProfiles()
 	if err != nil {
 		if errors.Is(err, awsutil.ErrNoConfigFile) {
 			return "", fmt.Errorf("no AWS configuration found (~/.aws/config does not exist)\n\n  Run: aws configure sso\n  Doc: https://docs.aws.amazon.com/cli/latest/userguide/sso-configure-profile-token.html")
@@ -248,23 +520,77 @@ func selectProfile() (string, error) {
 		return "", fmt.Errorf("failed to list AWS profiles: %w", err)
 	}
 
+	if len(profiles) == 0 && !flagAllProfiles {
+		return "", fmt.Errorf("no AWS profile with SSO or credentials found in ~/.aws/config\n\n  Run: aws configure sso\n  Or pass --all-profiles to list every profile")
+	}
 	if len(profiles) == 0 {
 		return "", fmt.Errorf("no AWS profiles found in ~/.aws/config\n\n  Run: aws configure sso\n  Doc: https://docs.aws.amazon.com/cli/latest/userguide/sso-configure-profile-token.html")
 	}
 
+	options := profileOptions(profiles, awsutil.ProfileRegion)
+	autoPicked["profile"] = len(profiles) == 1
 	if len(profiles) == 1 {
-		fmt.Printf("Using AWS profile: %s\n", profiles[0])
+		fmt.Printf("Using AWS profile: %s\n", options[0].Display)
+		warnRegionOverride(awsutil.ProfileRegion(profiles[0]))
 		return profiles[0], nil
 	}
 
-	return ui.Select("Select AWS profile", profiles)
+	profile, err := ui.SelectWithOptions("Select AWS profile", options)
+	if err != nil {
+		return "", err
+	}
+	warnRegionOverride(awsutil.ProfileRegion(profile))
+	return profile, nil
 }
 
-func resolveShell() string {
-	if flagShell != "" {
-		return flagShell
+// selectValidProfile checks the credentials of every profile and uses the
+// only one that works, or offers the working ones.
+func selectValidProfile() (string, error) {
+	profiles, err := awsutil.ListProfiles()
+	if err != nil {
+		return "", fmt.Errorf("failed to list AWS profiles: %w", err)
 	}
-	return "su -s /bin/sh www-data"
+
+	spin := ui.StartSpinner("Checking AWS credentials…")
+	valid := awsutil.ValidProfiles(rootCmd.Context(), profiles)
+	spin.Stop()
+
+	autoPicked["profile"] = len(valid) == 1
+	switch len(valid) {
+	case 0:
+		return "", fmt.Errorf("no AWS profile has valid credentials\n  Run: aws sso login --profile <profile>")
+	case 1:
+		fmt.Printf("Using AWS profile: %s (the only one with valid credentials)\n", valid[0])
+		return valid[0], nil
+	}
+	return ui.SelectWithOptions("Select AWS profile", profileOptions(valid, awsutil.ProfileRegion))
+}
+
+// profileOptions shows each profile with its configured region, so profiles
+// of the same account in different regions can be told apart.
+func profileOptions(profiles []string, region func(string) string) []ui.SelectOption {
+	options := make([]ui.SelectOption, len(profiles))
+	for i, p := range profiles {
+		options[i] = ui.SelectOption{Display: p, Value: p}
+		if r := region(p); r != "" {
+			options[i].Display = fmt.Sprintf("%s (%s)", p, r)
+		}
+	}
+	return options
+}
+
+// warnRegionOverride tells when --region replaces the region of the selected
+// profile, since its clusters are then not listed.
+func warnRegionOverride(profileRegion string) {
+	if flagRegion != "" && profileRegion != "" && flagRegion != profileRegion {
+		ui.PrintWarning(fmt.Sprintf("Using region %s (--region) instead of the profile's %s", flagRegion, profileRegion))
+	}
+}
+
+// shouldDetectShell reports whether --shell-detect applies: an explicit
+// --shell or --template always wins.
+func shouldDetectShell() bool {
+	return flagShellDetect && flagShell == "" && flagTemplate == ""
 }
 
 func showConnectHistory() (*history.Entry, error) {
@@ -278,8 +604,8 @@ func showConnectHistory() (*history.Entry, error) {
 		return nil, nil
 	}
 
-	if len(labels) > 10 {
-		labels = labels[:10]
+	if len(labels) > historyMenuSize {
+		labels = append(labels[:historyMenuSize], optionSearchHistory)
 	}
 
 	labels = append([]string{"+ New connection"}, labels...)
@@ -291,6 +617,9 @@ func showConnectHistory() (*history.Entry, error) {
 	if selected == "+ New connection" {
 		return nil, nil
 	}
+	if selected == optionSearchHistory {
+		return searchHistory(hist, "connect")
+	}
 
 	label := selected[:strings.LastIndex(selected, " (")]
 	return hist.FindByLabel("connect", label), nil
@@ -317,9 +646,11 @@ func replayLastConnect() error {
 }
 
 func replayConnectEntry(entry *history.Entry) error {
-	var profile, cluster, service, container string
+	var provider, profile, cluster, service, container string
 	for i := 0; i < len(entry.Args)-1; i += 2 {
 		switch entry.Args[i] {
+		case "--provider":
+			provider = entry.Args[i+1]
 		case "--profile":
 			profile = entry.Args[i+1]
 		case "--cluster":
@@ -328,52 +659,210 @@ func replayConnectEntry(entry *history.Entry) error {
 			service = entry.Args[i+1]
 		case "--container":
 			container = entry.Args[i+1]
+		case "--region":
+			flagRegion = entry.Args[i+1]
 		}
 	}
 
+	if provider == infra.ProviderK8s {
+		target := connectTarget{Profile: profile, Cluster: cluster, Service: service, Container: container, Provider: provider}
+		return replayK8sEntry(rootCmd.Context(), entry, target)
+	}
+
+	if err := checkReplayProfile(profile); err != nil {
+		return err
+	}
+
 	ui.PrintStep("↻", fmt.Sprintf("Replaying: %s", entry.Label))
 
 	if err := awsutil.EnsureSSOLogin(profile); err != nil {
 		return err
 	}
 
-	client, err := ecs.NewClient(profile, flagRegion)
+	client, err := newConnectClient(profile)
 	if err != nil {
 		return fmt.Errorf("failed to create AWS client: %w", err)
 	}
 
-	task, err := client.GetRunningTask(rootCmd.Context(), cluster, service)
+	spin := ui.StartSpinner("Loading tasks…")
+	ctx, span := telemetry.Start(rootCmd.Context(), "task-lookup",
+		telemetry.ClusterKey.String(cluster), telemetry.ServiceKey.String(service))
+	task, err := client.GetRunningTask(ctx, cluster, service)
+	telemetry.End(span, err)
+	spin.Stop()
 	if err != nil {
 		if isCredentialError(err) {
 			ui.PrintWarning("Credentials expired, re-authenticating...")
 			if ssoErr := awsutil.ForceSSOLogin(profile); ssoErr != nil {
 				return ssoErr
 			}
-			client, err = ecs.NewClient(profile, flagRegion)
+			client, err = newConnectClient(profile)
 			if err != nil {
 				return fmt.Errorf("failed to create AWS client: %w", err)
 			}
 			task, err = client.GetRunningTask(rootCmd.Context(), cluster, service)
 			if err != nil {
-				return fmt.Errorf("no running task found: %w", err)
+				return replayTaskError(cluster, service, err)
 			}
 		} else {
-			return fmt.Errorf("no running task found: %w", err)
+			return replayTaskError(cluster, service, err)
 		}
 	}
 
-	shell := resolveShell()
+	if err := client.CheckExecEnabled(rootCmd.Context(), cluster, service, task); err != nil {
+		return err
+	}
+
+	target := connectTarget{
+		Profile: profile, Cluster: cluster, Service: service, Task: task, Container: container,
+		OSFamily: taskOSFamily(rootCmd.Context(), client, cluster, task),
+	}
+	if flagExecCommand != "" {
+		return runOneShotCommand(rootCmd.Context(), client, target)
+	}
+	shell, err := sessionCommand(target)
+	if err != nil {
+		return err
+	}
+	if shouldDetectShell() {
+		shell = detectShell(rootCmd.Context(), client, target, shell)
+	}
+	if len(flagCopyEnv) > 0 {
+		copyContainerEnv(rootCmd.Context(), client, target, flagCopyEnv)
+	}
 	ui.PrintStep("▶", fmt.Sprintf("Connecting to %s/%s/%s", cluster, service, container))
-	return client.ExecInteractive(rootCmd.Context(), cluster, task, container, shell, profile)
+	if flagNewTab {
+		return openSessionTab(client.ExecCommandLine(cluster, task, container, shell, profile))
+	}
+	if flagRecord != "" {
+		return execRecorded(rootCmd.Context(), client, profile, cluster, service, task, container, shell)
+	}
+	return execInteractiveWithReconnect(rootCmd.Context(), client, profile, cluster, service, task, container, shell)
+}
+
+// checkReplayProfile fails early when the profile of a history entry is no
+// longer configured, rather than with an SSO error deep in the replay.
+func checkReplayProfile(profile string) error {
+	if profile == "" {
+		return nil
+	}
+	exists, err := awsutil.ProfileExists(profile)
+	if err != nil || exists {
+		return nil // missing or unreadable config: let the AWS SDK report it
+	}
+	return fmt.Errorf("AWS profile %q of this connection no longer exists in the AWS config or credentials file\n  Run devcli connect without --last to select a profile", profile)
+}
+
+// resolveEnvProfile sets --profile to the AWS profile mapped to --env under
+// environments in the config.
+func resolveEnvProfile() error {
+	if flagConnectEnv == "" {
+		return nil
+	}
+	if flagProfile != "" {
+		return fmt.Errorf("--env and --profile cannot be combined")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	profile, ok := cfg.Environments.Profile(flagConnectEnv)
+	if !ok {
+		if names := cfg.Environments.Names(); len(names) > 0 {
+			return fmt.Errorf("unknown environment %q\n  Available: %s", flagConnectEnv, strings.Join(names, ", "))
+		}
+		return fmt.Errorf("unknown environment %q\n  Map it to an AWS profile under environments in ~/.devcli/config.yaml", flagConnectEnv)
+	}
+	flagProfile = profile
+	return nil
+}
+
+// replayTaskError explains that a recorded service could not be reached, for
+// instance because the cluster was removed or lives in another region.
+func replayTaskError(cluster, service string, err error) error {
+	where := cluster
+	if flagRegion != "" {
+		where = fmt.Sprintf("%s (region %s)", cluster, flagRegion)
+	}
+	return fmt.Errorf("no running task found for %s in %s: %w\n  Run devcli connect without --last to select it again", service, where, err)
+}
+
+// execInteractiveWithReconnect opens the interactive session, reconnecting
+// to the service's current task when --auto-reconnect is set. A session
+// ended by an expired SSO token resumes after a new login.
+func execInteractiveWithReconnect(ctx context.Context, client *ecs.Client, profile, cluster, service, task, container, shell string) error {
+	for refreshes := 0; ; refreshes++ {
+		err := newReconnector().Run(ctx, task,
+			func(task string) error {
+				if flagTimeout > 0 {
+					return execWithTimeout(ctx, client, cluster, task, container, shell, profile)
+				}
+				return client.ExecInteractive(ctx, cluster, task, container, shell, profile)
+			},
+			func() (string, error) {
+				return client.GetRunningTask(ctx, cluster, service)
+			})
+		if err == nil || refreshes == maxSSORefreshes || !awsutil.IsSSOExpired(err) || !awsutil.IsSSO(profile) {
+			return explainECSError(err)
+		}
+
+		ui.PrintWarning("SSO session expired, re-authenticating...")
+		if ssoErr := awsutil.ForceSSOLogin(profile); ssoErr != nil {
+			return ssoErr
+		}
+		if client, err = newConnectClient(profile); err != nil {
+			return fmt.Errorf("failed to create AWS client: %w", err)
+		}
+		if task, err = client.GetRunningTask(ctx, cluster, service); err != nil {
+			return fmt.Errorf("no running task found after re-authenticating: %w", err)
+		}
+		ui.PrintStep("↻", fmt.Sprintf("Resuming session on task %s", task))
+	}
+}
+
+// explainECSError appends the remediation of a known ECS Exec error.
+func explainECSError(err error) error {
+	if hint := awsutil.InterpretECSError(err); hint != "" {
+		return fmt.Errorf("%w\n  %s", err, hint)
+	}
+	return err
+}
+
+// execAndRecord runs a connection and adds it to the replay history once
+// the session started, whatever the exit status of the shell. Connections
+// that could not start are never offered again.
+func execAndRecord(hist *history.Store, label string, args []string, run func() error) error {
+	err := run()
+	if hist != nil && sessionStarted(err) {
+		hist.Add("connect", label, args)
+		hist.Save() //nolint:errcheck
+	}
+	return err
+}
+
+// sessionStarted reports whether a session ending with err was opened:
+// credential errors and known ECS Exec errors mean it never started.
+func sessionStarted(err error) bool {
+	return err == nil || (!retry.IsAuthError(err) && awsutil.InterpretECSError(err) == "")
+}
+
+// openSessionTab starts an ECS Exec session in a new terminal tab so the
+// current terminal stays free for other sessions. cleanup is called when
+// the tab cannot be opened.
+func openSessionTab(commandLine []string, cleanup func(), err error) error {
+	if err != nil {
+		return err
+	}
+	if err := terminal.OpenTab(commandLine); err != nil {
+		cleanup()
+		return err
+	}
+	ui.PrintSuccess("Session opened in a new terminal tab")
+	return nil
 }
 
 // isCredentialError returns true if the error is related to AWS credentials/auth.
 func isCredentialError(err error) bool {
-	msg := err.Error()
-	return strings.Contains(msg, "get credentials") ||
-		strings.Contains(msg, "failed to refresh") ||
-		strings.Contains(msg, "expired") ||
-		strings.Contains(msg, "IMDS") ||
-		strings.Contains(msg, "security token") ||
-		strings.Contains(msg, "AccessDenied")
+	return retry.IsAuthError(err)
 }
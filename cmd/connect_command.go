@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/20uf/devcli/internal/ecs"
+)
+
+// checkCommandFlags rejects the session flags that --command replaces.
+func checkCommandFlags() error {
+	if flagExecCommand == "" {
+		return nil
+	}
+	conflicts := []struct {
+		name string
+		set  bool
+	}{
+		{"--shell", flagShell != ""},
+		{"--shell-detect", flagShellDetect},
+		{"--template", flagTemplate != ""},
+		{"--tunnel", flagTunnel},
+		{"--new-tab", flagNewTab},
+		{"--copy-env", len(flagCopyEnv) > 0},
+		{"--container all", isReportMode()},
+	}
+	for _, c := range conflicts {
+		if c.set {
+			return fmt.Errorf("--command cannot be combined with %s", c.name)
+		}
+	}
+	return nil
+}
+
+// runOneShotCommand runs --command in the target container, streaming only
+// its output, and fails with the command's exit status.
+func runOneShotCommand(ctx context.Context, client *ecs.Client, target connectTarget) error {
+	status, err := client.ExecStream(ctx, target.Cluster, target.Task, target.Container, flagExecCommand, target.Profile, os.Stdout)
+	if err != nil {
+		return err
+	}
+	if status != 0 {
+		return &exitError{err: fmt.Errorf("command exited with status %d", status), code: status}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import "testing"
+
+// Test: --command rejects the flags of interactive sessions
+func TestCheckCommandFlags(t *testing.T) {
+	defer func() {
+		flagExecCommand, flagShell, flagTunnel, flagContainer = "", "", false, ""
+	}()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr bool
+	}{
+		{"Command alone", func() {}, false},
+		{"With --shell", func() { flagShell = "/bin/bash" }, true},
+		{"With --tunnel", func() { flagTunnel = true }, true},
+		{"With --container all", func() { flagContainer = "all" }, true},
+		{"With a container", func() { flagContainer = "php" }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flagExecCommand, flagShell, flagTunnel, flagContainer = "php -v", "", false, ""
+			tt.setup()
+			if err := checkCommandFlags(); (err != nil) != tt.wantErr {
+				t.Errorf("checkCommandFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Log("✓ --command conflicts detected")
+}
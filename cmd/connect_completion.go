@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	awsutil "github.com/20uf/devcli/internal/aws"
+	"github.com/20uf/devcli/internal/config"
+	"github.com/20uf/devcli/internal/ecs"
+	"github.com/spf13/cobra"
+)
+
+// completionTimeout bounds the AWS calls made while completing a flag, so a
+// slow or expired session never hangs the shell.
+const completionTimeout = 5 * time.Second
+
+func init() {
+	connectCmd.RegisterFlagCompletionFunc("profile", completeProfiles) //nolint:errcheck
+	connectCmd.RegisterFlagCompletionFunc("cluster", completeClusters) //nolint:errcheck
+	connectCmd.RegisterFlagCompletionFunc("service", completeServices) //nolint:errcheck
+}
+
+// completeProfiles completes --profile with the profiles of ~/.aws/config.
+func completeProfiles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	profiles, err := awsutil.ListProfiles()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return filterPrefix(profiles, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeClusters completes --cluster with the clusters of the --profile
+// given before it.
+func completeClusters(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	client := completionClient()
+	if client == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+	clusters, err := client.ListClusters(ctx)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return filterPrefix(clusters, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeServices completes --service with the services of the --cluster
+// given before it.
+func completeServices(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	client := completionClient()
+	if client == nil || flagCluster == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+	services, err := client.ListServices(ctx, flagCluster)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	names := make([]string, len(services))
+	for i, s := range services {
+		names[i] = s.Name
+	}
+	return filterPrefix(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionClient creates an ECS client for the --profile being completed,
+// or nil when no profile was given. Unlike newConnectClient it prints
+// nothing, as completion output is parsed by the shell.
+func completionClient() *ecs.Client {
+	if flagProfile == "" {
+		return nil
+	}
+
+	cfg, _ := config.Load()
+	var creds *awsutil.Credentials
+	if roleARN := resolveRoleARN(flagRoleARN, cfg, flagProfile); roleARN != "" {
+		c, err := awsutil.AssumeRole(flagProfile, roleARN)
+		if err != nil {
+			return nil
+		}
+		creds = c
+	}
+
+	client, err := ecs.NewClientWithCredentials(flagProfile, flagRegion, creds)
+	if err != nil {
+		return nil
+	}
+	return client
+}
+
+// filterPrefix keeps the candidates starting with prefix.
+func filterPrefix(candidates []string, prefix string) []string {
+	var matches []string
+	for _, c := range candidates {
+		if strings.HasPrefix(c, prefix) {
+			matches = append(matches, c)
+		}
+	}
+	return matches
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// Test: --profile completes the profiles of the AWS config matching the prefix
+func TestCompleteProfiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	config := "[profile dev]\nregion = eu-west-1\n\n[profile prod]\nsso_session = corp\n\n[profile prod-ro]\n\n[sso-session corp]\nsso_start_url = https://corp.awsapps.com/start\n"
+	if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("AWS_CONFIG_FILE", path)
+
+	tests := []struct {
+		prefix string
+		want   []string
+	}{
+		{"", []string{"dev", "prod", "prod-ro"}},
+		{"pro", []string{"prod", "prod-ro"}},
+		{"staging", nil},
+	}
+
+	for _, tt := range tests {
+		got, directive := completeProfiles(connectCmd, nil, tt.prefix)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("completeProfiles(%q) = %v, want %v", tt.prefix, got, tt.want)
+		}
+		if directive != cobra.ShellCompDirectiveNoFileComp {
+			t.Errorf("directive = %v, want NoFileComp", directive)
+		}
+	}
+
+	t.Log("✓ Profiles completed")
+}
+
+// Test: clusters and services are not completed without a profile
+func TestCompleteClusters_NoProfile(t *testing.T) {
+	orig := flagProfile
+	defer func() { flagProfile = orig }()
+	flagProfile = ""
+
+	if got, _ := completeClusters(connectCmd, nil, ""); got != nil {
+		t.Errorf("completeClusters() = %v, want nil", got)
+	}
+	if got, _ := completeServices(connectCmd, nil, ""); got != nil {
+		t.Errorf("completeServices() = %v, want nil", got)
+	}
+
+	t.Log("✓ No AWS call without a profile")
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/20uf/devcli/internal/ecs"
+	"github.com/20uf/devcli/internal/ui"
+)
+
+// envKeyPattern matches the names of environment variables a shell can export.
+var envKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// checkCopyEnvKeys rejects --copy-env keys that are not variable names, as
+// they end up in the export lines of a script to source.
+func checkCopyEnvKeys(keys []string) error {
+	for _, key := range keys {
+		if !envKeyPattern.MatchString(key) {
+			return fmt.Errorf("invalid --copy-env key %q: use letters, digits and underscores, not starting with a digit", key)
+		}
+	}
+	return nil
+}
+
+// copyContainerEnv runs env in the target container and writes the values
+// of keys as export lines to ~/.devcli/env-<session>.sh, ready to be sourced
+// in the local shell. It only helps, so failures are warnings.
+func copyContainerEnv(ctx context.Context, client *ecs.Client, target connectTarget, keys []string) {
+	ui.PrintStep("◌", fmt.Sprintf("Reading %s from %s", strings.Join(keys, ", "), target.Container))
+	out, err := client.ExecCapture(ctx, target.Cluster, target.Task, target.Container, "env -0", target.Profile)
+	if err != nil {
+		ui.PrintWarning(fmt.Sprintf("Could not read the container environment: %s", err))
+		return
+	}
+
+	script, missing := envExports(parseEnvOutput(out), keys)
+	if len(missing) > 0 {
+		ui.PrintWarning(fmt.Sprintf("Not set in the container: %s", strings.Join(missing, ", ")))
+	}
+	if script == "" {
+		return
+	}
+
+	path, err := writeEnvFile(envSessionID(target.Task, time.Now()), script)
+	if err != nil {
+		ui.PrintWarning(fmt.Sprintf("Could not write the environment file: %s", err))
+		return
+	}
+	ui.PrintSuccess(fmt.Sprintf("Copied %d variable(s)", len(keys)-len(missing)))
+	fmt.Println(ui.MutedStyle.Render("  Run: source " + path))
+}
+
+// parseEnvOutput reads the NUL separated KEY=value entries printed by
+// env -0, so values spanning several lines are kept whole.
+func parseEnvOutput(out string) map[string]string {
+	values := make(map[string]string)
+	for _, entry := range strings.Split(out, "\x00") {
+		key, value, ok := strings.Cut(strings.TrimLeft(entry, "\r\n"), "=")
+		if ok && envKeyPattern.MatchString(key) {
+			values[key] = value
+		}
+	}
+	return values
+}
+
+// envExports returns the export lines of keys found in values, and the
+// sorted keys that are not set.
+func envExports(values map[string]string, keys []string) (string, []string) {
+	var b strings.Builder
+	var missing []string
+	for _, key := range keys {
+		value, ok := values[key]
+		if !ok {
+			missing = append(missing, key)
+			continue
+		}
+		fmt.Fprintf(&b, "export %s=%s\n", key, shellQuote(value))
+	}
+	sort.Strings(missing)
+	return b.String(), missing
+}
+
+// envSessionID names the environment file of a session after its task and
+// start time.
+func envSessionID(task string, now time.Time) string {
+	if len(task) > 8 {
+		task = task[:8]
+	}
+	return fmt.Sprintf("%s-%d", task, now.Unix())
+}
+
+// writeEnvFile writes script to ~/.devcli/env-<sessionID>.sh, readable by
+// the user only since it may hold secrets.
+func writeEnvFile(sessionID, script string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(home, ".devcli")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("env-%s.sh", sessionID))
+	if err := os.WriteFile(path, []byte(script), 0600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Test: Requested env vars become quoted export lines, missing ones are reported
+func TestEnvExports(t *testing.T) {
+	values := parseEnvOutput("\r\nAPP_ENV=prod\x00DATABASE_URL=mysql://u:p@db/app?x=1\x00GREETING=it's here\x00CERT=line1\nFAKE=line2\x00\r\n")
+
+	script, missing := envExports(values, []string{"DATABASE_URL", "GREETING", "MISSING", "APP_ENV", "CERT", "FAKE"})
+	want := "export DATABASE_URL='mysql://u:p@db/app?x=1'\n" +
+		"export GREETING='it'\\''s here'\n" +
+		"export APP_ENV='prod'\n" +
+		"export CERT='line1\nFAKE=line2'\n"
+	if script != want {
+		t.Errorf("envExports() script = %q, want %q", script, want)
+	}
+	if !reflect.DeepEqual(missing, []string{"FAKE", "MISSING"}) {
+		t.Errorf("envExports() missing = %v, want [FAKE MISSING]", missing)
+	}
+
+	t.Log("✓ Env vars exported")
+}
+
+// Test: --copy-env keys must be variable names
+func TestCheckCopyEnvKeys(t *testing.T) {
+	if err := checkCopyEnvKeys([]string{"APP_ENV", "_private", "db2"}); err != nil {
+		t.Errorf("checkCopyEnvKeys() error = %v", err)
+	}
+	for _, key := range []string{"2FA", "A-B", "X;rm -rf ~", ""} {
+		if err := checkCopyEnvKeys([]string{key}); err == nil {
+			t.Errorf("checkCopyEnvKeys(%q) accepted", key)
+		}
+	}
+
+	t.Log("✓ --copy-env keys validated")
+}
+
+// Test: The env file is private and named after the session
+func TestWriteEnvFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	id := envSessionID("0123456789abcdef", time.Unix(1700000000, 0))
+	if id != "01234567-1700000000" {
+		t.Errorf("envSessionID() = %q", id)
+	}
+
+	path, err := writeEnvFile(id, "export APP_ENV='prod'\n")
+	if err != nil {
+		t.Fatalf("writeEnvFile() error = %v", err)
+	}
+	if !strings.HasSuffix(path, "/.devcli/env-01234567-1700000000.sh") {
+		t.Errorf("writeEnvFile() path = %s", path)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Env file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	t.Log("✓ Env file written")
+}
@@ -3,17 +3,21 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strings"
-	"time"
 
+	awsutil "github.com/20uf/devcli/internal/aws"
+	devconfig "github.com/20uf/devcli/internal/config"
 	"github.com/20uf/devcli/internal/connection/application"
 	"github.com/20uf/devcli/internal/connection/domain"
 	"github.com/20uf/devcli/internal/connection/infra"
+	"github.com/20uf/devcli/internal/ecs"
 	"github.com/20uf/devcli/internal/history"
 	"github.com/20uf/devcli/internal/ui"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
 	ecsv2 "github.com/aws/aws-sdk-go-v2/service/ecs"
 	"github.com/spf13/cobra"
 )
@@ -24,9 +28,16 @@ type ConnectHandler struct {
 	orchestrator *application.ConnectOrchestrator
 	repos        *domain.AllRepositories
 	history      *history.Store
-	profile      string // AWS profile for SSO
+	profile      string   // AWS profile for SSO
+	env          []string // assumed role credentials for the aws CLI
+	region       string   // region passed to the aws CLI with assumed role credentials
+	awsOpts      []func(*config.LoadOptions) error
 }
 
+// maxSSORefreshes bounds the SSO logins of a single connection, so a login
+// that does not fix the session cannot loop.
+const maxSSORefreshes = 3
+
 // NewConnectHandler creates a handler with all dependencies wired.
 func NewConnectHandler(ctx context.Context, profile, region string) (*ConnectHandler, error) {
 	// Auto-detect default profile if not provided
@@ -43,30 +54,58 @@ func NewConnectHandler(ctx context.Context, profile, region string) (*ConnectHan
 		opts = append(opts, config.WithRegion(region))
 	}
 
+	creds, err := assumeConnectRole(profile)
+	if err != nil {
+		return nil, err
+	}
+	if creds != nil {
+		opts = append(opts, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(creds.AccessKeyID, creds.SecretAccessKey, creds.SessionToken)))
+	}
+
 	cfg, err := config.LoadDefaultConfig(ctx, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load AWS config: %w", err)
 	}
 
-	ecsClient := ecsv2.NewFromConfig(cfg)
-
 	// Step 2: Create repositories (infrastructure layer)
-	repos := &domain.AllRepositories{
-		Clusters:    infra.NewECSClusterRepository(ecsClient),
-		Services:    infra.NewECSServiceRepository(ecsClient),
-		Tasks:       infra.NewECSTaskRepository(ecsClient),
-		Connections: &infra.NoOpConnectionRepository{}, // TODO: use FileConnectionRepository
-	}
+	repos := newECSRepositories(ecsv2.NewFromConfig(cfg))
 
 	// Step 3: Load history for replay
 	hist, _ := history.Load()
 
-	return &ConnectHandler{
+	handler := &ConnectHandler{
 		orchestrator: application.NewConnectOrchestrator(repos),
 		repos:        repos,
 		history:      hist,
 		profile:      profile,
-	}, nil
+		awsOpts:      opts,
+	}
+	if creds != nil {
+		handler.env = creds.Env()
+		handler.region = cfg.Region
+	}
+	return handler, nil
+}
+
+// preferredContainers returns the container names auto-selected in a task:
+// connect.preferred_containers, domain.DefaultPreferredContainers when unset.
+func preferredContainers() []string {
+	if cfg, err := devconfig.Load(); err == nil && len(cfg.Connect.PreferredContainers) > 0 {
+		return cfg.Connect.PreferredContainers
+	}
+	return domain.DefaultPreferredContainers
+}
+
+// newECSRepositories wires the ECS repositories, with the preferred
+// containers of connect.preferred_containers.
+func newECSRepositories(ecsClient *ecsv2.Client) *domain.AllRepositories {
+	return &domain.AllRepositories{
+		Clusters:    infra.NewECSClusterRepository(ecsClient),
+		Services:    infra.NewECSServiceRepository(ecsClient),
+		Tasks:       infra.NewECSTaskRepository(ecsClient, preferredContainers()...),
+		Connections: &infra.NoOpConnectionRepository{}, // TODO: use FileConnectionRepository
+	}
 }
 
 // Handle orchestrates the complete connection flow.
@@ -138,16 +177,16 @@ func (h *ConnectHandler) interactiveFlow(ctx context.Context, clusterFlag, servi
 		return err
 	}
 
-	serviceNames := make([]string, len(services))
+	serviceInfos := make([]ecs.ServiceInfo, len(services))
 	for i, s := range services {
-		serviceNames[i] = s.Name()
+		serviceInfos[i] = ecs.ServiceInfo{Name: s.Name(), TaskDefinition: s.TaskDefinition(), Image: s.Image()}
 	}
 
 	if serviceFlag != "" {
-		serviceNames = []string{serviceFlag}
+		serviceInfos = []ecs.ServiceInfo{{Name: serviceFlag}}
 	}
 
-	selectedServiceName, err := ui.Select("Select service", serviceNames)
+	selectedServiceName, err := ui.SelectWithOptions("Select service", serviceOptions(serviceInfos))
 	if err != nil {
 		return nil // User pressed ESC
 	}
@@ -189,7 +228,7 @@ func (h *ConnectHandler) interactiveFlow(ctx context.Context, clusterFlag, servi
 		Service:      service,
 		Task:         task,
 		Container:    container,
-		ShellCommand: h.resolveShell(shellFlag),
+		ShellCommand: h.resolveShell(shellFlag, cluster.Name(), service.Name()),
 	})
 	if err != nil {
 		return err
@@ -198,47 +237,120 @@ func (h *ConnectHandler) interactiveFlow(ctx context.Context, clusterFlag, servi
 	return h.executeConnection(ctx, conn)
 }
 
-// executeConnection saves to history and executes the AWS CLI command.
+// executeConnection executes the AWS CLI command and saves the connection to
+// history once the session started.
 func (h *ConnectHandler) executeConnection(ctx context.Context, conn domain.Connection) error {
-	// Save to history for replay
-	if h.history != nil {
-		label := conn.String()
-		h.history.Add("connect", label, []string{
-			"--cluster", conn.Cluster().Name(),
-			"--service", conn.Service().Name(),
-			"--container", conn.Container().Name(),
+	ui.PrintStep("▶", fmt.Sprintf("Connecting to %s", conn.String()))
+
+	args := []string{
+		"--cluster", conn.Cluster().Name(),
+		"--service", conn.Service().Name(),
+		"--container", conn.Container().Name(),
+	}
+
+	// Save to history for replay once the session started
+	return execAndRecord(h.history, conn.String(), args, func() error {
+		taskID := conn.Task().ID()
+		for refreshes := 0; ; refreshes++ {
+			err := h.runWithReconnect(ctx, conn, taskID)
+			if err == nil || refreshes == maxSSORefreshes || !h.canRefreshSSO(err) {
+				return err
+			}
+			// The SSO token expired during the session: log in again and
+			// resume on the service's current task, which may have changed.
+			if taskID, err = h.refreshSSO(ctx, conn); err != nil {
+				return err
+			}
+		}
+	})
+}
+
+// runWithReconnect runs the session on taskID, reconnecting to the
+// service's current task when --auto-reconnect is set.
+func (h *ConnectHandler) runWithReconnect(ctx context.Context, conn domain.Connection, taskID string) error {
+	if flagNewTab {
+		return h.runSession(conn, taskID)
+	}
+	return newReconnector().Run(ctx, taskID,
+		func(taskID string) error {
+			return h.runSession(conn, taskID)
+		},
+		func() (string, error) {
+			task, err := h.repos.Tasks.GetRunningTask(ctx, conn.Cluster(), conn.Service())
+			return task.ID(), err
 		})
-		h.history.Save() //nolint:errcheck
+}
+
+// canRefreshSSO reports whether a session error is an expired SSO session
+// that a new login fixes. Assumed role credentials are static and a session
+// in another tab is out of reach, so neither is refreshed.
+func (h *ConnectHandler) canRefreshSSO(err error) bool {
+	return !flagNewTab && h.profile != "" && h.env == nil &&
+		awsutil.IsSSOExpired(err) && awsutil.IsSSO(h.profile)
+}
+
+// refreshSSO logs in to the profile again, recreates the ECS client with the
+// new token and returns the service's current task to resume the session on.
+func (h *ConnectHandler) refreshSSO(ctx context.Context, conn domain.Connection) (string, error) {
+	ui.PrintWarning("SSO session expired, re-authenticating...")
+	if err := awsutil.ForceSSOLogin(h.profile); err != nil {
+		return "", err
 	}
 
-	ui.PrintStep("▶", fmt.Sprintf("Connecting to %s", conn.String()))
+	cfg, err := config.LoadDefaultConfig(ctx, h.awsOpts...)
+	if err != nil {
+		return "", fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	h.repos = newECSRepositories(ecsv2.NewFromConfig(cfg))
+	h.orchestrator = application.NewConnectOrchestrator(h.repos)
+
+	task, err := h.repos.Tasks.GetRunningTask(ctx, conn.Cluster(), conn.Service())
+	if err != nil {
+		return "", fmt.Errorf("no running task found after re-authenticating: %w", err)
+	}
+	ui.PrintStep("↻", fmt.Sprintf("Resuming session on task %s", task.ID()))
+	return task.ID(), nil
+}
 
+// runSession runs the ECS Exec session for a connection on the given task.
+func (h *ConnectHandler) runSession(conn domain.Connection, taskID string) error {
 	// Execute AWS CLI command via ECS Exec
 	// Build AWS SSM session command for ECS container
 	args := []string{
 		"ecs", "execute-command",
 		"--cluster", conn.Cluster().Name(),
-		"--task", conn.Task().ID(),
+		"--task", taskID,
 		"--container", conn.Container().Name(),
 		"--interactive",
 		"--command", conn.ShellCommand(),
 	}
 
-	// Add profile if specified
-	if h.profile != "" {
+	// Add profile if specified; assumed role credentials replace it
+	if h.profile != "" && h.env == nil {
 		args = append(args, "--profile", h.profile)
 	}
+	if h.region != "" {
+		args = append(args, "--region", h.region)
+	}
+
+	if flagNewTab {
+		return openSessionTab(ecs.EnvFileCommand(append([]string{"aws"}, args...), h.env))
+	}
 
 	cmd := exec.Command("aws", args...)
+	if h.env != nil {
+		cmd.Env = append(os.Environ(), h.env...)
+	}
 
 	// Attach stdin/stdout/stderr for interactive session
+	var stderr strings.Builder
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	cmd.Stderr = io.MultiWriter(os.Stderr, &stderr)
 
 	// Execute and return result
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("connection failed: %w", err)
+		return fmt.Errorf("connection failed: %w", explainECSError(ecs.SessionError(err, stderr.String())))
 	}
 
 	return nil
@@ -322,14 +434,14 @@ func (h *ConnectHandler) showHistoryMenu() (*domain.Connection, error) {
 }
 
 // detectDefaultProfile finds a default AWS profile for SSO.
-// Priority: playiad-dev > playiad-testing > playiad-preprod > first SSO profile
+// Priority: the environments of the config in file order > first SSO profile
 func detectDefaultProfile() string {
-	priorities := []string{"playiad-dev", "playiad-testing", "playiad-preprod"}
-
-	// Check prioritized profiles
-	for _, p := range priorities {
-		if isValidProfile(p) {
-			return p
+	// Check the profiles mapped to environments
+	if cfg, err := devconfig.Load(); err == nil {
+		for _, env := range cfg.Environments {
+			if isValidProfile(env.Profile) {
+				return env.Profile
+			}
 		}
 	}
 
@@ -382,21 +494,7 @@ func (h *ConnectHandler) ensureSSO(ctx context.Context) error {
 	}
 
 	// Show loader while checking SSO
-	spinner := []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
-	spinnerIdx := 0
-	done := make(chan bool)
-	go func() {
-		for {
-			select {
-			case <-done:
-				return
-			default:
-				fmt.Printf("\r%s Checking AWS credentials...", ui.MutedStyle.Render(spinner[spinnerIdx%len(spinner)]))
-				spinnerIdx++
-				time.Sleep(100 * time.Millisecond)
-			}
-		}
-	}()
+	spin := ui.StartSpinner("Checking AWS credentials...")
 
 	// Check if SSO credentials are valid by attempting a test AWS call
 	checkCmd := exec.CommandContext(ctx, "aws", "sts", "get-caller-identity", "--profile", h.profile)
@@ -404,8 +502,7 @@ func (h *ConnectHandler) ensureSSO(ctx context.Context) error {
 	checkCmd.Stderr = nil
 
 	err := checkCmd.Run()
-	close(done)
-	fmt.Print("\r\033[K") // Clear line
+	spin.Stop()
 
 	if err == nil {
 		return nil // Already authenticated
@@ -438,10 +535,19 @@ func (h *ConnectHandler) ensureSSO(ctx context.Context) error {
 	return nil
 }
 
-// resolveShell returns the shell command to use.
-func (h *ConnectHandler) resolveShell(flagShell string) string {
+// resolveShell returns the shell command to use: the flag, then the
+// connect.shells rule of the service, then the configured default.
+func (h *ConnectHandler) resolveShell(flagShell, cluster, service string) string {
 	if flagShell != "" {
 		return flagShell
 	}
-	return "su -s /bin/sh www-data"
+	if cfg, err := devconfig.Load(); err == nil {
+		if shell, ok := cfg.ServiceShell(cluster, service); ok {
+			return shell
+		}
+		if shell, ok := cfg.DefaultShell(h.profile); ok {
+			return shell
+		}
+	}
+	return defaultShell
 }
@@ -2,8 +2,13 @@ package cmd
 
 import (
 	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/20uf/devcli/internal/history"
 	"github.com/spf13/cobra"
 )
 
@@ -116,3 +121,124 @@ func TestConnectHandler_WithProfile(t *testing.T) {
 
 	t.Log("✓ Profile parameter handling")
 }
+
+// Test: Only connections whose session started become replayable history entries
+func TestExecAndRecord(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	hist, err := history.Load()
+	if err != nil {
+		t.Fatalf("Failed to load history: %v", err)
+	}
+	args := []string{"--profile", "dev", "--cluster", "c", "--service", "s", "--container", "app"}
+
+	runErr := errors.New("TargetNotConnectedException")
+	if err := execAndRecord(hist, "dev → c/s/app", args, func() error { return runErr }); !errors.Is(err, runErr) {
+		t.Fatalf("Expected the exec error, got %v", err)
+	}
+	if len(hist.Labels("connect")) != 0 {
+		t.Fatalf("Failed connection should not be recorded")
+	}
+
+	// A shell exiting with a non-zero status still had a session
+	exitErr := errors.New("exit status 1")
+	if err := execAndRecord(hist, "dev → c/s/app", args, func() error { return exitErr }); !errors.Is(err, exitErr) {
+		t.Fatalf("Expected the exit status, got %v", err)
+	}
+
+	reloaded, err := history.Load()
+	if err != nil {
+		t.Fatalf("Failed to reload history: %v", err)
+	}
+	if entry := reloaded.FindByLabel("connect", "dev → c/s/app"); entry == nil {
+		t.Errorf("Started connection should be saved to history")
+	}
+
+	t.Log("✓ Failed connections are not replayable")
+}
+
+// Test: Replaying a connection whose profile was removed fails early
+func TestCheckReplayProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte("[profile dev]\nregion = eu-west-1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("AWS_CONFIG_FILE", path)
+	credentials := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(credentials, []byte("[ci]\naws_access_key_id = AKIA\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", credentials)
+
+	for _, profile := range []string{"dev", "ci"} {
+		if err := checkReplayProfile(profile); err != nil {
+			t.Errorf("Existing profile %s rejected: %v", profile, err)
+		}
+	}
+	if err := checkReplayProfile(""); err != nil {
+		t.Errorf("Entry without profile rejected: %v", err)
+	}
+	err := checkReplayProfile("old-prod")
+	if err == nil || !strings.Contains(err.Error(), "without --last") {
+		t.Errorf("Expected a hint to reconnect interactively, got %v", err)
+	}
+
+	// Without a config file, the AWS SDK reports the profile itself
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "missing"))
+	if err := checkReplayProfile("old-prod"); err != nil {
+		t.Errorf("Missing config file should not fail the replay: %v", err)
+	}
+
+	t.Log("✓ Removed profiles detected before replay")
+}
+
+// Test: --env resolves to the profile mapped in config
+func TestResolveEnvProfile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, ".devcli"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	data := "environments:\n  dev: acme-dev\n  prod: acme-prod\n"
+	if err := os.WriteFile(filepath.Join(home, ".devcli", "config.yaml"), []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	oldEnv, oldProfile := flagConnectEnv, flagProfile
+	defer func() { flagConnectEnv, flagProfile = oldEnv, oldProfile }()
+
+	flagConnectEnv, flagProfile = "prod", ""
+	if err := resolveEnvProfile(); err != nil || flagProfile != "acme-prod" {
+		t.Errorf("resolveEnvProfile() = %v, profile %q, want acme-prod", err, flagProfile)
+	}
+
+	flagConnectEnv, flagProfile = "staging", ""
+	err := resolveEnvProfile()
+	if err == nil || !strings.Contains(err.Error(), "Available: dev, prod") {
+		t.Errorf("Expected the available environments, got %v", err)
+	}
+
+	flagConnectEnv, flagProfile = "prod", "other"
+	if err := resolveEnvProfile(); err == nil {
+		t.Error("Expected --env and --profile to conflict")
+	}
+
+	t.Log("✓ Environment resolved to its profile")
+}
+
+// Test: Profiles are listed with their region when one is configured
+func TestProfileOptions(t *testing.T) {
+	regions := map[string]string{"dev": "eu-west-1", "prod": "us-east-1"}
+	options := profileOptions([]string{"dev", "legacy", "prod"}, func(p string) string { return regions[p] })
+
+	want := []string{"dev (eu-west-1)", "legacy", "prod (us-east-1)"}
+	for i, opt := range options {
+		if opt.Display != want[i] {
+			t.Errorf("options[%d].Display = %q, want %q", i, opt.Display, want[i])
+		}
+	}
+	if options[0].Value != "dev" {
+		t.Errorf("options[0].Value = %q, want the bare profile name", options[0].Value)
+	}
+
+	t.Log("✓ Profiles shown with their region")
+}
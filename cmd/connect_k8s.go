@@ -0,0 +1,347 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/20uf/devcli/internal/connection/domain"
+	"github.com/20uf/devcli/internal/connection/infra"
+	"github.com/20uf/devcli/internal/history"
+	"github.com/20uf/devcli/internal/ui"
+	"github.com/20uf/devcli/internal/verbose"
+	"github.com/spf13/cobra"
+)
+
+// checkK8sFlags rejects the connect flags that only exist for ECS.
+func checkK8sFlags() error {
+	conflicts := []struct {
+		name string
+		set  bool
+	}{
+		{"--all-regions", flagAllRegions},
+		{"--json-task", flagJSONTask},
+		{"--list", flagConnectList},
+		{"--role-arn", flagRoleARN != ""},
+		{"--tunnel", flagTunnel},
+		{"--new-tab", flagNewTab},
+		{"--auto-reconnect", flagAutoReconnect},
+		{"--shell-detect", flagShellDetect},
+		{"--copy-env", len(flagCopyEnv) > 0},
+		{"--record", flagRecord != ""},
+		{"--timeout", flagTimeout > 0},
+		{"--profile auto", flagProfile == profileAuto},
+		{"--container all", isReportMode()},
+	}
+	for _, c := range conflicts {
+		if c.set {
+			return fmt.Errorf("%s is not supported with --provider %s", c.name, infra.ProviderK8s)
+		}
+	}
+	return nil
+}
+
+// runK8sConnect opens a shell in a Kubernetes pod through kubectl exec. The
+// steps mirror the ECS ones: --profile is the kubectl context, --cluster the
+// namespace and --service the workload whose first running pod is used.
+func runK8sConnect(cmd *cobra.Command) error {
+	if _, err := verbose.LookPath("kubectl"); err != nil {
+		return fmt.Errorf("kubectl is required with --provider k8s.\n  Install: https://kubernetes.io/docs/tasks/tools/")
+	}
+	if err := checkK8sFlags(); err != nil {
+		return err
+	}
+	if err := checkCommandFlags(); err != nil {
+		return err
+	}
+
+	if flagConnectLast {
+		return replayLastConnect()
+	}
+
+	// Show history if no flags
+	if flagProfile == "" && flagCluster == "" && flagService == "" {
+		entry, err := showConnectHistory()
+		if err != nil {
+			return err
+		}
+		if entry != nil {
+			return replayConnectEntry(entry)
+		}
+	}
+
+	// Step-based navigation: ESC goes back to previous step
+	var kubeContext string
+	var repos *domain.AllRepositories
+	var namespace domain.Cluster
+	var workload domain.Service
+	var pod domain.Task
+	var container string
+
+	step := 0
+	for {
+		switch step {
+		case 0: // Select context
+			c, err := selectK8sContext(cmd.Context())
+			if err != nil {
+				return err // ESC at first step → back to home
+			}
+			kubeContext = c
+			repos = infra.CreateK8sRepositories(kubeContext)
+			step += 2
+
+		case 2: // Select namespace
+			ns, err := selectK8sNamespace(cmd.Context(), repos)
+			if err != nil {
+				if flagProfile != "" {
+					return err
+				}
+				step = 0 // ESC → back to context
+				continue
+			}
+			namespace = ns
+			step++
+
+		case 3: // Select workload
+			w, err := selectK8sWorkload(cmd.Context(), repos, namespace)
+			if err != nil {
+				if flagCluster != "" {
+					return err
+				}
+				if errors.Is(err, domain.ErrNoServiceFound) {
+					ui.PrintWarning(fmt.Sprintf("No running pod in namespace %s", namespace.Name()))
+				}
+				step = 2 // ESC → back to namespace
+				continue
+			}
+			workload = w
+			step++
+
+		case 4: // Get pod + select container
+			p, err := repos.Tasks.GetRunningTask(cmd.Context(), namespace, workload)
+			if err != nil {
+				if flagService != "" {
+					return fmt.Errorf("no running pod found: %w", err)
+				}
+				ui.PrintWarning(fmt.Sprintf("No running pod for %s: %s", workload.Name(), err))
+				step = 3 // back to workload
+				continue
+			}
+			pod = p
+
+			cont, err := selectK8sContainer(pod)
+			if err != nil {
+				step = 3 // ESC → back to workload
+				continue
+			}
+			container = cont
+			step++
+
+		case 5: // Execute
+			target := connectTarget{
+				Profile: kubeContext, Cluster: namespace.Name(), Service: workload.Name(),
+				Task: pod.ID(), Container: container, Provider: infra.ProviderK8s,
+			}
+			if flagExecCommand != "" {
+				return runK8sCommand(cmd.Context(), target)
+			}
+
+			hist, _ := history.Load()
+			label := fmt.Sprintf("k8s %s → %s/%s/%s", kubeContext, target.Cluster, target.Service, container)
+			histArgs := []string{
+				"--provider", infra.ProviderK8s, "--profile", kubeContext,
+				"--cluster", target.Cluster, "--service", target.Service, "--container", container,
+			}
+			return execAndRecord(hist, label, histArgs, func() error {
+				return execK8sSession(cmd.Context(), target)
+			})
+		}
+	}
+}
+
+// replayK8sEntry reconnects to the current pod of a recorded workload.
+func replayK8sEntry(ctx context.Context, entry *history.Entry, target connectTarget) error {
+	if _, err := verbose.LookPath("kubectl"); err != nil {
+		return fmt.Errorf("kubectl is required to replay %s.\n  Install: https://kubernetes.io/docs/tasks/tools/", entry.Label)
+	}
+	ui.PrintStep("↻", fmt.Sprintf("Replaying: %s", entry.Label))
+
+	namespace, err := domain.NewCluster(target.Cluster)
+	if err != nil {
+		return err
+	}
+	workload, err := domain.NewService(target.Service)
+	if err != nil {
+		return err
+	}
+	pod, err := infra.NewK8sTaskRepository(target.Profile).GetRunningTask(ctx, namespace, workload)
+	if err != nil {
+		return fmt.Errorf("no running pod found: %w", err)
+	}
+	target.Task = pod.ID()
+
+	if flagExecCommand != "" {
+		return runK8sCommand(ctx, target)
+	}
+	return execK8sSession(ctx, target)
+}
+
+func selectK8sContext(ctx context.Context) (string, error) {
+	if flagProfile != "" {
+		return flagProfile, nil
+	}
+
+	contexts, err := infra.ListK8sContexts(ctx)
+	if err != nil {
+		return "", err
+	}
+	switch len(contexts) {
+	case 0:
+		return "", fmt.Errorf("no kubectl context found\n  Run: kubectl config get-contexts")
+	case 1:
+		fmt.Printf("Using kubectl context: %s\n", contexts[0])
+		return contexts[0], nil
+	}
+	return ui.Select("Select kubectl context", contexts)
+}
+
+func selectK8sNamespace(ctx context.Context, repos *domain.AllRepositories) (domain.Cluster, error) {
+	if flagCluster != "" {
+		return domain.NewCluster(flagCluster)
+	}
+
+	spin := ui.StartSpinner("Loading namespaces…")
+	clusters, err := repos.Clusters.ListClusters(ctx)
+	spin.Stop()
+	if err != nil {
+		return domain.Cluster{}, err
+	}
+	names := make([]string, len(clusters))
+	for i, c := range clusters {
+		names[i] = c.Name()
+	}
+
+	selected, err := ui.Select("Select namespace", names)
+	if err != nil {
+		return domain.Cluster{}, err
+	}
+	return domain.NewCluster(selected)
+}
+
+func selectK8sWorkload(ctx context.Context, repos *domain.AllRepositories, namespace domain.Cluster) (domain.Service, error) {
+	if flagService != "" {
+		return domain.NewService(flagService)
+	}
+
+	spin := ui.StartSpinner("Loading workloads…")
+	services, err := repos.Services.ListServices(ctx, namespace)
+	spin.Stop()
+	if err != nil {
+		return domain.Service{}, err
+	}
+	options := make([]ui.SelectOption, len(services))
+	for i, s := range services {
+		options[i] = ui.SelectOption{Display: s.Name(), Value: s.Name()}
+		if s.Image() != "" {
+			options[i].Display = fmt.Sprintf("%s (%s)", s.Name(), s.Image())
+		}
+	}
+
+	selected, err := ui.SelectWithOptions("Select workload", options)
+	if err != nil {
+		return domain.Service{}, err
+	}
+	return domain.NewService(selected)
+}
+
+func selectK8sContainer(pod domain.Task) (string, error) {
+	if flagContainer != "" {
+		return flagContainer, nil
+	}
+
+	containers := pod.Containers()
+	if len(containers) == 0 {
+		return "", domain.ErrNoContainerFound
+	}
+	if len(containers) == 1 {
+		return containers[0].Name(), nil
+	}
+
+	names := make([]string, len(containers))
+	for i, c := range containers {
+		names[i] = c.Name()
+	}
+	return ui.Select("Select container", names)
+}
+
+// k8sSessionCommand returns the command line of the session. Templates run
+// through sh -c so their quoting is kept.
+func k8sSessionCommand(target connectTarget) ([]string, error) {
+	command, err := sessionCommand(target)
+	if err != nil {
+		return nil, err
+	}
+	if flagTemplate != "" {
+		return []string{"sh", "-c", command}, nil
+	}
+	return strings.Fields(command), nil
+}
+
+// execK8sSession attaches the terminal to a shell in the target pod.
+func execK8sSession(ctx context.Context, target connectTarget) error {
+	command, err := k8sSessionCommand(target)
+	if err != nil {
+		return err
+	}
+
+	ui.PrintStep("▶", fmt.Sprintf("Connecting to %s/%s/%s", target.Cluster, target.Task, target.Container))
+	c := verbose.Cmd(exec.CommandContext(ctx, "kubectl",
+		infra.K8sExecArgs(target.Profile, target.Cluster, target.Task, target.Container, true, command...)...))
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	return c.Run()
+}
+
+// k8sExitStatus matches the line kubectl prints on stderr when the remote
+// command exits with a non-zero status. kubectl exits with that status too,
+// but also with 1 when it fails itself.
+var k8sExitStatus = regexp.MustCompile(`command terminated with exit code (\d+)`)
+
+// runK8sCommand runs --command in the target pod and fails with the
+// command's exit status.
+func runK8sCommand(ctx context.Context, target connectTarget) error {
+	var stderr bytes.Buffer
+	c := verbose.Cmd(exec.CommandContext(ctx, "kubectl",
+		infra.K8sExecArgs(target.Profile, target.Cluster, target.Task, target.Container, false, "sh", "-c", flagExecCommand)...))
+	c.Stdout = os.Stdout
+	c.Stderr = io.MultiWriter(os.Stderr, &stderr)
+
+	return k8sCommandError(c.Run(), stderr.String())
+}
+
+// k8sCommandError returns the exit status of the remote command reported by
+// kubectl, or the failure of kubectl itself with its last error line.
+func k8sCommandError(err error, stderr string) error {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return err
+	}
+	if matches := k8sExitStatus.FindAllStringSubmatch(stderr, -1); len(matches) > 0 {
+		status, _ := strconv.Atoi(matches[len(matches)-1][1])
+		return &exitError{err: fmt.Errorf("command exited with status %d", status), code: status}
+	}
+
+	lines := strings.Split(strings.TrimSpace(stderr), "\n")
+	if last := strings.TrimSpace(lines[len(lines)-1]); last != "" {
+		return fmt.Errorf("kubectl exec failed: %s", last)
+	}
+	return fmt.Errorf("kubectl exec failed: %w", err)
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// Test: The command's exit status is told apart from kubectl failures
+func TestK8sCommandError(t *testing.T) {
+	runErr := exec.Command("sh", "-c", "exit 1").Run()
+
+	err := k8sCommandError(runErr, "php: not found\ncommand terminated with exit code 127\n")
+	var exitErr *exitError
+	if !errors.As(err, &exitErr) || exitErr.code != 127 {
+		t.Errorf("k8sCommandError() = %v, want the command status 127", err)
+	}
+
+	err = k8sCommandError(runErr, "Error from server (NotFound): pods \"api-0\" not found\n")
+	if errors.As(err, &exitErr) || !strings.Contains(err.Error(), "kubectl exec failed: Error from server (NotFound)") {
+		t.Errorf("k8sCommandError() = %v, want a kubectl failure", err)
+	}
+
+	if err := k8sCommandError(nil, ""); err != nil {
+		t.Errorf("k8sCommandError(nil) = %v", err)
+	}
+
+	t.Log("✓ kubectl failures separated from the command status")
+}
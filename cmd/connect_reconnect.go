@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/20uf/devcli/internal/retry"
+	"github.com/20uf/devcli/internal/ui"
+)
+
+// defaultReconnectDelay is the pause before re-fetching the task of a dropped session.
+const defaultReconnectDelay = 5 * time.Second
+
+// reconnector restarts a dropped ECS Exec session on the current running task.
+type reconnector struct {
+	maxRetries int
+	delay      time.Duration
+	sleep      func(time.Duration)
+}
+
+// newReconnector builds a reconnector from the --auto-reconnect flags.
+// Without --auto-reconnect no retry is attempted.
+func newReconnector() reconnector {
+	retries := flagMaxRetries
+	if !flagAutoReconnect {
+		retries = 0
+	}
+	return reconnector{maxRetries: retries, delay: defaultReconnectDelay, sleep: time.Sleep}
+}
+
+// droppedSessionMarkers are substrings the session manager plugin prints
+// when the connection to the task is lost rather than closed by the shell.
+var droppedSessionMarkers = []string{
+	"cannot perform start session",
+	"websocket",
+	"targetnotconnected",
+	"broken pipe",
+	"eof",
+}
+
+// isDroppedSession reports whether a session ended because the connection
+// dropped. A shell exiting with a non-zero status, a cancelled session and
+// credential errors are final.
+func isDroppedSession(err error) bool {
+	if err == nil || retry.IsAuthError(err) {
+		return false
+	}
+	if retry.IsTransient(err) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, m := range droppedSessionMarkers {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// Run starts the session on task and, when the connection drops, waits,
+// re-fetches the running task (a replacement task may have a new ID) and
+// starts it again. Cancelling ctx stops the retries.
+func (r reconnector) Run(ctx context.Context, task string, session func(task string) error, refetch func() (string, error)) error {
+	err := session(task)
+	if !isDroppedSession(err) {
+		return err
+	}
+	for attempt := 1; attempt <= r.maxRetries; attempt++ {
+		if ctx.Err() != nil {
+			return err
+		}
+		ui.PrintWarning(fmt.Sprintf("Reconnecting (attempt %d/%d)...", attempt, r.maxRetries))
+		r.sleep(r.delay)
+		if ctx.Err() != nil {
+			return err
+		}
+
+		newTask, fetchErr := refetch()
+		if fetchErr != nil {
+			err = fmt.Errorf("no running task found: %w", fetchErr)
+			continue
+		}
+		task = newTask
+		if err = session(task); !isDroppedSession(err) {
+			return err
+		}
+	}
+
+	if r.maxRetries > 0 {
+		return fmt.Errorf("connection lost after %d reconnect attempts: %w", r.maxRetries, err)
+	}
+	return err
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Test: reconnector retries dropped sessions on the refetched task
+func TestReconnector_Run(t *testing.T) {
+	dropped := errors.New("exit status 255: websocket: close 1006 (abnormal closure)")
+
+	tests := []struct {
+		name        string
+		maxRetries  int
+		failures    int
+		sessionErr  error
+		refetchErr  error
+		wantErr     bool
+		wantTasks   []string
+		wantRetries int
+	}{
+		{"succeeds first time", 3, 0, dropped, nil, false, []string{"task-0"}, 0},
+		{"reconnects on new task", 3, 2, dropped, nil, false, []string{"task-0", "task-1", "task-2"}, 2},
+		{"gives up after max retries", 3, 10, dropped, nil, true, []string{"task-0", "task-1", "task-2", "task-3"}, 3},
+		{"disabled does not retry", 0, 1, dropped, nil, true, []string{"task-0"}, 0},
+		{"no running task", 2, 1, dropped, errors.New("no tasks"), true, []string{"task-0"}, 2},
+		{"shell exit status is final", 3, 1, errors.New("exit status 1"), nil, true, []string{"task-0"}, 0},
+		{"expired credentials are final", 3, 1, errors.New("exit status 255: the SSO session has expired"), nil, true, []string{"task-0"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sessions []string
+			var sleeps int
+			refetches := 0
+
+			r := reconnector{
+				maxRetries: tt.maxRetries,
+				delay:      time.Second,
+				sleep:      func(time.Duration) { sleeps++ },
+			}
+			err := r.Run(context.Background(), "task-0",
+				func(task string) error {
+					sessions = append(sessions, task)
+					if len(sessions) <= tt.failures {
+						return tt.sessionErr
+					}
+					return nil
+				},
+				func() (string, error) {
+					refetches++
+					if tt.refetchErr != nil {
+						return "", tt.refetchErr
+					}
+					return "task-" + string(rune('0'+refetches)), nil
+				})
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if strings.Join(sessions, ",") != strings.Join(tt.wantTasks, ",") {
+				t.Errorf("sessions = %v, want %v", sessions, tt.wantTasks)
+			}
+			if sleeps != tt.wantRetries {
+				t.Errorf("sleeps = %d, want %d", sleeps, tt.wantRetries)
+			}
+		})
+	}
+
+	// A cancelled session (Ctrl-C) is not reconnected
+	ctx, cancel := context.WithCancel(context.Background())
+	r := reconnector{maxRetries: 3, sleep: func(time.Duration) { cancel() }}
+	calls := 0
+	err := r.Run(ctx, "task-0",
+		func(string) error { calls++; return dropped },
+		func() (string, error) { return "task-1", nil })
+	if err == nil || calls != 1 {
+		t.Errorf("Run() after cancel = %v with %d sessions, want the error of the only session", err, calls)
+	}
+
+	t.Log("✓ reconnector retries dropped sessions on the refetched task")
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/20uf/devcli/internal/ecs"
+	"github.com/20uf/devcli/internal/ui"
+)
+
+// recordMeta describes a recorded session.
+type recordMeta struct {
+	Cluster   string
+	Service   string
+	Container string
+	Started   time.Time
+}
+
+func (m recordMeta) title() string {
+	return fmt.Sprintf("%s/%s/%s", m.Cluster, m.Service, m.Container)
+}
+
+// checkRecordFlags rejects the flags whose session --record cannot capture.
+func checkRecordFlags() error {
+	if flagRecord == "" {
+		return nil
+	}
+	conflicts := []struct {
+		name string
+		set  bool
+	}{
+		{"--command", flagExecCommand != ""},
+		{"--tunnel", flagTunnel},
+		{"--new-tab", flagNewTab},
+		{"--auto-reconnect", flagAutoReconnect},
+		{"--container all", isReportMode()},
+	}
+	for _, c := range conflicts {
+		if c.set {
+			return fmt.Errorf("--record cannot be combined with %s", c.name)
+		}
+	}
+	return nil
+}
+
+// execRecorded opens the interactive session recorded to --record: through
+// asciinema when it is installed, otherwise as a plain text log of the
+// session output with a timestamp per line.
+func execRecorded(ctx context.Context, client *ecs.Client, profile, cluster, service, task, container, shell string) error {
+	meta := recordMeta{Cluster: cluster, Service: service, Container: container, Started: time.Now()}
+
+	if asciinema, err := exec.LookPath("asciinema"); err == nil {
+		// The credentials of an assumed role go through the environment:
+		// asciinema writes the command into the header of the .cast file.
+		commandLine, env := client.ExecSession(cluster, task, container, shell, profile)
+		rec := exec.CommandContext(ctx, asciinema, asciinemaArgs(flagRecord, meta, commandLine)...)
+		if env != nil {
+			rec.Env = append(os.Environ(), env...)
+		}
+		rec.Stdin, rec.Stdout, rec.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if err := rec.Run(); err != nil {
+			return fmt.Errorf("asciinema recording failed: %w", err)
+		}
+		ui.PrintSuccess(fmt.Sprintf("Session recorded to %s (play it with asciinema play %s)", flagRecord, flagRecord))
+		return nil
+	}
+
+	path := textLogPath(flagRecord)
+	ui.PrintWarning(fmt.Sprintf("asciinema not found, writing a plain text log to %s", path))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("could not create %s: %w", path, err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	fmt.Fprintf(f, "# devcli session %s, started %s\n", meta.title(), meta.Started.Format(time.RFC3339)) //nolint:errcheck
+	log := &timestampWriter{w: f, now: time.Now}
+	err = explainECSError(client.ExecInteractiveTo(ctx, cluster, task, container, shell, profile, io.MultiWriter(os.Stdout, log)))
+	log.Flush() //nolint:errcheck
+	if err == nil {
+		ui.PrintSuccess(fmt.Sprintf("Session recorded to %s", path))
+	}
+	return err
+}
+
+// asciinemaArgs records commandLine to path, the session title and start
+// time landing in the header of the .cast file.
+func asciinemaArgs(path string, meta recordMeta, commandLine []string) []string {
+	quoted := make([]string, len(commandLine))
+	for i, arg := range commandLine {
+		quoted[i] = shellQuote(arg)
+	}
+	return []string{
+		"rec", path, "--overwrite",
+		"--title", fmt.Sprintf("%s (%s)", meta.title(), meta.Started.Format(time.RFC3339)),
+		"--command", strings.Join(quoted, " "),
+	}
+}
+
+// textLogPath names the plain text log written instead of a .cast file, so
+// it is not mistaken for an asciinema recording.
+func textLogPath(path string) string {
+	if strings.HasSuffix(path, ".cast") {
+		return strings.TrimSuffix(path, ".cast") + ".log"
+	}
+	return path
+}
+
+// timestampWriter prefixes each line written to w with the time it started.
+type timestampWriter struct {
+	w       io.Writer
+	now     func() time.Time
+	partial bytes.Buffer
+}
+
+func (t *timestampWriter) Write(p []byte) (int, error) {
+	rest := p
+	for len(rest) > 0 {
+		if t.partial.Len() == 0 {
+			t.partial.WriteString(t.now().Format("[15:04:05] "))
+		}
+		i := bytes.IndexByte(rest, '\n')
+		if i < 0 {
+			t.partial.Write(rest)
+			break
+		}
+		t.partial.Write(rest[:i+1])
+		rest = rest[i+1:]
+		if err := t.Flush(); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush writes the pending partial line.
+func (t *timestampWriter) Flush() error {
+	if t.partial.Len() == 0 {
+		return nil
+	}
+	_, err := t.w.Write(t.partial.Bytes())
+	t.partial.Reset()
+	return err
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/20uf/devcli/internal/ecs"
+	"github.com/20uf/devcli/internal/ui"
+)
+
+// selectClusterAllRegions lets the user pick a cluster among every enabled
+// region and returns a client bound to the region of the selected cluster.
+func selectClusterAllRegions(profile string, steps ...ui.BreadcrumbStep) (string, *ecs.Client, error) {
+	creds, err := assumeConnectRole(profile)
+	if err != nil {
+		return "", nil, err
+	}
+
+	ui.PrintStep("◌", "Searching clusters in every enabled region")
+	clusters, err := ecs.ListClustersAllRegions(rootCmd.Context(), profile, creds)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(clusters) == 0 {
+		return "", nil, fmt.Errorf("no ECS clusters found in any region")
+	}
+
+	options := make([]ui.SelectOption, len(clusters))
+	for i, c := range clusters {
+		options[i] = ui.SelectOption{Display: c.Label(), Value: strconv.Itoa(i)}
+	}
+	selected, err := ui.SelectWithContext("Select cluster", steps, options)
+	if err != nil {
+		return "", nil, err
+	}
+	i, _ := strconv.Atoi(selected)
+	cluster := clusters[i]
+
+	// The region is now fixed for the next steps and the history entry
+	flagRegion = cluster.Region
+	client, err := ecs.NewClientWithCredentials(profile, cluster.Region, creds)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create AWS client: %w", err)
+	}
+	return cluster.Name, client, nil
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/20uf/devcli/internal/ecs"
+	"github.com/20uf/devcli/internal/ui"
+)
+
+// defaultReportCommand is the diagnostic executed in every container when
+// no --report-command is given.
+const defaultReportCommand = "ps"
+
+// containerResult holds the outcome of a command executed in one container.
+type containerResult struct {
+	Container string
+	Output    string
+	Err       error
+}
+
+// execReport aggregates command results across all containers of a task.
+type execReport struct {
+	Command string
+	Results []containerResult
+}
+
+// Add records the result for a container.
+func (r *execReport) Add(container, output string, err error) {
+	r.Results = append(r.Results, containerResult{
+		Container: container,
+		Output:    output,
+		Err:       err,
+	})
+}
+
+// Failed returns the number of containers where the command failed.
+func (r execReport) Failed() int {
+	failed := 0
+	for _, res := range r.Results {
+		if res.Err != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
+// Render formats the report with a section per container and a summary line.
+func (r execReport) Render() string {
+	var b strings.Builder
+
+	for _, res := range r.Results {
+		if res.Err != nil {
+			fmt.Fprintf(&b, "%s %s %s\n", ui.ErrorStyle.Render("✗"), res.Container, ui.MutedStyle.Render("("+res.Err.Error()+")"))
+		} else {
+			fmt.Fprintf(&b, "%s %s\n", ui.SuccessStyle.Render("✓"), res.Container)
+		}
+
+		if res.Output != "" {
+			for _, line := range strings.Split(res.Output, "\n") {
+				b.WriteString("    " + line + "\n")
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	summary := fmt.Sprintf("%d containers, %d failed — `%s`", len(r.Results), r.Failed(), r.Command)
+	if r.Failed() > 0 {
+		b.WriteString(ui.WarningStyle.Render(summary))
+	} else {
+		b.WriteString(ui.MutedStyle.Render(summary))
+	}
+
+	return b.String()
+}
+
+// isReportMode returns true when connect should run a diagnostic in every container.
+func isReportMode() bool {
+	return flagSelectAllContainers || flagContainer == "all"
+}
+
+// runContainerReport executes the report command in every container of the task.
+func runContainerReport(ctx context.Context, client *ecs.Client, profile, cluster, service, task string) error {
+	all, err := client.ListContainers(ctx, cluster, task)
+	if err != nil {
+		return fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	containers, err := execContainers(all, task)
+	if err != nil {
+		return err
+	}
+
+	command := flagReportCommand
+	if command == "" {
+		command = defaultReportCommand
+	}
+
+	ui.PrintStep("▶", fmt.Sprintf("Running `%s` in %d containers of %s/%s", command, len(containers), cluster, service))
+	fmt.Println()
+
+	report := execReport{Command: command}
+	for _, c := range containers {
+		out, execErr := client.ExecCapture(ctx, cluster, task, c.Name, command, profile)
+		report.Add(c.Name, out, execErr)
+	}
+
+	fmt.Println(report.Render())
+
+	if failed := report.Failed(); failed > 0 {
+		return fmt.Errorf("%d of %d containers failed", failed, len(report.Results))
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Test: Report aggregates results from multiple containers
+func TestExecReport_Aggregate(t *testing.T) {
+	report := execReport{Command: "ps"}
+	report.Add("php", "PID USER\n1 www-data", nil)
+	report.Add("nginx", "PID USER\n1 nginx", nil)
+	report.Add("worker", "", errors.New("exit status 1"))
+
+	if len(report.Results) != 3 {
+		t.Fatalf("Got %d results, want 3", len(report.Results))
+	}
+
+	if report.Failed() != 1 {
+		t.Errorf("Got %d failed, want 1", report.Failed())
+	}
+
+	if report.Results[0].Container != "php" || report.Results[2].Container != "worker" {
+		t.Errorf("Results should keep insertion order")
+	}
+
+	t.Log("✓ Report aggregates container results")
+}
+
+// Test: Rendered report lists every container and a summary
+func TestExecReport_Render(t *testing.T) {
+	report := execReport{Command: "ps"}
+	report.Add("php", "1 www-data", nil)
+	report.Add("worker", "", errors.New("exit status 1"))
+
+	out := report.Render()
+
+	for _, want := range []string{"php", "    1 www-data", "worker", "exit status 1", "2 containers, 1 failed"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Render output missing %q:\n%s", want, out)
+		}
+	}
+
+	t.Log("✓ Report renders per-container output")
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	awsutil "github.com/20uf/devcli/internal/aws"
+	"github.com/20uf/devcli/internal/config"
+	"github.com/20uf/devcli/internal/ecs"
+	"github.com/20uf/devcli/internal/ui"
+)
+
+// resolveRoleARN returns the role to assume for a profile: the --role-arn
+// flag first, then connect.profiles.<profile>.role_arn from the config.
+func resolveRoleARN(flagValue string, cfg *config.Config, profile string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	if cfg == nil {
+		return ""
+	}
+	return cfg.RoleARN(profile)
+}
+
+// assumeConnectRole assumes the role configured for profile, if any.
+// With --save-role, a role given by flag is persisted for the profile.
+// Without a role, a profile with an mfa_serial signs in with an MFA code.
+func assumeConnectRole(profile string) (*awsutil.Credentials, error) {
+	cfg, _ := config.Load()
+
+	roleARN := resolveRoleARN(flagRoleARN, cfg, profile)
+	if roleARN == "" {
+		return mfaCredentials(profile)
+	}
+
+	ui.PrintStep("⇄", fmt.Sprintf("Assuming role %s", roleARN))
+	creds, err := awsutil.AssumeRole(profile, roleARN)
+	if err != nil {
+		return nil, err
+	}
+
+	if flagSaveRole && flagRoleARN != "" && cfg != nil && cfg.RoleARN(profile) != flagRoleARN {
+		cfg.SetRoleARN(profile, flagRoleARN)
+		if err := cfg.Save(); err != nil {
+			ui.PrintWarning(fmt.Sprintf("Could not save role for profile %s: %s", profile, err))
+		} else {
+			ui.PrintStep("✎", fmt.Sprintf("Saved role for profile %s in ~/.devcli/config.yaml", profile))
+		}
+	}
+
+	return creds, nil
+}
+
+// mfaCredentials asks the MFA code of a profile requiring one and returns
+// the temporary credentials, nil for profiles without MFA.
+func mfaCredentials(profile string) (*awsutil.Credentials, error) {
+	mfa, err := awsutil.ProfileMFA(profile)
+	if err != nil || mfa == nil {
+		return nil, err
+	}
+
+	ui.PrintStep("◆", fmt.Sprintf("Profile %s requires MFA", profile))
+	return awsutil.MFACredentials(profile, mfa, promptMFACode)
+}
+
+// mfaCodePattern matches the six digits of an MFA code.
+var mfaCodePattern = regexp.MustCompile(`^[0-9]{6}$`)
+
+func promptMFACode(serial string) (string, error) {
+	code, err := ui.InputWithValidation(fmt.Sprintf("MFA code for %s", serial), "", func(code string) error {
+		if !mfaCodePattern.MatchString(strings.TrimSpace(code)) {
+			return fmt.Errorf("enter the 6 digits of the MFA code")
+		}
+		return nil
+	})
+	return strings.TrimSpace(code), err
+}
+
+// newConnectClient creates an ECS client for profile, assuming its role when one is set.
+func newConnectClient(profile string) (*ecs.Client, error) {
+	creds, err := assumeConnectRole(profile)
+	if err != nil {
+		return nil, err
+	}
+	return ecs.NewClientWithCredentials(profile, flagRegion, creds)
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/20uf/devcli/internal/config"
+)
+
+// Test: --role-arn wins over the role configured for the profile
+func TestResolveRoleARN(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.SetRoleARN("prod", "arn:aws:iam::1234:role/ops")
+
+	tests := []struct {
+		name    string
+		flag    string
+		cfg     *config.Config
+		profile string
+		want    string
+	}{
+		{"Flag wins", "arn:aws:iam::1234:role/admin", cfg, "prod", "arn:aws:iam::1234:role/admin"},
+		{"Configured profile", "", cfg, "prod", "arn:aws:iam::1234:role/ops"},
+		{"Profile without role", "", cfg, "dev", ""},
+		{"No config", "", nil, "prod", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveRoleARN(tt.flag, tt.cfg, tt.profile); got != tt.want {
+				t.Errorf("resolveRoleARN() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Log("✓ Role ARN resolved")
+}
+
+// Test: Profiles without mfa_serial connect without MFA credentials
+func TestMFACredentials_NoMFA(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte("[profile dev]\nregion = eu-west-1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("AWS_CONFIG_FILE", path)
+
+	creds, err := mfaCredentials("dev")
+	if err != nil || creds != nil {
+		t.Errorf("mfaCredentials(dev) = %v, %v, want no credentials", creds, err)
+	}
+
+	t.Log("✓ MFA skipped for profiles without a device")
+}
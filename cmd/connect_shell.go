@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/20uf/devcli/internal/config"
+	"github.com/20uf/devcli/internal/connection/infra"
+	"github.com/20uf/devcli/internal/ecs"
+	"github.com/20uf/devcli/internal/shells"
+	"github.com/20uf/devcli/internal/ui"
+	"github.com/20uf/devcli/internal/verbose"
+)
+
+// defaultShell is opened in Linux containers when neither --shell nor a
+// shell from the config applies.
+const defaultShell = "su -s /bin/sh www-data"
+
+// k8sDefaultShell is the default of Kubernetes pods, which rarely have the
+// www-data user of the ECS images.
+const k8sDefaultShell = "/bin/sh"
+
+// resolveShell returns the shell to open: --shell, then the first
+// connect.shells pattern matching the cluster/service, then the
+// profile's shell or connect.default_shell, then the default of the
+// container's OS or of Kubernetes pods.
+func resolveShell(target connectTarget) string {
+	if flagShell != "" {
+		return flagShell
+	}
+	if cfg, err := config.Load(); err == nil {
+		if shell, ok := cfg.ServiceShell(target.Cluster, target.Service); ok {
+			return shell
+		}
+		if shell, ok := cfg.DefaultShell(target.Profile); ok {
+			return shell
+		}
+	}
+	if target.Provider == infra.ProviderK8s {
+		return k8sDefaultShell
+	}
+	if target.OSFamily == nil {
+		return defaultShell
+	}
+	return osDefaultShell(target.OSFamily())
+}
+
+// osDefaultShell returns the default shell for an ECS operating system
+// family. Windows containers have no /bin/sh.
+func osDefaultShell(osFamily string) string {
+	if strings.HasPrefix(osFamily, "WINDOWS") {
+		return "powershell.exe"
+	}
+	return defaultShell
+}
+
+// taskOSFamily returns a lookup of the OS family of a task, "" (Linux) when
+// it is unknown. The task is only described when the shell defaults to its
+// OS, and at most once.
+func taskOSFamily(ctx context.Context, client *ecs.Client, cluster, task string) func() string {
+	return sync.OnceValue(func() string {
+		family, err := client.TaskOSFamily(ctx, cluster, task)
+		if err != nil {
+			verbose.Log("could not read the task OS: %s", err)
+			return ""
+		}
+		return family
+	})
+}
+
+// shellPreference returns the shells to try in order:
+// connect.shell_preference from the config, then the built-in preference.
+func shellPreference(cfg *config.Config) []string {
+	if cfg != nil && len(cfg.Connect.ShellPreference) > 0 {
+		return cfg.Connect.ShellPreference
+	}
+	return shells.DefaultPreference
+}
+
+// detectShell probes the shells available in a container and returns the
+// best one. Results are cached per image; fallback is returned when no
+// preferred shell is found.
+func detectShell(ctx context.Context, client *ecs.Client, target connectTarget, fallback string) string {
+	cfg, _ := config.Load()
+	preference := shellPreference(cfg)
+
+	image := containerImage(ctx, client, target)
+	store, _ := shells.Load()
+	if store != nil && image != "" {
+		if available, ok := store.Get(image); ok {
+			if shell, ok := shells.Choose(available, preference); ok {
+				verbose.Log("shell for %s from cache: %s", image, shell)
+				return shell
+			}
+		}
+	}
+
+	ui.PrintStep("◌", "Detecting available shells")
+	out, err := client.ExecCapture(ctx, target.Cluster, target.Task, target.Container, shells.ProbeCommand(preference), target.Profile)
+	if err != nil {
+		ui.PrintWarning(fmt.Sprintf("Shell detection failed, using %s: %s", fallback, err))
+		return fallback
+	}
+
+	available := shells.ParseProbe(out)
+	if store != nil && image != "" {
+		store.Set(image, available)
+		store.Save() //nolint:errcheck
+	}
+
+	shell, ok := shells.Choose(available, preference)
+	if !ok {
+		ui.PrintWarning(fmt.Sprintf("No preferred shell found, using %s", fallback))
+		return fallback
+	}
+	ui.PrintStep("✓", fmt.Sprintf("Using shell %s", shell))
+	return shell
+}
+
+// containerImage returns the image of the target container, or "" if unknown.
+func containerImage(ctx context.Context, client *ecs.Client, target connectTarget) string {
+	containers, err := client.ListContainers(ctx, target.Cluster, target.Task)
+	if err != nil {
+		return ""
+	}
+	for _, c := range containers {
+		if c.Name == target.Container {
+			return c.Image
+		}
+	}
+	return ""
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Test: --shell wins, then the shells rule, then the OS default
+func TestResolveShell(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := filepath.Join(home, ".devcli")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	config := "connect:\n  shells:\n    \"*/node-*\": /bin/sh\n  profiles:\n    dev:\n      shell: /bin/bash\n"
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { flagShell = "" }()
+
+	tests := []struct {
+		name   string
+		flag   string
+		target connectTarget
+		want   string
+	}{
+		{"Flag", "/bin/zsh", connectTarget{Cluster: "prod", Service: "node-api"}, "/bin/zsh"},
+		{"Service rule", "", connectTarget{Cluster: "prod", Service: "node-api"}, "/bin/sh"},
+		{"Linux default", "", connectTarget{Cluster: "prod", Service: "api", OSFamily: func() string { return "LINUX" }}, defaultShell},
+		{"Unknown OS", "", connectTarget{Cluster: "prod", Service: "api"}, defaultShell},
+		{"Kubernetes default", "", connectTarget{Cluster: "default", Service: "api", Provider: "k8s"}, k8sDefaultShell},
+		{"Windows default", "", connectTarget{Cluster: "prod", Service: "api", OSFamily: func() string { return "WINDOWS_SERVER_2022_CORE" }}, "powershell.exe"},
+		{"Profile shell", "", connectTarget{Profile: "dev", Cluster: "prod", Service: "api"}, "/bin/bash"},
+		{"Service rule over profile", "", connectTarget{Profile: "dev", Cluster: "prod", Service: "node-api"}, "/bin/sh"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flagShell = tt.flag
+			if got := resolveShell(tt.target); got != tt.want {
+				t.Errorf("resolveShell() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// The task OS is only read when no configured shell applies
+	flagShell = ""
+	lookedUp := false
+	resolveShell(connectTarget{Cluster: "prod", Service: "node-api", OSFamily: func() string { lookedUp = true; return "LINUX" }})
+	if lookedUp {
+		t.Error("OS family read although a shells rule matched")
+	}
+
+	t.Log("✓ Shell resolved per service and OS")
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/20uf/devcli/internal/ecs"
+)
+
+// taskList is the JSON document printed by `connect --json-task`.
+type taskList struct {
+	Cluster string         `json:"cluster"`
+	Service string         `json:"service"`
+	Tasks   []ecs.TaskInfo `json:"tasks"`
+}
+
+// printTaskList prints all running tasks of a service as JSON without connecting.
+func printTaskList(ctx context.Context, client *ecs.Client, cluster, service string) error {
+	tasks, err := client.ListRunningTasks(ctx, cluster, service)
+	if err != nil {
+		return fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	out, err := marshalTaskList(cluster, service, tasks)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+// marshalTaskList renders the task list document. An empty service yields
+// an empty tasks array rather than null.
+func marshalTaskList(cluster, service string, tasks []ecs.TaskInfo) ([]byte, error) {
+	if tasks == nil {
+		tasks = []ecs.TaskInfo{}
+	}
+	return json.MarshalIndent(taskList{Cluster: cluster, Service: service, Tasks: tasks}, "", "  ")
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/20uf/devcli/internal/ecs"
+)
+
+// Test: the --json-task document exposes cluster, service and per-task fields
+func TestMarshalTaskList(t *testing.T) {
+	started := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	out, err := marshalTaskList("prod", "api", []ecs.TaskInfo{
+		{ID: "abc123", StartedAt: &started, Containers: []string{"app", "nginx"}, ExecEnabled: true},
+		{ID: "def456", Containers: []string{}, ExecEnabled: false},
+	})
+	if err != nil {
+		t.Fatalf("marshalTaskList() error = %v", err)
+	}
+
+	var doc struct {
+		Cluster string           `json:"cluster"`
+		Service string           `json:"service"`
+		Tasks   []map[string]any `json:"tasks"`
+	}
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+
+	if doc.Cluster != "prod" || doc.Service != "api" || len(doc.Tasks) != 2 {
+		t.Fatalf("Unexpected document: %s", out)
+	}
+
+	first := doc.Tasks[0]
+	for _, key := range []string{"task_id", "started_at", "containers", "exec_enabled"} {
+		if _, ok := first[key]; !ok {
+			t.Errorf("Task is missing key %q: %v", key, first)
+		}
+	}
+	if first["started_at"] != "2024-05-01T10:00:00Z" {
+		t.Errorf("started_at = %v, want RFC 3339", first["started_at"])
+	}
+	if first["exec_enabled"] != true {
+		t.Errorf("exec_enabled = %v, want true", first["exec_enabled"])
+	}
+
+	if _, ok := doc.Tasks[1]["started_at"]; ok {
+		t.Errorf("started_at should be omitted when unknown")
+	}
+
+	t.Log("✓ Task list JSON shape")
+}
+
+// Test: a service without running tasks prints an empty array
+func TestMarshalTaskList_Empty(t *testing.T) {
+	out, err := marshalTaskList("prod", "api", nil)
+	if err != nil {
+		t.Fatalf("marshalTaskList() error = %v", err)
+	}
+	if !strings.Contains(string(out), `"tasks": []`) {
+		t.Errorf("Expected empty tasks array, got %s", out)
+	}
+
+	t.Log("✓ Empty task list")
+}
+
+// Test: The first configured preferred name present in the task is picked
+func TestPickPreferredContainer(t *testing.T) {
+	containers := []ecs.ContainerInfo{{Name: "nginx"}, {Name: "worker"}, {Name: "app"}}
+
+	if c, ok := pickPreferredContainer(containers, []string{"php", "app", "worker"}); !ok || c.Name != "app" {
+		t.Errorf("pickPreferredContainer() = %q, %v, want app", c.Name, ok)
+	}
+	if c, ok := pickPreferredContainer(containers, []string{"sidecar"}); ok {
+		t.Errorf("pickPreferredContainer() = %q, want no match", c.Name)
+	}
+
+	t.Log("✓ Preferred container picked in configured order")
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/20uf/devcli/internal/config"
+)
+
+// connectTarget is the resolved destination of an exec session.
+type connectTarget struct {
+	Profile   string
+	Cluster   string
+	Service   string
+	Task      string
+	Container string
+	OSFamily  func() string // runtimePlatform OS of the task, read on first use; nil when unknown
+	Provider  string        // infra.ProviderK8s for a pod, "" for an ECS task
+}
+
+var placeholderPattern = regexp.MustCompile(`\{([a-z_]+)\}`)
+
+// expandCommandTemplate replaces {profile}, {cluster}, {service}, {task} and
+// {container} in tmpl with the values of target.
+func expandCommandTemplate(tmpl string, target connectTarget) (string, error) {
+	values := map[string]string{
+		"profile":   target.Profile,
+		"cluster":   target.Cluster,
+		"service":   target.Service,
+		"task":      target.Task,
+		"container": target.Container,
+	}
+
+	var unknown []string
+	expanded := placeholderPattern.ReplaceAllStringFunc(tmpl, func(match string) string {
+		key := match[1 : len(match)-1]
+		if value, ok := values[key]; ok {
+			return value
+		}
+		unknown = append(unknown, match)
+		return match
+	})
+	if len(unknown) > 0 {
+		return "", fmt.Errorf("unknown placeholder %s in command template\n  Available: {profile}, {cluster}, {service}, {task}, {container}", strings.Join(unknown, ", "))
+	}
+	return expanded, nil
+}
+
+// sessionCommand returns the command to run in the container: the expanded
+// --template when one is set, the shell otherwise.
+func sessionCommand(target connectTarget) (string, error) {
+	if flagTemplate == "" {
+		return resolveShell(target), nil
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return "", fmt.Errorf("failed to load config: %w", err)
+	}
+
+	tmpl, ok := cfg.CommandTemplate(flagTemplate)
+	if !ok {
+		names := make([]string, 0, len(cfg.Connect.Templates))
+		for name := range cfg.Connect.Templates {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		if len(names) == 0 {
+			return "", fmt.Errorf("unknown command template %q\n  Define it under connect.templates in ~/.devcli/config.yaml", flagTemplate)
+		}
+		return "", fmt.Errorf("unknown command template %q\n  Available: %s", flagTemplate, strings.Join(names, ", "))
+	}
+
+	return expandCommandTemplate(tmpl, target)
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Test: Command template placeholders expand against the resolved target
+func TestExpandCommandTemplate(t *testing.T) {
+	target := connectTarget{
+		Profile:   "prod",
+		Cluster:   "main",
+		Service:   "api",
+		Task:      "abc123",
+		Container: "php",
+	}
+
+	tests := []struct {
+		name    string
+		tmpl    string
+		want    string
+		wantErr bool
+	}{
+		{"no placeholder", "php bin/console cache:clear", "php bin/console cache:clear", false},
+		{"service", "php bin/console cache:clear --env={service}", "php bin/console cache:clear --env=api", false},
+		{"all", "echo {profile} {cluster} {service} {task} {container}", "echo prod main api abc123 php", false},
+		{"repeated", "{service}-{service}", "api-api", false},
+		{"unknown", "echo {region}", "", true},
+		{"json braces untouched", `echo '{"a": 1}'`, `echo '{"a": 1}'`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandCommandTemplate(tt.tmpl, target)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expandCommandTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("expandCommandTemplate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Log("✓ Command template placeholders expanded")
+}
+
+// Test: --template resolves the named template from config
+func TestSessionCommand_Template(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	dir := filepath.Join(home, ".devcli")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	config := "connect:\n  templates:\n    clear-cache: \"bin/console cache:clear --env={service}\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { flagTemplate = "" }()
+
+	flagTemplate = "clear-cache"
+	got, err := sessionCommand(connectTarget{Service: "api"})
+	if err != nil {
+		t.Fatalf("sessionCommand() error = %v", err)
+	}
+	if got != "bin/console cache:clear --env=api" {
+		t.Errorf("sessionCommand() = %q", got)
+	}
+
+	flagTemplate = "missing"
+	if _, err := sessionCommand(connectTarget{Service: "api"}); err == nil {
+		t.Errorf("Unknown template should fail")
+	}
+
+	t.Log("✓ Command template resolved from config")
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/20uf/devcli/internal/ecs"
+	"github.com/20uf/devcli/internal/ui"
+)
+
+// inputPollInterval is how often input is looked for after the warning.
+var inputPollInterval = time.Second
+
+// sessionTimeout ends a session once timeout elapsed since it started,
+// whatever it prints: a shell left running tail -f or top is closed too.
+// warnBefore the end, a warning invites to press a key; input typed after it
+// extends the session by another timeout.
+type sessionTimeout struct {
+	warnOut    io.Writer
+	timeout    time.Duration
+	warnBefore time.Duration
+	lastInput  func() (time.Time, bool) // when the user last typed
+
+	mu       sync.Mutex
+	expire   *time.Timer
+	warn     *time.Timer
+	warnedAt time.Time
+	expired  bool
+	stopped  bool
+}
+
+// newSessionTimeout starts the timers: cancel is called when the session
+// times out. lastInput may be nil when input cannot be detected.
+func newSessionTimeout(warnOut io.Writer, timeout, warnBefore time.Duration, lastInput func() (time.Time, bool), cancel context.CancelFunc) *sessionTimeout {
+	t := &sessionTimeout{warnOut: warnOut, timeout: timeout, warnBefore: warnBefore, lastInput: lastInput}
+	t.expire = time.AfterFunc(timeout, func() {
+		t.mu.Lock()
+		t.expired = !t.stopped
+		t.mu.Unlock()
+		cancel()
+	})
+	if t.warns() {
+		t.warn = time.AfterFunc(timeout-warnBefore, t.printWarning)
+	}
+	return t
+}
+
+// warns reports whether a warning is printed before the timeout.
+func (t *sessionTimeout) warns() bool {
+	return t.warnBefore > 0 && t.warnBefore < t.timeout
+}
+
+func (t *sessionTimeout) printWarning() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.expired || t.stopped {
+		return
+	}
+	message := fmt.Sprintf("⚠ Session closing in %s", t.warnBefore)
+	if t.lastInput != nil {
+		message += ": press any key to keep it open"
+		t.warnedAt = time.Now()
+		go t.watchInput(t.warnedAt)
+	}
+	// The session terminal is in raw mode: return the carriage explicitly.
+	fmt.Fprintf(t.warnOut, "\r\n%s\r\n", ui.WarningStyle.Render(message)) //nolint:errcheck
+}
+
+// watchInput extends the session once the user types after the warning
+// given at warnedAt, until the session ends.
+func (t *sessionTimeout) watchInput(warnedAt time.Time) {
+	ticker := time.NewTicker(inputPollInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		typed, ok := t.lastInput()
+		if ok && !typed.Before(warnedAt.Truncate(time.Second)) {
+			t.extend(warnedAt)
+			return
+		}
+		t.mu.Lock()
+		done := t.expired || t.stopped || !t.warnedAt.Equal(warnedAt)
+		t.mu.Unlock()
+		if done {
+			return
+		}
+	}
+}
+
+// extend restarts the timers for another timeout.
+func (t *sessionTimeout) extend(warnedAt time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.expired || t.stopped || !t.warnedAt.Equal(warnedAt) || !t.expire.Stop() {
+		return
+	}
+	t.expire.Reset(t.timeout)
+	t.warn.Reset(t.timeout - t.warnBefore)
+	t.warnedAt = time.Time{}
+	fmt.Fprintf(t.warnOut, "\r\n%s\r\n", ui.MutedStyle.Render(fmt.Sprintf("Session extended by %s", t.timeout))) //nolint:errcheck
+}
+
+// Stop releases the timers once the session ended.
+func (t *sessionTimeout) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stopped = true
+	t.expire.Stop()
+	if t.warn != nil {
+		t.warn.Stop()
+	}
+}
+
+// Expired reports whether the session was ended by the timeout.
+func (t *sessionTimeout) Expired() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.expired
+}
+
+// execWithTimeout opens the interactive session, terminating the aws CLI
+// once --timeout elapsed. A timed out session is not an error.
+func execWithTimeout(ctx context.Context, client *ecs.Client, cluster, task, container, shell, profile string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var lastInput func() (time.Time, bool)
+	if _, ok := lastTTYInput(os.Stdin); ok {
+		lastInput = func() (time.Time, bool) { return lastTTYInput(os.Stdin) }
+	}
+	timeout := newSessionTimeout(os.Stderr, flagTimeout, flagTimeoutWarnBefore, lastInput, cancel)
+	err := client.ExecInteractive(ctx, cluster, task, container, shell, profile)
+	timeout.Stop()
+	if timeout.Expired() {
+		ui.PrintWarning(fmt.Sprintf("Session timed out after %s", flagTimeout))
+		return nil
+	}
+	return err
+}
+
+// checkTimeoutFlags rejects a negative --timeout and the sessions it cannot
+// watch.
+func checkTimeoutFlags() error {
+	if flagTimeout < 0 || flagTimeoutWarnBefore < 0 {
+		return fmt.Errorf("--timeout and --timeout-warn-before must be positive durations")
+	}
+	if flagTimeout == 0 {
+		return nil
+	}
+	conflicts := []struct {
+		name string
+		set  bool
+	}{
+		{"--command", flagExecCommand != ""},
+		{"--tunnel", flagTunnel},
+		{"--new-tab", flagNewTab},
+		{"--record", flagRecord != ""},
+		{"--container all", isReportMode()},
+	}
+	for _, c := range conflicts {
+		if c.set {
+			return fmt.Errorf("--timeout cannot be combined with %s", c.name)
+		}
+	}
+	return nil
+}
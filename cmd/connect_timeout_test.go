@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Test: A session is closed after the timeout even when it keeps printing
+func TestSessionTimeout(t *testing.T) {
+	var warnings strings.Builder
+	cancelled := make(chan struct{})
+	timeout := newSessionTimeout(&warnings, 200*time.Millisecond, 100*time.Millisecond, nil, func() { close(cancelled) })
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatalf("Session was not cancelled")
+	}
+	timeout.Stop()
+
+	if !timeout.Expired() {
+		t.Errorf("Expired() = false after the timeout")
+	}
+	if !strings.Contains(warnings.String(), "closing in") || strings.Contains(warnings.String(), "press any key") {
+		t.Errorf("Unexpected warning without input detection: %q", warnings.String())
+	}
+
+	t.Log("✓ Session timed out")
+}
+
+// Test: A key pressed after the warning extends the session
+func TestSessionTimeout_Extended(t *testing.T) {
+	var mu sync.Mutex
+	var typed time.Time
+	lastInput := func() (time.Time, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		return typed, true
+	}
+
+	oldInterval := inputPollInterval
+	inputPollInterval = 10 * time.Millisecond
+	defer func() { inputPollInterval = oldInterval }()
+
+	var warnings syncBuilder
+	cancelled := make(chan struct{})
+	timeout := newSessionTimeout(&warnings, 2*time.Second, 1500*time.Millisecond, lastInput, func() { close(cancelled) })
+	defer timeout.Stop()
+
+	// Past the warning, the user types
+	time.Sleep(time.Second)
+	if !strings.Contains(warnings.String(), "press any key") {
+		t.Fatalf("No warning before the timeout: %q", warnings.String())
+	}
+	mu.Lock()
+	typed = time.Now().Add(time.Second)
+	mu.Unlock()
+
+	// The original deadline passes without closing the session
+	select {
+	case <-cancelled:
+		t.Fatalf("Session closed despite the key press")
+	case <-time.After(1500 * time.Millisecond):
+	}
+	if !strings.Contains(warnings.String(), "Session extended") {
+		t.Errorf("Extension not reported: %q", warnings.String())
+	}
+
+	t.Log("✓ Session extended by a key press")
+}
+
+// syncBuilder is a strings.Builder safe for the timer goroutines.
+type syncBuilder struct {
+	mu sync.Mutex
+	b  strings.Builder
+}
+
+func (s *syncBuilder) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.b.Write(p)
+}
+
+func (s *syncBuilder) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.b.String()
+}
+
+// Test: --timeout only applies to interactive sessions
+func TestCheckTimeoutFlags(t *testing.T) {
+	defer func() { flagTimeout, flagTunnel = 0, false }()
+
+	flagTimeout = -time.Minute
+	if err := checkTimeoutFlags(); err == nil {
+		t.Errorf("Negative --timeout should fail")
+	}
+
+	flagTimeout = 2 * time.Hour
+	if err := checkTimeoutFlags(); err != nil {
+		t.Errorf("checkTimeoutFlags() = %v", err)
+	}
+
+	flagTunnel = true
+	if err := checkTimeoutFlags(); err == nil || !strings.Contains(err.Error(), "--tunnel") {
+		t.Errorf("--timeout with --tunnel should fail, got %v", err)
+	}
+
+	t.Log("✓ --timeout conflicts rejected")
+}
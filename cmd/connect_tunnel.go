@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/20uf/devcli/internal/ecs"
+	"github.com/20uf/devcli/internal/ui"
+)
+
+// freeLocalPort asks the OS for an unused TCP port on the loopback interface.
+func freeLocalPort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, fmt.Errorf("failed to find a free local port: %w", err)
+	}
+	defer l.Close() //nolint:errcheck
+
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+// tunnelCommand is run inside the container to open a SOCKS5 proxy on port.
+func tunnelCommand(port int) string {
+	return fmt.Sprintf("ssh -D %d -N -o StrictHostKeyChecking=no localhost", port)
+}
+
+// proxyExportLine is the shell line users paste to route traffic through the proxy.
+func proxyExportLine(port int) string {
+	return fmt.Sprintf("export all_proxy=socks5://localhost:%d", port)
+}
+
+// runTunnel starts a SOCKS5 proxy in the container and forwards it to the same
+// local port until the user presses Ctrl+C.
+func runTunnel(ctx context.Context, client *ecs.Client, profile, cluster, task, container string) error {
+	port, err := freeLocalPort()
+	if err != nil {
+		return err
+	}
+
+	runtimeID, err := client.ContainerRuntimeID(ctx, cluster, task, container)
+	if err != nil {
+		return fmt.Errorf("failed to resolve container %s: %w", container, err)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errs := make(chan error, 2)
+	go func() {
+		out, err := client.ExecCapture(ctx, cluster, task, container, tunnelCommand(port), profile)
+		if err != nil && ctx.Err() == nil {
+			errs <- fmt.Errorf("SOCKS proxy exited in %s: %w\n%s", container, err, out)
+			return
+		}
+		errs <- nil
+	}()
+	go func() {
+		errs <- client.PortForward(ctx, cluster, task, runtimeID, port, port, profile)
+	}()
+
+	ui.PrintSuccess(fmt.Sprintf("SOCKS5 proxy active on localhost:%d", port))
+	fmt.Println(ui.MutedStyle.Render("  Route other terminals through it with:"))
+	fmt.Println("  " + proxyExportLine(port))
+	fmt.Println(ui.MutedStyle.Render("  Press Ctrl+C to close the tunnel."))
+
+	select {
+	case <-ctx.Done():
+		fmt.Println()
+		ui.PrintStep("⊘", "Tunnel closed")
+		return nil
+	case err := <-errs:
+		stop()
+		if err == nil {
+			ui.PrintWarning("Tunnel session ended")
+		}
+		return err
+	}
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Test: A free local port can be bound
+func TestFreeLocalPort(t *testing.T) {
+	port, err := freeLocalPort()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if port <= 0 || port > 65535 {
+		t.Fatalf("Invalid port %d", port)
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:"+strconv.Itoa(port))
+	if err != nil {
+		t.Fatalf("Port %d should be free: %v", port, err)
+	}
+	l.Close() //nolint:errcheck
+
+	t.Log("✓ Free local port found")
+}
+
+// Test: Tunnel command and export line use the chosen port
+func TestTunnelCommand(t *testing.T) {
+	cmd := tunnelCommand(1080)
+	if !strings.HasPrefix(cmd, "ssh -D 1080 -N -o StrictHostKeyChecking=no") {
+		t.Errorf("Unexpected tunnel command: %s", cmd)
+	}
+
+	if got := proxyExportLine(1080); got != "export all_proxy=socks5://localhost:1080" {
+		t.Errorf("Unexpected export line: %s", got)
+	}
+
+	t.Log("✓ Tunnel command built")
+}
@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	awsutil "github.com/20uf/devcli/internal/aws"
+	"github.com/20uf/devcli/internal/ecs"
+	"github.com/20uf/devcli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// containerPrefix marks the container side of a copy.
+const containerPrefix = "container:"
+
+// uploadChunkSize is the number of bytes sent per execute-command call.
+// Each chunk is base64-encoded into the command line, which SSM limits in size.
+const uploadChunkSize = 3 * 1024
+
+var cpCmd = &cobra.Command{
+	Use:   "cp <src> <dst>",
+	Short: "Copy files to or from an ECS container",
+	Long: `Copy a file between your machine and an ECS container.
+
+Prefix the container side with "container:". The target is selected like
+devcli connect; files are streamed base64-encoded through ECS Exec.
+
+Examples:
+  devcli cp local.txt container:/tmp/                    Upload to /tmp/local.txt
+  devcli cp container:/var/log/app.log ./                Download to ./app.log
+  devcli cp --profile dev --cluster c --service s container:/etc/hosts hosts`,
+	Args: cobra.ExactArgs(2),
+	RunE: runCp,
+}
+
+func init() {
+	cpCmd.Flags().StringVar(&flagProfile, "profile", "", "AWS profile to use, auto for the one with valid credentials")
+	cpCmd.Flags().StringVar(&flagRegion, "region", "", "AWS region to use")
+	cpCmd.Flags().StringVar(&flagCluster, "cluster", "", "ECS cluster name or ARN (skip selection)")
+	cpCmd.Flags().StringVar(&flagService, "service", "", "ECS service name (skip selection)")
+	cpCmd.Flags().StringVar(&flagContainer, "container", "", "Container name (skip selection)")
+	rootCmd.AddCommand(cpCmd)
+}
+
+// copySpec describes a copy between a local path and a container path.
+type copySpec struct {
+	Upload bool // local → container when true
+	Local  string
+	Remote string
+}
+
+// parseCopyArgs determines the copy direction from the side carrying the
+// container: prefix. Exactly one side must carry it.
+func parseCopyArgs(src, dst string) (copySpec, error) {
+	srcRemote := strings.HasPrefix(src, containerPrefix)
+	dstRemote := strings.HasPrefix(dst, containerPrefix)
+
+	switch {
+	case srcRemote && dstRemote:
+		return copySpec{}, fmt.Errorf("cannot copy between two container paths")
+	case !srcRemote && !dstRemote:
+		return copySpec{}, fmt.Errorf("one of the paths must start with %q\n  Example: devcli cp local.txt container:/tmp/", containerPrefix)
+	}
+
+	if dstRemote {
+		remote := strings.TrimPrefix(dst, containerPrefix)
+		if remote == "" {
+			return copySpec{}, fmt.Errorf("missing container path in %q", dst)
+		}
+		if strings.HasSuffix(remote, "/") {
+			remote += filepath.Base(src)
+		}
+		return copySpec{Upload: true, Local: src, Remote: remote}, nil
+	}
+
+	remote := strings.TrimPrefix(src, containerPrefix)
+	if remote == "" || strings.HasSuffix(remote, "/") {
+		return copySpec{}, fmt.Errorf("container path %q must be a file", src)
+	}
+	local := dst
+	if info, err := os.Stat(dst); (err == nil && info.IsDir()) || strings.HasSuffix(dst, string(os.PathSeparator)) {
+		local = filepath.Join(dst, path.Base(remote))
+	}
+	return copySpec{Local: local, Remote: remote}, nil
+}
+
+func runCp(cmd *cobra.Command, args []string) error {
+	spec, err := parseCopyArgs(args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	if err := awsutil.CheckDependencies(); err != nil {
+		return err
+	}
+
+	profile, err := selectProfile()
+	if err != nil {
+		return err
+	}
+	if err := awsutil.EnsureSSOLogin(profile); err != nil {
+		return err
+	}
+
+	client, err := newConnectClient(profile)
+	if err != nil {
+		return fmt.Errorf("failed to create AWS client: %w", err)
+	}
+
+	cluster, err := selectCluster(client)
+	if err != nil {
+		return err
+	}
+	service, err := selectService(client, cluster)
+	if err != nil {
+		return err
+	}
+	task, err := client.GetRunningTask(cmd.Context(), cluster, service)
+	if err != nil {
+		return fmt.Errorf("no running task found: %w", err)
+	}
+	if err := client.CheckExecEnabled(cmd.Context(), cluster, service, task); err != nil {
+		return err
+	}
+	container, err := selectContainer(client, cmd, cluster, task)
+	if err != nil {
+		return err
+	}
+
+	target := copyTarget{client: client, profile: profile, cluster: cluster, task: task, container: container}
+	if spec.Upload {
+		return uploadFile(cmd, target, spec)
+	}
+	return downloadFile(cmd, target, spec)
+}
+
+// copyTarget is the container files are copied to or from.
+type copyTarget struct {
+	client    *ecs.Client
+	profile   string
+	cluster   string
+	task      string
+	container string
+}
+
+func downloadFile(cmd *cobra.Command, target copyTarget, spec copySpec) error {
+	ui.PrintStep("↓", fmt.Sprintf("Copying %s:%s to %s", target.container, spec.Remote, spec.Local))
+
+	out, err := target.client.ExecCapture(cmd.Context(), target.cluster, target.task, target.container,
+		"base64 "+shellQuote(spec.Remote), target.profile)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", spec.Remote, err)
+	}
+
+	data, err := decodeCopyOutput(out)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %s", spec.Remote, strings.TrimSpace(out))
+	}
+
+	if err := os.WriteFile(spec.Local, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", spec.Local, err)
+	}
+
+	ui.PrintSuccess(fmt.Sprintf("Copied %d bytes to %s", len(data), spec.Local))
+	return nil
+}
+
+func uploadFile(cmd *cobra.Command, target copyTarget, spec copySpec) error {
+	data, err := os.ReadFile(spec.Local)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", spec.Local, err)
+	}
+
+	ui.PrintStep("↑", fmt.Sprintf("Copying %s to %s:%s", spec.Local, target.container, spec.Remote))
+
+	for _, command := range uploadCommands(data, spec.Remote) {
+		out, err := target.client.ExecCapture(cmd.Context(), target.cluster, target.task, target.container, command, target.profile)
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", spec.Remote, err)
+		}
+		if out != "" {
+			return fmt.Errorf("failed to write %s: %s", spec.Remote, out)
+		}
+	}
+
+	ui.PrintSuccess(fmt.Sprintf("Copied %d bytes to %s:%s", len(data), target.container, spec.Remote))
+	return nil
+}
+
+// uploadCommands returns the commands writing data to remote, one per chunk.
+// The first chunk truncates the file, the next ones append to it.
+func uploadCommands(data []byte, remote string) []string {
+	if len(data) == 0 {
+		return []string{"sh -c " + shellQuote(": > "+shellQuote(remote))}
+	}
+
+	var commands []string
+	for start := 0; start < len(data); start += uploadChunkSize {
+		end := min(start+uploadChunkSize, len(data))
+		redirect := ">>"
+		if start == 0 {
+			redirect = ">"
+		}
+		script := fmt.Sprintf("printf %%s %s | base64 -d %s %s",
+			base64.StdEncoding.EncodeToString(data[start:end]), redirect, shellQuote(remote))
+		commands = append(commands, "sh -c "+shellQuote(script))
+	}
+	return commands
+}
+
+// decodeCopyOutput decodes the base64 output of a session, ignoring the
+// line breaks and carriage returns added by the terminal.
+func decodeCopyOutput(out string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(out), ""))
+}
+
+// shellQuote quotes s as a single shell word.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
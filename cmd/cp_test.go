@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Test: The container: prefix determines the copy direction
+func TestParseCopyArgs(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		src     string
+		dst     string
+		want    copySpec
+		wantErr bool
+	}{
+		{"upload to dir", "local.txt", "container:/tmp/", copySpec{Upload: true, Local: "local.txt", Remote: "/tmp/local.txt"}, false},
+		{"upload to file", "a/local.txt", "container:/tmp/remote.txt", copySpec{Upload: true, Local: "a/local.txt", Remote: "/tmp/remote.txt"}, false},
+		{"download to dir", "container:/var/log/app.log", dir, copySpec{Local: filepath.Join(dir, "app.log"), Remote: "/var/log/app.log"}, false},
+		{"download to file", "container:/var/log/app.log", "out.log", copySpec{Local: "out.log", Remote: "/var/log/app.log"}, false},
+		{"both remote", "container:/a", "container:/b", copySpec{}, true},
+		{"both local", "a", "b", copySpec{}, true},
+		{"empty remote", "local.txt", "container:", copySpec{}, true},
+		{"download a dir", "container:/var/log/", "./", copySpec{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCopyArgs(tt.src, tt.dst)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseCopyArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseCopyArgs() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	t.Log("✓ Copy direction parsed from container: prefix")
+}
+
+// Test: Uploads are split into base64 chunks, the first one truncating the file
+func TestUploadCommands(t *testing.T) {
+	data := []byte(strings.Repeat("x", uploadChunkSize*2+10))
+
+	commands := uploadCommands(data, "/tmp/it's.txt")
+	if len(commands) != 3 {
+		t.Fatalf("Expected 3 chunks, got %d", len(commands))
+	}
+	if !strings.Contains(commands[0], "base64 -d > ") || !strings.Contains(commands[1], "base64 -d >> ") {
+		t.Errorf("First chunk should truncate and next ones append: %q / %q", commands[0], commands[1])
+	}
+	if !strings.HasPrefix(commands[0], "sh -c '") {
+		t.Errorf("Chunk should run through sh: %q", commands[0])
+	}
+
+	if got := uploadCommands(nil, "/tmp/empty"); len(got) != 1 {
+		t.Errorf("Empty file should produce a single truncate command, got %v", got)
+	}
+
+	t.Log("✓ Upload commands chunked")
+}
+
+// Test: Terminal line breaks are ignored when decoding downloads
+func TestDecodeCopyOutput(t *testing.T) {
+	encoded := base64.StdEncoding.EncodeToString([]byte("hello from the container\n"))
+	out := encoded[:10] + "\r\n" + encoded[10:] + "\r\n"
+
+	data, err := decodeCopyOutput(out)
+	if err != nil {
+		t.Fatalf("decodeCopyOutput() error = %v", err)
+	}
+	if string(data) != "hello from the container\n" {
+		t.Errorf("decodeCopyOutput() = %q", data)
+	}
+
+	if _, err := decodeCopyOutput("base64: /nope: No such file or directory"); err == nil {
+		t.Errorf("Error output should not decode")
+	}
+
+	t.Log("✓ Download output decoded")
+}
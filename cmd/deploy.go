@@ -1,21 +1,26 @@
 package cmd
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
-	"sort"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/20uf/devcli/internal/deployment/application"
+	"github.com/20uf/devcli/internal/deployment/domain"
+	"github.com/20uf/devcli/internal/deployment/infra"
 	"github.com/20uf/devcli/internal/history"
-	"github.com/20uf/devcli/internal/tracker"
+	"github.com/20uf/devcli/internal/retry"
+	"github.com/20uf/devcli/internal/telemetry"
 	"github.com/20uf/devcli/internal/ui"
 	"github.com/20uf/devcli/internal/verbose"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
 var (
@@ -25,19 +30,64 @@ var (
 	flagInputs   []string
 	flagWatch    bool
 	flagLast     bool
+
+	flagWatchTimeout time.Duration
+	flagProvider     string
+
+	flagRepoLimit   int
+	flagBranchLimit int
+
+	flagRequireCleanGit bool
+	flagAllowDirty      bool
+	flagCancelPrevious  bool
+	flagReuseInputs     bool
+	flagRequireApproval bool
+	flagShowDiff        bool
+	flagInputsFile      string
+	flagAutoSelect      bool
+	flagCopyURL         bool
+	flagRerunLast       bool
+	flagDeployLabel     string
+	flagWait            bool
+	flagEditInputs      bool
+	flagInputsEditor    bool
+
+	flagAllowTagLikeBranch bool
 )
 
 var deployCmd = &cobra.Command{
 	Use:   "deploy",
-	Short: "Trigger a GitHub Actions deployment workflow",
-	Long: `Trigger a GitHub Actions deployment workflow via the gh CLI.
+	Short: "Trigger a GitHub Actions workflow or GitLab CI/CD pipeline",
+	Long: `Trigger a deployment workflow.
+
+With --provider github (default) a GitHub Actions workflow_dispatch workflow
+is run via the gh CLI. With --provider gitlab the pipeline of a GitLab project
+is run via the glab CLI: --repo is the project path (group/project), inputs
+are the pipeline variables with a description, and CI_SERVER_URL selects a
+self-managed instance.
 
 Examples:
   devcli deploy                                          Interactive selection
-  devcli deploy --last                                   Replay last deployment
+  devcli deploy --last                                   Replay last deployment (new run)
+  devcli deploy --last --edit-inputs                     Replay last deployment, tweaking its inputs first
+  devcli deploy --rerun-last                             Rerun the latest run of the last deployed workflow
   devcli deploy --repo owner/repo --workflow deploy.yml  Non-interactive
   devcli deploy --branch feature-x --watch               Deploy and stream logs
-  devcli deploy --input environment=prod --input v=1.2   With workflow inputs`,
+  devcli deploy --last --watch --watch-timeout 20m       Give up watching after 20 minutes
+  devcli deploy --last --wait && ./notify.sh             Block until the run completes, exit with its result
+  devcli deploy --input environment=prod --input v=1.2   With workflow inputs
+  devcli deploy --inputs-file deploy-prod.yaml           Inputs from a YAML file
+  devcli deploy --require-clean-git                      Refuse to deploy from a dirty working tree
+  devcli deploy --repo owner/repo --reuse-inputs         Start from the last inputs used
+  devcli deploy --require-approval                       Show required reviewers before triggering
+  devcli deploy --last --cancel-previous                 Cancel in-flight runs, then redeploy
+  devcli deploy --repo owner/repo --show-diff            Show what changed between the last two deploys
+  devcli deploy --auto-select                            Reuse the last repo, workflow, inputs and branch without prompting
+  devcli deploy --last --copy-url                        Copy the run URL to the clipboard
+  devcli deploy --last --label release-2.5.0             Tag the run for status --label
+  devcli deploy --provider gitlab --repo group/api --branch main --input ENV=prod
+                                                         Run a GitLab pipeline`,
+	Args: cobra.NoArgs,
 	RunE: runDeploy,
 }
 
@@ -46,8 +96,27 @@ func init() {
 	deployCmd.Flags().StringVar(&flagWorkflow, "workflow", "", "Workflow file name or ID")
 	deployCmd.Flags().StringVar(&flagBranch, "branch", "", "Branch to run the workflow on")
 	deployCmd.Flags().StringSliceVar(&flagInputs, "input", nil, "Workflow inputs (key=value)")
+	deployCmd.Flags().StringVar(&flagInputsFile, "inputs-file", "", "YAML or JSON file of workflow inputs, - for stdin (${VAR} is replaced from the environment, --input wins)")
+	deployCmd.Flags().BoolVar(&flagInputsEditor, "inputs", false, "Edit the workflow inputs as YAML in $EDITOR (vi by default), --input wins")
 	deployCmd.Flags().BoolVar(&flagWatch, "watch", false, "Watch workflow run and stream logs")
-	deployCmd.Flags().BoolVar(&flagLast, "last", false, "Replay last deployment")
+	deployCmd.Flags().BoolVar(&flagWait, "wait", false, "Block until the run completes and exit with its result (0 success, 1 failure, 2 cancelled)")
+	deployCmd.Flags().BoolVar(&flagLast, "last", false, "Replay last deployment (triggers a new run)")
+	deployCmd.Flags().BoolVar(&flagEditInputs, "edit-inputs", false, "When replaying, prompt for the inputs pre-filled with the saved values and show what changed")
+	deployCmd.Flags().BoolVar(&flagRerunLast, "rerun-last", false, "Rerun the latest run of the last deployed workflow on its branch (gh run rerun, same commit and inputs)")
+	deployCmd.Flags().StringVar(&flagProvider, "provider", infra.ProviderGitHub, "CI/CD provider: github or gitlab")
+	deployCmd.Flags().DurationVar(&flagWatchTimeout, "watch-timeout", 0, "Stop watching or waiting after this duration (e.g. 30m) and exit non-zero if the run is still going")
+	deployCmd.Flags().IntVar(&flagRepoLimit, "repo-limit", 0, "Maximum repositories to list (default 50, max 200, config: deploy.repo_limit)")
+	deployCmd.Flags().IntVar(&flagBranchLimit, "branch-limit", 0, "Maximum branches listed before Load more (default 50, config: deploy.branch_limit)")
+	deployCmd.Flags().BoolVar(&flagRequireCleanGit, "require-clean-git", false, "Block the deploy when the local working tree has uncommitted changes")
+	deployCmd.Flags().BoolVar(&flagAllowTagLikeBranch, "allow-tag-like-branch", false, "Skip the check rejecting branches named like a release tag (e.g. v1.2.3)")
+	deployCmd.Flags().BoolVar(&flagAllowDirty, "allow-dirty", false, "Only warn when --require-clean-git finds uncommitted changes")
+	deployCmd.Flags().BoolVar(&flagReuseInputs, "reuse-inputs", false, "Pre-fill workflow inputs with the values of the last deploy of the same workflow")
+	deployCmd.Flags().BoolVar(&flagRequireApproval, "require-approval", false, "Show the required reviewers of the target environment and confirm before triggering")
+	deployCmd.Flags().BoolVar(&flagShowDiff, "show-diff", false, "Show the image inputs of your last two deploys of the workflow, or the commits between its last two runs")
+	deployCmd.Flags().BoolVar(&flagAutoSelect, "auto-select", false, "Skip the prompts: pick the most recently used value of each step, else the first option")
+	deployCmd.Flags().BoolVar(&flagCopyURL, "copy-url", false, "Copy the web URL of the triggered run to the clipboard (config: deploy.copy_url)")
+	deployCmd.Flags().StringVar(&flagDeployLabel, "label", "", "Name the run in the status dashboard and history instead of repo/workflow @ branch (e.g. release-2.5.0)")
+	deployCmd.Flags().BoolVar(&flagCancelPrevious, "cancel-previous", false, "Cancel in-progress runs of the workflow before triggering")
 	rootCmd.AddCommand(deployCmd)
 }
 
@@ -64,32 +133,84 @@ type repoInfo struct {
 }
 
 func runDeploy(cmd *cobra.Command, args []string) error {
+	switch flagProvider {
+	case infra.ProviderGitHub, infra.ProviderGitLab:
+	default:
+		return fmt.Errorf("unknown provider %q\n  Available: %s, %s", flagProvider, infra.ProviderGitHub, infra.ProviderGitLab)
+	}
+
+	if err := checkWaitFlags(); err != nil {
+		return err
+	}
+	if err := checkInputsEditorFlags(); err != nil {
+		return err
+	}
+	if err := checkEditInputsFlags(); err != nil {
+		return err
+	}
+	if flagRequireCleanGit {
+		if err := checkCleanGit("", flagAllowDirty); err != nil {
+			return err
+		}
+	}
+
+	if flagProvider == infra.ProviderGitLab {
+		if err := checkGitLabFlags(false); err != nil {
+			return err
+		}
+		return runGitLabDeploy(cmd.Context())
+	}
+
 	// Check gh is installed
-	if _, err := exec.LookPath("gh"); err != nil {
+	if _, err := verbose.LookPath("gh"); err != nil {
 		return fmt.Errorf("GitHub CLI (gh) is required.\n  Install: https://cli.github.com/")
 	}
 
+	// Defaults committed in the .devcli.yaml of the working directory
+	defaults := loadProjectDefaults(".")
+
+	fileInputs, err := loadInputsFile()
+	if err != nil {
+		return err
+	}
+
 	// Load history
 	hist, _ := history.Load()
 
+	// Rerun the latest run instead of triggering a new one
+	if flagRerunLast {
+		if flagLast {
+			return fmt.Errorf("--rerun-last cannot be combined with --last: --last triggers a new run, --rerun-last reruns the latest one")
+		}
+		return rerunLast(hist)
+	}
+
 	// Replay last deployment
 	if flagLast && hist != nil {
 		return replayLast(hist)
 	}
 
 	// Show history if no flags provided
-	if flagRepo == "" && flagWorkflow == "" && flagBranch == "" && hist != nil {
+	if flagRepo == "" && flagWorkflow == "" && flagBranch == "" && !flagAutoSelect && hist != nil {
 		labels := hist.Labels("deploy")
 		if len(labels) > 0 {
-			if len(labels) > 10 {
-				labels = labels[:10]
+			if len(labels) > historyMenuSize {
+				labels = append(labels[:historyMenuSize], optionSearchHistory)
 			}
 			labels = append([]string{"+ New deployment"}, labels...)
 			selected, err := ui.Select("Deploy", labels)
 			if err != nil {
 				return err
 			}
-			if selected != "+ New deployment" {
+			if selected == optionSearchHistory {
+				entry, err := searchHistory(hist, "deploy")
+				if err != nil {
+					return err
+				}
+				if entry != nil {
+					return executeDeployFromHistory(entry)
+				}
+			} else if selected != "+ New deployment" {
 				label := selected[:strings.LastIndex(selected, " (")]
 				entry := hist.FindByLabel("deploy", label)
 				if entry != nil {
@@ -99,6 +220,11 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// A new deployment has no saved inputs to edit
+	if flagEditInputs {
+		return errEditInputsNoReplay
+	}
+
 	// Step-based navigation: ESC goes back to previous step
 	var owner, repo, workflow, workflowName, branch string
 	var workflowInputValues []string
@@ -112,7 +238,17 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 	for {
 		switch step {
 		case 0: // Select owner
-			o, err := selectOwner()
+			if flagAutoSelect {
+				o, err := autoSelectOwner(hist)
+				if err != nil {
+					return err
+				}
+				owner = o
+				step++
+				continue
+			}
+			ui.PrintBreadcrumb(breadcrumb("owner"))
+			o, err := selectOwner(defaults.owner())
 			if err != nil {
 				return err // ESC → back to home
 			}
@@ -120,7 +256,16 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 			step++
 
 		case 1: // Select repo
-			r, err := selectRepoForOwner(owner)
+			if flagAutoSelect {
+				r, err := autoSelectRepo(hist, owner)
+				if err != nil {
+					return err
+				}
+				repo = r
+				step++
+				continue
+			}
+			r, err := selectRepoForOwner(owner, breadcrumb("repo", "owner", owner)...)
 			if err != nil {
 				step = 0 // ESC → back to owner
 				continue
@@ -129,9 +274,18 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 			step++
 
 		case 2: // Select workflow
-			w, wn, err := selectDeployWorkflow(repo)
+			preferred := defaults.forRepo(repo).Workflow
+			selectWorkflow := func(repo string) (string, string, error) {
+				return selectDeployWorkflow(repo, preferred, breadcrumb("workflow", "owner", owner, "repo", repo)...)
+			}
+			if flagAutoSelect && flagWorkflow == "" {
+				selectWorkflow = func(repo string) (string, string, error) {
+					return autoSelectWorkflow(hist, repo, preferred)
+				}
+			}
+			w, wn, err := selectWorkflow(repo)
 			if err != nil {
-				if flagRepo != "" {
+				if flagRepo != "" || flagAutoSelect {
 					return err // can't go back if repo was a flag
 				}
 				step = 1 // ESC → back to repo
@@ -139,12 +293,56 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 			}
 			workflow = w
 			workflowName = wn
+			if flagShowDiff {
+				showDeployDiff(hist, repo, workflow, workflowName)
+			}
 			step++
 
 		case 3: // Workflow inputs (if any)
-			if len(flagInputs) > 0 {
-				// Inputs provided via flags, skip interactive
-				workflowInputValues = flagInputs
+			projectInputs := defaults.forRepo(repo).Inputs
+			if flagInputsEditor {
+				inputs, err := fetchWorkflowInputs(repo, workflow)
+				if err != nil {
+					return fmt.Errorf("could not read the inputs of %s: %w", workflow, err)
+				}
+				prefill := mergeInputDefaults(projectInputs, infra.WorkflowInputDefaults(inputs))
+				values, err := editInputs(workflow, inputs, prefill)
+				if err != nil {
+					return err
+				}
+				workflowInputValues = overrideInputs(values, flagInputs)
+				step++
+				continue
+			}
+
+			if len(flagInputs) > 0 || fileInputs != nil {
+				// Inputs provided via flags or a file, skip interactive
+				given := flagInputs
+				inputs, fetchErr := fetchWorkflowInputs(repo, workflow)
+				if fetchErr == nil {
+					if err := checkInputKeys(flagInputs, infra.OrderedInputKeys(inputs)); err != nil {
+						return err
+					}
+				}
+				if fileInputs != nil {
+					values := fileInputs
+					if fetchErr == nil {
+						declared := make(map[string]bool, len(inputs))
+						for name := range inputs {
+							declared[name] = true
+						}
+						values, err = coerceInputValues(inputs, dropUnknownInputs(fileInputs, declared))
+						if err != nil {
+							return err
+						}
+					}
+					given = overrideInputs(values, flagInputs)
+				}
+				workflowInputValues = applyInputDefaults(given, projectInputs)
+				if fetchErr == nil {
+					defaults := mergeInputDefaults(projectInputs, infra.WorkflowInputDefaults(inputs))
+					workflowInputValues = applyInputDefaults(given, defaults)
+				}
 				step++
 				continue
 			}
@@ -164,8 +362,23 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 				continue
 			}
 
+			if flagAutoSelect {
+				prefill := mergeInputDefaults(lastUsedInputs(hist, repo, workflowName), projectInputs)
+				workflowInputValues = autoSelectInputs(inputs, prefill)
+				step++
+				continue
+			}
+
 			ui.PrintStep("◆", "Workflow inputs")
-			values, err := promptWorkflowInputs(inputs)
+			prefill := projectInputs
+			if flagReuseInputs {
+				lastUsed := lastUsedInputs(hist, repo, workflowName)
+				if len(lastUsed) > 0 {
+					ui.PrintStep("↻", fmt.Sprintf("Pre-filled %d input(s) from the last deploy", len(lastUsed)))
+				}
+				prefill = mergeInputDefaults(lastUsed, projectInputs)
+			}
+			values, err := promptWorkflowInputs(inputs, prefill)
 			if err != nil {
 				step = 2 // ESC → back to workflow
 				continue
@@ -174,7 +387,16 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 			step++
 
 		case 4: // Select branch
-			b, err := selectBranch(repo)
+			if flagAutoSelect && flagBranch == "" {
+				b, err := autoSelectBranch(hist, repo, workflow, defaults.forRepo(repo).Branch)
+				if err != nil {
+					return err
+				}
+				branch = b
+				step++
+				continue
+			}
+			b, err := selectBranch(repo, defaults.forRepo(repo).Branch, breadcrumb("branch", "repo", repo, "workflow", workflowName)...)
 			if err != nil {
 				step = 3 // ESC → back to inputs
 				continue
@@ -183,62 +405,109 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 			step++
 
 		case 5: // Trigger
+			if err := checkTagLikeBranch(repo, branch); err != nil {
+				if flagBranch != "" || flagAutoSelect {
+					return err
+				}
+				ui.PrintError(err.Error())
+				step = 4 // back to the branch
+				continue
+			}
 			label := fmt.Sprintf("%s/%s @ %s", repo, workflowName, branch)
 			deployArgs := []string{"--repo", repo, "--workflow", workflow, "--branch", branch}
 			for _, input := range workflowInputValues {
 				deployArgs = append(deployArgs, "--input", input)
 			}
+			runLabel := label
+			if flagDeployLabel != "" {
+				deployArgs = append(deployArgs, "--label", flagDeployLabel)
+				runLabel = flagDeployLabel
+			}
+
+			if flagRequireApproval {
+				if err := confirmApproval(repo, workflowInputValues); err != nil {
+					return err
+				}
+			}
+
+			if flagCancelPrevious {
+				if err := cancelPreviousRuns(repo, workflow, branch); err != nil {
+					return err
+				}
+			}
 
 			if err := triggerWorkflowWithInputs(repo, workflow, branch, workflowInputValues); err != nil {
 				return err
 			}
 
 			if hist != nil {
-				hist.Add("deploy", label, deployArgs)
+				hist.Add("deploy", historyLabel(label, flagDeployLabel), verbose.RedactArgs(deployArgs))
 				hist.Save() //nolint:errcheck
 			}
 
 			// Track the run for the dashboard
-			runID, findErr := findLatestRunID(repo, workflow)
-			if findErr == nil && runID != "" {
-				runs, loadErr := tracker.Load()
-				if loadErr == nil {
-					runs.Add(repo, workflow, branch, runID, label)
-					runs.Save() //nolint:errcheck
-				}
-				ui.PrintStep("◉", fmt.Sprintf("Tracking run #%s — view with `devcli status`", runID))
-			}
+			run, findErr := trackTriggeredRun(repo, workflow, branch, runLabel)
 
 			if flagWatch {
+				if findErr == nil {
+					return watchRunJobs(repo, run.ID)
+				}
 				return watchLatestRun(repo, workflow)
 			}
+			if flagWait {
+				if findErr != nil {
+					return fmt.Errorf("could not find the triggered run to wait for: %w", findErr)
+				}
+				return waitForRun(repo, run.ID)
+			}
+			if findErr != nil {
+				ui.PrintWarning(fmt.Sprintf("Could not find the triggered run, it won't appear in `devcli status`: %s", findErr))
+			}
 			return nil
 		}
 	}
 }
 
-func selectOwner() (string, error) {
+// currentGitHubRepo returns the owner/name of the repository cloned in the
+// working directory, or "" outside a GitHub repository.
+func currentGitHubRepo() string {
+	out, err := verbose.Cmd(exec.Command("gh", "repo", "view", "--json", "nameWithOwner", "-q", ".nameWithOwner")).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// selectOwner asks for the owner of the repository, preferred preselected
+// when it is one of the user's owners.
+func selectOwner(preferred string) (string, error) {
 	owners := listOwners()
 	if len(owners) == 0 {
 		return "", fmt.Errorf("could not determine GitHub user/orgs")
 	}
+	autoPicked["owner"] = len(owners) == 1
 	if len(owners) == 1 {
 		return owners[0], nil
 	}
+	if containsString(owners, preferred) {
+		return ui.SelectWithDefault("Select owner", owners, preferred)
+	}
 	return ui.Select("Select owner", owners)
 }
 
-func selectRepoForOwner(owner string) (string, error) {
+// selectRepoForOwner, selectDeployWorkflow and selectBranch show steps, the
+// breadcrumb of the interactive flow, above their prompt.
+func selectRepoForOwner(owner string, steps ...ui.BreadcrumbStep) (string, error) {
 	ui.PrintStep("◆", fmt.Sprintf("Organization: %s", owner))
 
 	// Try to detect current repo
-	var currentRepo string
-	out, err := verbose.Cmd(exec.Command("gh", "repo", "view", "--json", "nameWithOwner", "-q", ".nameWithOwner")).Output()
-	if err == nil {
-		currentRepo = strings.TrimSpace(string(out))
-	}
+	currentRepo := currentGitHubRepo()
 
-	repos, err := listReposForOwner(owner)
+	limit := resolveRepoLimit()
+	spin := ui.StartSpinner("Loading repositories…")
+	repos, err := listReposForOwner(owner, limit)
+	spin.Stop()
+	repos = sortRepos(repos, currentRepo)
 	if err != nil || len(repos) == 0 {
 		ui.PrintWarning(fmt.Sprintf("Could not list repositories for %s", owner))
 		// Use Select with manual entry option so ESC works for back navigation
@@ -291,7 +560,51 @@ func selectRepoForOwner(owner string) (string, error) {
 		})
 	}
 
-	return ui.SelectWithOptions("Select repository", options)
+	if len(repos) >= limit {
+		fmt.Println(ui.MutedStyle.Render("  " + repoLimitNote(limit, countReposForOwner(owner))))
+	}
+
+	return ui.SelectWithContext("Select repository", steps, options)
+}
+
+// sortRepos orders repositories by name, the current one first. gh lists
+// them by last update, which makes a repository hard to find in a large
+// organization.
+func sortRepos(repos []repoInfo, current string) []repoInfo {
+	sorted := slices.Clone(repos)
+	slices.SortStableFunc(sorted, func(a, b repoInfo) int {
+		if (a.NameWithOwner == current) != (b.NameWithOwner == current) {
+			if a.NameWithOwner == current {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(strings.ToLower(a.NameWithOwner), strings.ToLower(b.NameWithOwner))
+	})
+	return sorted
+}
+
+// repoLimitNote tells that the repository list is truncated. total is 0
+// when the owner's repository count is unknown.
+func repoLimitNote(limit, total int) string {
+	if total > limit {
+		return fmt.Sprintf("(showing %d of %d, use --repo-limit to see more)", limit, total)
+	}
+	return fmt.Sprintf("(showing the first %d, use --repo-limit to see more)", limit)
+}
+
+// countReposForOwner returns how many repositories an owner has, or 0 when
+// the count cannot be fetched.
+func countReposForOwner(owner string) int {
+	out, err := verbose.Cmd(exec.Command("gh", "api", "graphql",
+		"-f", "query=query($login: String!) { repositoryOwner(login: $login) { repositories { totalCount } } }",
+		"-F", "login="+owner,
+		"--jq", ".data.repositoryOwner.repositories.totalCount")).Output()
+	if err != nil {
+		return 0
+	}
+	total, _ := strconv.Atoi(strings.TrimSpace(string(out)))
+	return total
 }
 
 func replayLast(hist *history.Store) error {
@@ -309,9 +622,20 @@ func replayLast(hist *history.Store) error {
 	return executeDeployFromHistory(entry)
 }
 
+// historyLabel names a deploy in the history. A user label is appended to the
+// repo/workflow @ branch label rather than replacing it, so the lookups of
+// the last inputs of a workflow still match the entry.
+func historyLabel(label, userLabel string) string {
+	if userLabel == "" {
+		return label
+	}
+	return fmt.Sprintf("%s [%s]", label, userLabel)
+}
+
 func executeDeployFromHistory(entry *history.Entry) error {
-	var repo, workflow, branch string
+	var repo, workflow, branch, provider string
 	var inputs []string
+	runLabel := entry.Label
 	for i := 0; i < len(entry.Args)-1; i += 2 {
 		switch entry.Args[i] {
 		case "--repo":
@@ -322,26 +646,74 @@ func executeDeployFromHistory(entry *history.Entry) error {
 			branch = entry.Args[i+1]
 		case "--input":
 			inputs = append(inputs, entry.Args[i+1])
+		case "--label":
+			runLabel = entry.Args[i+1]
+		case "--provider":
+			provider = entry.Args[i+1]
 		}
 	}
+	if flagDeployLabel != "" {
+		runLabel = flagDeployLabel
+	}
 
 	if repo == "" || workflow == "" || branch == "" {
 		return fmt.Errorf("incomplete history entry")
 	}
+	if provider == infra.ProviderGitLab {
+		return replayGitLabDeploy(repo, workflow, branch, inputs)
+	}
+	if err := checkTagLikeBranch(repo, branch); err != nil {
+		return err
+	}
+
+	var err error
+	if flagEditInputs {
+		inputs, err = editReplayInputs(repo, workflow, inputs)
+	} else {
+		inputs, err = promptRedactedInputs(inputs)
+	}
+	if err != nil {
+		return err
+	}
 
 	ui.PrintStep("↻", fmt.Sprintf("Replaying: %s", entry.Label))
+	if flagRequireApproval {
+		if err := confirmApproval(repo, inputs); err != nil {
+			return err
+		}
+	}
+	if flagCancelPrevious {
+		if err := cancelPreviousRuns(repo, workflow, branch); err != nil {
+			return err
+		}
+	}
 	if err := triggerWorkflowWithInputs(repo, workflow, branch, inputs); err != nil {
 		return err
 	}
 
+	run, findErr := trackTriggeredRun(repo, workflow, branch, runLabel)
+
 	if flagWatch {
+		if findErr == nil {
+			return watchRunJobs(repo, run.ID)
+		}
 		return watchLatestRun(repo, workflow)
 	}
+	if flagWait {
+		if findErr != nil {
+			return fmt.Errorf("could not find the triggered run to wait for: %w", findErr)
+		}
+		return waitForRun(repo, run.ID)
+	}
 	return nil
 }
 
-func listReposForOwner(owner string) ([]repoInfo, error) {
-	args := []string{"repo", "list", "--json", "nameWithOwner,description", "--limit", "10"}
+func listReposForOwner(owner string, limit int) ([]repoInfo, error) {
+	if repos, ok := ghCache.Repos(owner); ok {
+		return repos, nil
+	}
+
+	args := []string{"repo", "list", "--json", "nameWithOwner,description", "--limit", strconv.Itoa(limit)}
 	if owner != "" {
 		args = append(args, owner)
 	}
@@ -353,11 +725,14 @@ func listReposForOwner(owner string) ([]repoInfo, error) {
 	if err := json.Unmarshal(out, &repos); err != nil {
 		return nil, err
 	}
+	ghCache.SetRepos(owner, repos)
 	return repos, nil
 }
 
 func listOwners() []string {
-	userOut, err := verbose.Cmd(exec.Command("gh", "api", "user", "--jq", ".login")).Output()
+	userOut, err := retry.Output(func() *exec.Cmd {
+		return verbose.Cmd(exec.Command("gh", "api", "user", "--jq", ".login"))
+	})
 	if err != nil {
 		return nil
 	}
@@ -365,7 +740,9 @@ func listOwners() []string {
 
 	owners := []string{user}
 
-	orgsOut, err := verbose.Cmd(exec.Command("gh", "api", "user/orgs", "--jq", ".[].login")).Output()
+	orgsOut, err := retry.Output(func() *exec.Cmd {
+		return verbose.Cmd(exec.Command("gh", "api", "user/orgs", "--jq", ".[].login"))
+	})
 	if err == nil {
 		for _, org := range strings.Split(strings.TrimSpace(string(orgsOut)), "\n") {
 			org = strings.TrimSpace(org)
@@ -378,19 +755,14 @@ func listOwners() []string {
 	return owners
 }
 
-func selectDeployWorkflow(repo string) (fileName, displayName string, err error) {
+func selectDeployWorkflow(repo, preferred string, steps ...ui.BreadcrumbStep) (fileName, displayName string, err error) {
 	if flagWorkflow != "" {
 		return flagWorkflow, flagWorkflow, nil
 	}
 
-	out, err := verbose.Cmd(exec.Command("gh", "workflow", "list", "--repo", repo, "--json", "name,id,path,state")).Output()
+	workflows, err := listWorkflows(repo)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to list workflows: %w", err)
-	}
-
-	var workflows []ghWorkflow
-	if err := json.Unmarshal(out, &workflows); err != nil {
-		return "", "", fmt.Errorf("failed to parse workflows: %w", err)
+		return "", "", err
 	}
 
 	var active []ghWorkflow
@@ -403,13 +775,14 @@ func selectDeployWorkflow(repo string) (fileName, displayName string, err error)
 	if len(active) == 0 {
 		return "", "", fmt.Errorf("no active workflows found in %s", repo)
 	}
+	active = preferWorkflow(active, preferred)
 
 	options := make([]string, len(active))
 	for i, w := range active {
 		options[i] = fmt.Sprintf("%s (%s)", w.Name, extractWorkflowFile(w.Path))
 	}
 
-	selected, err := ui.Select("Select workflow", options)
+	selected, err := ui.SelectWithContext("Select workflow", steps, ui.StringOptions(options))
 	if err != nil {
 		return "", "", err
 	}
@@ -423,38 +796,165 @@ func selectDeployWorkflow(repo string) (fileName, displayName string, err error)
 	return "", "", fmt.Errorf("workflow not found")
 }
 
-func selectBranch(repo string) (string, error) {
+// selectBranch asks for the branch to deploy, suggesting preferred (the
+// branch of .devcli.yaml) when set, the current or default branch otherwise.
+func selectBranch(repo, preferred string, steps ...ui.BreadcrumbStep) (string, error) {
 	if flagBranch != "" {
 		return flagBranch, nil
 	}
 
-	out, err := verbose.Cmd(exec.Command("gh", "api", fmt.Sprintf("repos/%s/branches", repo),
-		"--jq", ".[].name", "--paginate")).Output()
+	spin := ui.StartSpinner("Loading branches…")
+	branches, err := listRepoBranches(repo)
+	spin.Stop()
+	suggested := preferred
+	if suggested == "" || (err == nil && !containsString(branches, suggested)) {
+		current, currentErr := currentGitBranch("")
+		suggested = suggestBranch(current, currentErr, branches, func() string {
+			return repoDefaultBranch(repo)
+		})
+	}
+
 	if err != nil {
-		branch, err := ui.Input("Branch name", "main")
+		branch, err := ui.Input("Branch name", suggested)
 		if err != nil {
 			return "", err
 		}
 		if branch == "" {
-			return "main", nil
+			return suggested, nil
 		}
 		return branch, nil
 	}
 
-	branches := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(branches) == 0 {
+		return suggested, nil
+	}
+
+	ui.PrintBreadcrumb(steps)
+	useSuggested, err := ui.Confirm(fmt.Sprintf("Deploy branch %s?", suggested))
+	if err != nil {
+		return "", err
+	}
+	if useSuggested {
+		return suggested, nil
+	}
+
+	return selectBranchFromList(branches, resolveBranchLimit(), steps)
+}
+
+// selectBranchFromList lets the user pick among the first limit branches.
+// When there are more, "Load more" lists twice as many and "Enter manually"
+// prompts for a branch name.
+func selectBranchFromList(branches []string, limit int, steps []ui.BreadcrumbStep) (string, error) {
+	for {
+		options := ui.StringOptions(branches[:min(limit, len(branches))])
+		if len(branches) > limit {
+			options = append(options, ui.StringOptions([]string{optionLoadMore, optionEnterManual})...)
+		}
+
+		selected, err := ui.SelectWithContext("Select branch", steps, options)
+		if err != nil {
+			return "", err
+		}
+		switch selected {
+		case optionLoadMore:
+			limit *= 2
+			continue
+		case optionEnterManual:
+			branch, err := ui.Input("Branch name", "")
+			if err != nil {
+				return "", err
+			}
+			if branch == "" {
+				return "", fmt.Errorf("no branch specified")
+			}
+			return branch, nil
+		}
+		return selected, nil
+	}
+}
+
+// preferWorkflow moves the workflow whose file is preferred to the front of
+// the list, where the prompt starts.
+func preferWorkflow(workflows []ghWorkflow, preferred string) []ghWorkflow {
+	for i, w := range workflows {
+		if preferred != "" && extractWorkflowFile(w.Path) == preferred {
+			sorted := append([]ghWorkflow{w}, workflows[:i]...)
+			return append(sorted, workflows[i+1:]...)
+		}
+	}
+	return workflows
+}
+
+// listWorkflows returns all workflows of a repository, cached per session.
+func listWorkflows(repo string) ([]ghWorkflow, error) {
+	if workflows, ok := ghCache.Workflows(repo); ok {
+		return workflows, nil
+	}
+
+	out, err := verbose.Cmd(exec.Command("gh", "workflow", "list", "--repo", repo, "--json", "name,id,path,state")).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflows: %w", err)
+	}
+
+	var workflows []ghWorkflow
+	if err := json.Unmarshal(out, &workflows); err != nil {
+		return nil, fmt.Errorf("failed to parse workflows: %w", err)
+	}
+
+	ghCache.SetWorkflows(repo, workflows)
+	return workflows, nil
+}
+
+// listRepoBranches returns all branch names of a repository, cached per session.
+func listRepoBranches(repo string) ([]string, error) {
+	if branches, ok := ghCache.Branches(repo); ok {
+		return branches, nil
+	}
+
+	out, err := retry.Output(func() *exec.Cmd {
+		return verbose.Cmd(exec.Command("gh", "api", fmt.Sprintf("repos/%s/branches", repo),
+			"--jq", ".[].name", "--paginate"))
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	var cleaned []string
-	for _, b := range branches {
+	for _, b := range strings.Split(strings.TrimSpace(string(out)), "\n") {
 		b = strings.TrimSpace(b)
 		if b != "" {
 			cleaned = append(cleaned, b)
 		}
 	}
 
-	if len(cleaned) == 0 {
-		return "main", nil
+	ghCache.SetBranches(repo, cleaned)
+	return cleaned, nil
+}
+
+// cancelPreviousRuns cancels the in-progress runs of a workflow before a new trigger.
+func cancelPreviousRuns(repo, workflow, branch string) error {
+	orchestrator := application.NewTriggerDeploymentOrchestrator(infra.CreateRepositories(repo))
+	return cancelInFlightRuns(context.Background(), orchestrator, workflow, branch)
+}
+
+// cancelInFlightRuns asks the orchestrator to cancel in-progress runs and reports the count.
+func cancelInFlightRuns(ctx context.Context, orchestrator *application.TriggerDeploymentOrchestrator, workflowName, branch string) error {
+	workflow, err := domain.NewWorkflow(workflowName)
+	if err != nil {
+		return err
 	}
 
-	return ui.Select("Select branch", cleaned)
+	cancelled, err := orchestrator.CancelPreviousRuns(ctx, application.CancelPreviousRunsRequest{Workflow: workflow, Branch: branch})
+	if err != nil {
+		return err
+	}
+
+	if cancelled == 0 {
+		ui.PrintStep("·", "No in-progress runs to cancel")
+	} else {
+		ui.PrintStep("⊘", fmt.Sprintf("Cancelled %d in-progress run(s) of %s", cancelled, workflowName))
+	}
+	return nil
 }
 
 func triggerWorkflowWithInputs(repo, workflow, branch string, inputs []string) error {
@@ -466,12 +966,16 @@ func triggerWorkflowWithInputs(repo, workflow, branch string, inputs []string) e
 
 	ui.PrintStep("▶", fmt.Sprintf("Triggering %s on %s (branch: %s)", workflow, repo, branch))
 
+	_, span := telemetry.Start(rootCmd.Context(), "workflow-trigger",
+		telemetry.WorkflowKey.String(workflow), telemetry.BranchKey.String(branch))
 	c := verbose.Cmd(exec.Command("gh", ghArgs...))
 	c.Stdin = os.Stdin
 	c.Stdout = os.Stdout
 	c.Stderr = os.Stderr
 
-	if err := c.Run(); err != nil {
+	err := c.Run()
+	telemetry.End(span, err)
+	if err != nil {
 		return fmt.Errorf("failed to trigger workflow: %w", err)
 	}
 
@@ -482,40 +986,16 @@ func triggerWorkflowWithInputs(repo, workflow, branch string, inputs []string) e
 func watchLatestRun(repo, workflow string) error {
 	ui.PrintStep("◉", "Waiting for workflow run to start...")
 
-	time.Sleep(3 * time.Second)
-
-	out, err := verbose.Cmd(exec.Command("gh", "run", "list",
-		"--repo", repo,
-		"--workflow", workflow,
-		"--limit", "1",
-		"--json", "databaseId",
-		"-q", ".[0].databaseId")).Output()
+	run, err := findLatestRun(repo, workflow)
 	if err != nil {
 		return fmt.Errorf("failed to get run ID: %w", err)
 	}
 
-	runID := strings.TrimSpace(string(out))
-	if runID == "" {
-		return fmt.Errorf("no run found")
+	if run.URL != "" {
+		fmt.Println(ui.MutedStyle.Render("  " + run.URL))
 	}
 
-	ui.PrintStep("◉", fmt.Sprintf("Streaming logs for run #%s", runID))
-	fmt.Println(ui.BoxStyle.Render("Press Ctrl+C to stop watching"))
-	fmt.Println()
-
-	watchCmd := verbose.Cmd(exec.Command("gh", "run", "watch", runID, "--repo", repo, "--exit-status"))
-	watchCmd.Stdin = os.Stdin
-	watchCmd.Stdout = os.Stdout
-	watchCmd.Stderr = os.Stderr
-
-	if err := watchCmd.Run(); err != nil {
-		ui.PrintError(fmt.Sprintf("Workflow run failed (run #%s)", runID))
-		fmt.Printf("\nView full logs: gh run view %s --repo %s --log\n", runID, repo)
-		return err
-	}
-
-	ui.PrintSuccess(fmt.Sprintf("Workflow run #%s completed successfully", runID))
-	return nil
+	return watchRunJobs(repo, run.ID)
 }
 
 func extractWorkflowFile(path string) string {
@@ -524,22 +1004,7 @@ func extractWorkflowFile(path string) string {
 }
 
 // workflowInput represents a single input from workflow_dispatch.
-type workflowInput struct {
-	Description string   `yaml:"description"`
-	Required    bool     `yaml:"required"`
-	Default     string   `yaml:"default"`
-	Type        string   `yaml:"type"`
-	Options     []string `yaml:"options"`
-}
-
-// workflowFile represents the relevant parts of a GitHub Actions workflow YAML.
-type workflowFile struct {
-	On struct {
-		WorkflowDispatch struct {
-			Inputs map[string]workflowInput `yaml:"inputs"`
-		} `yaml:"workflow_dispatch"`
-	} `yaml:"on"`
-}
+type workflowInput = infra.WorkflowInputSpec
 
 // fetchWorkflowInputs retrieves the workflow file from GitHub and parses its inputs.
 func fetchWorkflowInputs(repo, workflowFileName string) (map[string]workflowInput, error) {
@@ -559,45 +1024,54 @@ func fetchWorkflowInputs(repo, workflowFileName string) (map[string]workflowInpu
 		return nil, fmt.Errorf("failed to decode workflow file: %w", err)
 	}
 
-	var wf workflowFile
-	if err := yaml.Unmarshal(decoded, &wf); err != nil {
-		return nil, fmt.Errorf("failed to parse workflow YAML: %w", err)
+	inputs, err := infra.ParseWorkflowInputs(decoded)
+	if err != nil {
+		return nil, err
 	}
+	schema := fetchSchema(repo)
+	applyInputConditions(inputs, parseSchemaConditions(schema, workflowFileName))
+	applyInputTypes(inputs, parseSchemaTypes(schema, workflowFileName))
 
-	return wf.On.WorkflowDispatch.Inputs, nil
+	return inputs, nil
 }
 
 // promptWorkflowInputs interactively prompts the user for each workflow input.
-func promptWorkflowInputs(inputs map[string]workflowInput) ([]string, error) {
+// Values in prefill (e.g. reused from the last deploy) take precedence over
+// the workflow defaults and are accepted with enter. Inputs whose condition
+// does not hold for the values collected so far are skipped.
+func promptWorkflowInputs(inputs map[string]workflowInput, prefill map[string]string) ([]string, error) {
 	if len(inputs) == 0 {
 		return nil, nil
 	}
 
-	// Collect input names in a stable order
-	var names []string
-	for name := range inputs {
-		names = append(names, name)
-	}
-	// Sort for consistent ordering
-	sort.Strings(names)
-
 	var result []string
-	for _, name := range names {
+	collected := make(map[string]string, len(inputs))
+	for _, name := range infra.OrderedInputKeys(inputs) {
 		input := inputs[name]
+		if !input.Condition.Eval(collected) {
+			fmt.Println(ui.MutedStyle.Render(fmt.Sprintf("  Skipping %s (only when %s)", name, input.Condition)))
+			continue
+		}
+
 		label := name
 		if input.Description != "" {
 			label = fmt.Sprintf("%s (%s)", name, input.Description)
 		}
 
+		def := input.Default
+		if v, ok := prefill[name]; ok {
+			def = v
+		}
+
 		var value string
 		var err error
 
 		if input.Type == "choice" && len(input.Options) > 0 {
 			// Show select for choice inputs
 			options := input.Options
-			value, err = ui.Select(label, options)
+			value, err = ui.SelectWithDefault(label, options, def)
 		} else if input.Type == "boolean" {
-			confirmed, confirmErr := ui.Confirm(label)
+			confirmed, confirmErr := ui.ConfirmWithDefault(label, def == "true")
 			if confirmErr != nil {
 				return nil, confirmErr
 			}
@@ -607,23 +1081,22 @@ func promptWorkflowInputs(inputs map[string]workflowInput) ([]string, error) {
 				value = "false"
 			}
 			err = nil
+		} else if input.InputType() == domain.InputTypeNumber {
+			value, err = ui.InputWithValidation(label, def, numberValidator(name))
 		} else {
 			// Text input with default as placeholder
-			placeholder := input.Default
-			if placeholder == "" {
-				placeholder = ""
-			}
-			value, err = ui.Input(label, placeholder)
+			value, err = ui.Input(label, def)
 		}
 
 		if err != nil {
 			return nil, err
 		}
 
-		if value == "" && input.Default != "" {
-			value = input.Default
+		if value == "" && def != "" {
+			value = def
 		}
 
+		collected[name] = value
 		if value != "" {
 			result = append(result, fmt.Sprintf("%s=%s", name, value))
 		}
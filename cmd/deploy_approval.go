@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/20uf/devcli/internal/retry"
+	"github.com/20uf/devcli/internal/ui"
+	"github.com/20uf/devcli/internal/verbose"
+)
+
+// environmentInputKeys are the workflow inputs conventionally naming the target environment.
+var environmentInputKeys = []string{"environment", "env"}
+
+// environmentFromInputs returns the target environment of key=value workflow inputs.
+func environmentFromInputs(inputs []string) string {
+	values := make(map[string]string)
+	for _, input := range inputs {
+		if key, value, ok := strings.Cut(input, "="); ok {
+			values[strings.ToLower(key)] = value
+		}
+	}
+	for _, key := range environmentInputKeys {
+		if v := values[key]; v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// fetchEnvironmentReviewers lists the required reviewers of a repository environment.
+func fetchEnvironmentReviewers(repo, env string) ([]string, error) {
+	out, err := retry.Output(func() *exec.Cmd {
+		return verbose.Cmd(exec.Command("gh", "api", fmt.Sprintf("repos/%s/environments/%s", repo, env)))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch environment %s: %w", env, err)
+	}
+	return parseEnvironmentReviewers(out)
+}
+
+// parseEnvironmentReviewers extracts the required reviewers of an environment
+// API response. Users are listed by login and teams as org/slug.
+func parseEnvironmentReviewers(payload []byte) ([]string, error) {
+	var env struct {
+		ProtectionRules []struct {
+			Type      string `json:"type"`
+			Reviewers []struct {
+				Type     string `json:"type"`
+				Reviewer struct {
+					Login        string `json:"login"`
+					Slug         string `json:"slug"`
+					Organization struct {
+						Login string `json:"login"`
+					} `json:"organization"`
+				} `json:"reviewer"`
+			} `json:"reviewers"`
+		} `json:"protection_rules"`
+	}
+	if err := json.Unmarshal(payload, &env); err != nil {
+		return nil, fmt.Errorf("failed to parse environment: %w", err)
+	}
+
+	var reviewers []string
+	for _, rule := range env.ProtectionRules {
+		if rule.Type != "required_reviewers" {
+			continue
+		}
+		for _, r := range rule.Reviewers {
+			switch {
+			case r.Type == "Team" && r.Reviewer.Organization.Login != "":
+				reviewers = append(reviewers, r.Reviewer.Organization.Login+"/"+r.Reviewer.Slug)
+			case r.Type == "Team":
+				reviewers = append(reviewers, r.Reviewer.Slug)
+			default:
+				reviewers = append(reviewers, r.Reviewer.Login)
+			}
+		}
+	}
+	return reviewers, nil
+}
+
+// confirmApproval shows the reviewers required by the target environment and
+// asks whether to trigger anyway. Declining aborts the deploy.
+func confirmApproval(repo string, inputs []string) error {
+	env := environmentFromInputs(inputs)
+	if env == "" {
+		ui.PrintWarning("No environment input found, cannot check required reviewers")
+		return nil
+	}
+
+	reviewers, err := fetchEnvironmentReviewers(repo, env)
+	if err != nil {
+		ui.PrintWarning(err.Error())
+		return nil
+	}
+	if len(reviewers) == 0 {
+		ui.PrintStep("·", fmt.Sprintf("Environment %s has no required reviewers", env))
+		return nil
+	}
+
+	ui.PrintInfo(fmt.Sprintf("Approval required for %s", env), strings.Join(reviewers, "\n"))
+	confirmed, err := ui.Confirm("Trigger and wait for approval?")
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return ui.ErrUserAbort
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Test: Required reviewers are read from the environment API response
+func TestParseEnvironmentReviewers(t *testing.T) {
+	payload := []byte(`{
+		"name": "production",
+		"protection_rules": [
+			{"id": 1, "type": "wait_timer", "wait_timer": 30},
+			{"id": 2, "type": "required_reviewers", "reviewers": [
+				{"type": "User", "reviewer": {"login": "octocat", "id": 1}},
+				{"type": "Team", "reviewer": {"slug": "ops", "name": "Ops", "organization": {"login": "acme"}}}
+			]}
+		]
+	}`)
+
+	got, err := parseEnvironmentReviewers(payload)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := []string{"octocat", "acme/ops"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseEnvironmentReviewers() = %v, want %v", got, want)
+	}
+
+	none, err := parseEnvironmentReviewers([]byte(`{"name": "staging", "protection_rules": []}`))
+	if err != nil || len(none) != 0 {
+		t.Errorf("Unprotected environment should have no reviewers, got %v (%v)", none, err)
+	}
+
+	if _, err := parseEnvironmentReviewers([]byte(`{invalid`)); err == nil {
+		t.Errorf("Malformed JSON should fail")
+	}
+
+	t.Log("✓ Environment reviewers parsed")
+}
+
+// Test: The target environment is read from the workflow inputs
+func TestEnvironmentFromInputs(t *testing.T) {
+	tests := []struct {
+		inputs []string
+		want   string
+	}{
+		{[]string{"version=1.2", "environment=production"}, "production"},
+		{[]string{"ENV=staging"}, "staging"},
+		{[]string{"version=1.2"}, ""},
+		{nil, ""},
+	}
+
+	for _, tt := range tests {
+		if got := environmentFromInputs(tt.inputs); got != tt.want {
+			t.Errorf("environmentFromInputs(%v) = %q, want %q", tt.inputs, got, tt.want)
+		}
+	}
+
+	t.Log("✓ Target environment resolved")
+}
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/20uf/devcli/internal/deployment/infra"
+	"github.com/20uf/devcli/internal/history"
+	"github.com/20uf/devcli/internal/ui"
+)
+
+// recentDeployArg returns the value of flag in the most recent deploy of the
+// history whose args also hold each of the given flag/value pairs, or "".
+func recentDeployArg(hist *history.Store, flag string, match ...string) string {
+	if hist == nil {
+		return ""
+	}
+
+	for i := len(hist.Entries) - 1; i >= 0; i-- {
+		e := hist.Entries[i]
+		if e.Command != "deploy" || deployArg(e.Args, "--provider") == infra.ProviderGitLab {
+			continue
+		}
+		matches := true
+		for j := 0; j+1 < len(match); j += 2 {
+			if deployArg(e.Args, match[j]) != match[j+1] {
+				matches = false
+				break
+			}
+		}
+		if value := deployArg(e.Args, flag); matches && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// deployArg returns the value following flag in the args of a history entry.
+func deployArg(args []string, flag string) string {
+	for i := 0; i < len(args)-1; i++ {
+		if args[i] == flag {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+// pickRecent returns recent when it is one of options, the first option
+// otherwise.
+func pickRecent(options []string, recent string) string {
+	if recent != "" && containsString(options, recent) {
+		return recent
+	}
+	return options[0]
+}
+
+func printAutoSelected(what, value string) {
+	ui.PrintStep("→", fmt.Sprintf("Auto-selected %s: %s", what, value))
+}
+
+// autoSelectOwner picks the owner of the last deployed repository.
+func autoSelectOwner(hist *history.Store) (string, error) {
+	owners := listOwners()
+	if len(owners) == 0 {
+		return "", fmt.Errorf("could not determine GitHub user/orgs")
+	}
+
+	recent, _, _ := strings.Cut(recentDeployArg(hist, "--repo"), "/")
+	owner := pickRecent(owners, recent)
+	printAutoSelected("owner", owner)
+	return owner, nil
+}
+
+// autoSelectRepo picks the last deployed repository of owner.
+func autoSelectRepo(hist *history.Store, owner string) (string, error) {
+	spin := ui.StartSpinner("Loading repositories…")
+	repos, err := listReposForOwner(owner, resolveRepoLimit())
+	spin.Stop()
+	if err != nil {
+		return "", fmt.Errorf("could not list repositories for %s: %w", owner, err)
+	}
+	if len(repos) == 0 {
+		return "", fmt.Errorf("no repository found for %s", owner)
+	}
+
+	names := make([]string, len(repos))
+	for i, r := range repos {
+		names[i] = r.NameWithOwner
+	}
+	repo := pickRecent(names, recentDeployArg(hist, "--repo"))
+	printAutoSelected("repository", repo)
+	return repo, nil
+}
+
+// autoSelectWorkflow picks the last workflow deployed from repo among its
+// active workflows, fallback (the .devcli.yaml default) without history.
+func autoSelectWorkflow(hist *history.Store, repo, fallback string) (fileName, displayName string, err error) {
+	workflows, err := listWorkflows(repo)
+	if err != nil {
+		return "", "", err
+	}
+
+	var files, names []string
+	for _, w := range workflows {
+		if w.State == "active" {
+			files = append(files, extractWorkflowFile(w.Path))
+			names = append(names, w.Name)
+		}
+	}
+	if len(files) == 0 {
+		return "", "", fmt.Errorf("no active workflows found in %s", repo)
+	}
+
+	recent := recentDeployArg(hist, "--workflow", "--repo", repo)
+	if recent == "" {
+		recent = fallback
+	}
+	fileName = pickRecent(files, recent)
+	for i, f := range files {
+		if f == fileName {
+			displayName = names[i]
+			break
+		}
+	}
+	printAutoSelected("workflow", fmt.Sprintf("%s (%s)", displayName, fileName))
+	return fileName, displayName, nil
+}
+
+// autoSelectBranch picks the branch of the last deploy of the workflow when
+// it still exists, then fallback (the .devcli.yaml default), then the branch
+// the interactive prompt suggests.
+func autoSelectBranch(hist *history.Store, repo, workflow, fallback string) (string, error) {
+	spin := ui.StartSpinner("Loading branches…")
+	branches, err := listRepoBranches(repo)
+	spin.Stop()
+
+	recent := recentDeployArg(hist, "--branch", "--repo", repo, "--workflow", workflow)
+	if recent == "" || (err == nil && !containsString(branches, recent)) {
+		recent = fallback
+	}
+	branch := recent
+	if err != nil || recent == "" || !containsString(branches, recent) {
+		current, currentErr := currentGitBranch("")
+		branch = suggestBranch(current, currentErr, branches, func() string {
+			return repoDefaultBranch(repo)
+		})
+	}
+	if branch == "" {
+		return "", fmt.Errorf("could not determine a branch for %s", repo)
+	}
+	printAutoSelected("branch", branch)
+	return branch, nil
+}
+
+// autoSelectInputs answers the workflow inputs without prompting: prefill
+// (the last values used) first, then the workflow default, then the first
+// option of a choice. Inputs whose condition does not hold are skipped.
+func autoSelectInputs(inputs map[string]workflowInput, prefill map[string]string) []string {
+	var result []string
+	collected := make(map[string]string, len(inputs))
+	for _, name := range infra.OrderedInputKeys(inputs) {
+		input := inputs[name]
+		if !input.Condition.Eval(collected) {
+			continue
+		}
+
+		value := input.Default
+		if v, ok := prefill[name]; ok {
+			value = v
+		}
+		switch {
+		case input.Type == "choice" && len(input.Options) > 0:
+			value = pickRecent(input.Options, value)
+		case input.Type == "boolean":
+			if value != "true" {
+				value = "false"
+			}
+		}
+
+		collected[name] = value
+		if value != "" {
+			result = append(result, fmt.Sprintf("%s=%s", name, value))
+		}
+	}
+
+	printAutoSelected("inputs", fmt.Sprintf("%d value(s) from the last deploy and defaults", len(result)))
+	return result
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/20uf/devcli/internal/history"
+)
+
+// Test: The most recent deploy matching the scope provides the value
+func TestRecentDeployArg(t *testing.T) {
+	hist := &history.Store{Entries: []history.Entry{
+		{Command: "deploy", Args: []string{"--repo", "owner/api", "--workflow", "deploy.yml", "--branch", "main"}},
+		{Command: "deploy", Args: []string{"--repo", "owner/web", "--workflow", "release.yml", "--branch", "develop"}},
+		{Command: "connect", Args: []string{"--cluster", "prod"}},
+	}}
+
+	tests := []struct {
+		name  string
+		flag  string
+		match []string
+		want  string
+	}{
+		{"Last repo", "--repo", nil, "owner/web"},
+		{"Workflow of a repo", "--workflow", []string{"--repo", "owner/api"}, "deploy.yml"},
+		{"Branch of a repo and workflow", "--branch", []string{"--repo", "owner/web", "--workflow", "release.yml"}, "develop"},
+		{"Unknown repo", "--workflow", []string{"--repo", "owner/other"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := recentDeployArg(hist, tt.flag, tt.match...); got != tt.want {
+				t.Errorf("recentDeployArg() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+	if got := recentDeployArg(nil, "--repo"); got != "" {
+		t.Errorf("recentDeployArg(nil) = %q, want empty", got)
+	}
+
+	t.Log("✓ Recent deploy values found")
+}
+
+// Test: A recent value is kept only when it is still an option
+func TestPickRecent(t *testing.T) {
+	options := []string{"main", "develop"}
+	if got := pickRecent(options, "develop"); got != "develop" {
+		t.Errorf("pickRecent() = %q, want develop", got)
+	}
+	if got := pickRecent(options, "removed"); got != "main" {
+		t.Errorf("pickRecent() with a stale value = %q, want main", got)
+	}
+	if got := pickRecent(options, ""); got != "main" {
+		t.Errorf("pickRecent() without history = %q, want main", got)
+	}
+
+	t.Log("✓ Recent or first option picked")
+}
+
+// Test: Inputs are answered from the last values, defaults and first options
+func TestAutoSelectInputs(t *testing.T) {
+	inputs := map[string]workflowInput{
+		"environment": {Type: "choice", Options: []string{"staging", "production"}},
+		"dry_run":     {Type: "boolean", Default: "true"},
+		"version":     {Type: "string", Default: "latest"},
+		"notes":       {Type: "string"},
+	}
+	prefill := map[string]string{"version": "1.4.2", "dry_run": "false"}
+
+	got := autoSelectInputs(inputs, prefill)
+	sort.Strings(got)
+	want := []string{"dry_run=false", "environment=staging", "version=1.4.2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("autoSelectInputs() = %v, want %v", got, want)
+	}
+
+	t.Log("✓ Inputs auto-selected")
+}
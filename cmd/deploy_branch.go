@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/20uf/devcli/internal/deployment/domain"
+	"github.com/20uf/devcli/internal/ui"
+	"github.com/20uf/devcli/internal/verbose"
+)
+
+// fallbackBranch is used when neither the local checkout nor GitHub tell us
+// which branch to deploy.
+const fallbackBranch = "main"
+
+// currentGitBranch returns the branch checked out in dir (empty = working directory).
+// It fails outside a git repository and on a detached HEAD.
+func currentGitBranch(dir string) (string, error) {
+	args := []string{"rev-parse", "--abbrev-ref", "HEAD"}
+	if dir != "" {
+		args = append([]string{"-C", dir}, args...)
+	}
+
+	out, err := verbose.Cmd(exec.Command("git", args...)).Output()
+	if err != nil {
+		return "", fmt.Errorf("not in a git repository: %w", err)
+	}
+
+	branch := strings.TrimSpace(string(out))
+	if branch == "" || branch == "HEAD" {
+		return "", fmt.Errorf("detached HEAD")
+	}
+	return branch, nil
+}
+
+// gitDirtyFiles returns the entries of `git status --porcelain` in dir
+// (empty = working directory). An empty result means the tree is clean.
+func gitDirtyFiles(dir string) ([]string, error) {
+	args := []string{"status", "--porcelain"}
+	if dir != "" {
+		args = append([]string{"-C", dir}, args...)
+	}
+
+	out, err := verbose.Cmd(exec.Command("git", args...)).Output()
+	if err != nil {
+		return nil, fmt.Errorf("not in a git repository: %w", err)
+	}
+
+	var files []string
+	for _, line := range strings.Split(strings.TrimRight(string(out), "\n"), "\n") {
+		if strings.TrimSpace(line) != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+// checkCleanGit blocks a deploy from a dirty working tree, or only warns when
+// allowDirty is set. Outside a git repository there is nothing to check.
+func checkCleanGit(dir string, allowDirty bool) error {
+	files, err := gitDirtyFiles(dir)
+	if err != nil {
+		verbose.Log("skipping clean git check: %s", err)
+		return nil
+	}
+	if len(files) == 0 {
+		return nil
+	}
+
+	if allowDirty {
+		ui.PrintWarning(fmt.Sprintf("Working tree has %d uncommitted change(s) — deploying anyway (--allow-dirty)", len(files)))
+		return nil
+	}
+
+	return fmt.Errorf("working tree has %d uncommitted change(s)\n  Commit or stash them, or pass --allow-dirty to deploy anyway", len(files))
+}
+
+// checkTagLikeBranch rejects a branch that looks like a release tag, unless
+// --allow-tag-like-branch is set or the repository has a branch of that name
+// (a release branch such as v2.3). The branches are only listed when the
+// name looks like a tag.
+func checkTagLikeBranch(repo, branch string) error {
+	err := domain.ValidateBranch(branch)
+	if err == nil || flagAllowTagLikeBranch {
+		return nil
+	}
+	if branches, listErr := listRepoBranches(repo); listErr == nil && containsString(branches, branch) {
+		return nil
+	}
+	return fmt.Errorf("%w\n  Pass --allow-tag-like-branch if it is a branch", err)
+}
+
+// repoDefaultBranch asks GitHub for the default branch of a repository.
+func repoDefaultBranch(repo string) string {
+	out, err := verbose.Cmd(exec.Command("gh", "repo", "view", repo,
+		"--json", "defaultBranchRef",
+		"-q", ".defaultBranchRef.name")).Output()
+	if err != nil {
+		return fallbackBranch
+	}
+
+	if branch := strings.TrimSpace(string(out)); branch != "" {
+		return branch
+	}
+	return fallbackBranch
+}
+
+// suggestBranch picks the branch to pre-fill: the local checkout when the
+// remote has it, otherwise the repository default branch.
+func suggestBranch(current string, currentErr error, branches []string, defaultBranch func() string) string {
+	if currentErr == nil && current != "" {
+		if len(branches) == 0 {
+			return current
+		}
+		for _, b := range branches {
+			if b == current {
+				return current
+			}
+		}
+	}
+	return defaultBranch()
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/20uf/devcli/internal/deployment/domain"
+	"github.com/20uf/devcli/internal/history"
+)
+
+// initGitRepo creates a git repository with one commit on branch.
+func initGitRepo(t *testing.T, branch string) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q", "-b", branch},
+		{"-c", "user.name=devcli", "-c", "user.email=devcli@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	return dir
+}
+
+// Test: Current branch is read from a git checkout
+func TestCurrentGitBranch(t *testing.T) {
+	dir := initGitRepo(t, "feat/login")
+
+	branch, err := currentGitBranch(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if branch != "feat/login" {
+		t.Errorf("Got %s, want feat/login", branch)
+	}
+
+	if _, err := currentGitBranch(t.TempDir()); err == nil {
+		t.Errorf("Expected an error outside a git repository")
+	}
+
+	t.Log("✓ Current branch read from git")
+}
+
+// Test: Branch suggestion falls back to the default branch
+func TestSuggestBranch(t *testing.T) {
+	defaultBranch := func() string { return "develop" }
+	notARepo := errors.New("not in a git repository")
+
+	tests := []struct {
+		name       string
+		current    string
+		currentErr error
+		branches   []string
+		want       string
+	}{
+		{"Current branch exists remotely", "feat/login", nil, []string{"develop", "feat/login"}, "feat/login"},
+		{"Current branch unknown remotely", "local-only", nil, []string{"develop", "main"}, "develop"},
+		{"Branches unavailable", "feat/login", nil, nil, "feat/login"},
+		{"Not in a repository", "", notARepo, []string{"develop", "main"}, "develop"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := suggestBranch(tt.current, tt.currentErr, tt.branches, defaultBranch); got != tt.want {
+				t.Errorf("Got %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	t.Log("✓ Branch suggestion falls back to default branch")
+}
+
+// Test: Dirty working trees are blocked unless --allow-dirty is set
+func TestCheckCleanGit(t *testing.T) {
+	dir := initGitRepo(t, "main")
+
+	if err := checkCleanGit(dir, false); err != nil {
+		t.Errorf("Clean tree should pass: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "wip.txt"), []byte("wip"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	files, err := gitDirtyFiles(dir)
+	if err != nil || len(files) != 1 {
+		t.Fatalf("Expected 1 dirty file, got %v (%v)", files, err)
+	}
+
+	if err := checkCleanGit(dir, false); err == nil {
+		t.Errorf("Dirty tree should be blocked")
+	}
+	if err := checkCleanGit(dir, true); err != nil {
+		t.Errorf("--allow-dirty should only warn: %v", err)
+	}
+
+	if err := checkCleanGit(t.TempDir(), false); err != nil {
+		t.Errorf("Outside a git repository the check should be skipped: %v", err)
+	}
+
+	t.Log("✓ Dirty working tree detected and override honored")
+}
+
+// Test: Tag-like names are only rejected when the repository has no such branch
+func TestCheckTagLikeBranch(t *testing.T) {
+	ghCache.SetBranches("owner/release", []string{"main", "v2.3"})
+	defer func() { flagAllowTagLikeBranch = false }()
+
+	if err := checkTagLikeBranch("owner/release", "v2.3"); err != nil {
+		t.Errorf("Listed release branch rejected: %v", err)
+	}
+	err := checkTagLikeBranch("owner/release", "v1.2.3")
+	if !errors.Is(err, domain.ErrBranchLooksLikeTag) || !strings.Contains(err.Error(), "--allow-tag-like-branch") {
+		t.Errorf("checkTagLikeBranch(v1.2.3) = %v, want ErrBranchLooksLikeTag with the override", err)
+	}
+
+	flagAllowTagLikeBranch = true
+	if err := checkTagLikeBranch("owner/release", "v1.2.3"); err != nil {
+		t.Errorf("--allow-tag-like-branch ignored: %v", err)
+	}
+
+	t.Log("✓ Release branches deployable, tags rejected")
+}
+
+// Test: Replaying a deploy of a tag is rejected before triggering
+func TestExecuteDeployFromHistory_RejectsTag(t *testing.T) {
+	ghCache.SetBranches("owner/api", []string{"main"})
+	entry := &history.Entry{
+		Command: "deploy",
+		Label:   "owner/api/Deploy @ v1.2.3",
+		Args:    []string{"--repo", "owner/api", "--workflow", "deploy.yml", "--branch", "v1.2.3"},
+	}
+
+	if err := executeDeployFromHistory(entry); !errors.Is(err, domain.ErrBranchLooksLikeTag) {
+		t.Errorf("executeDeployFromHistory() = %v, want ErrBranchLooksLikeTag", err)
+	}
+
+	t.Log("✓ Tag replay rejected")
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import "sync"
+
+// sessionCache memoizes gh lookups for the lifetime of the process so that
+// navigating back with ESC in the deploy step machine doesn't re-hit GitHub.
+type sessionCache struct {
+	mu        sync.Mutex
+	repos     map[string][]repoInfo
+	workflows map[string][]ghWorkflow
+	branches  map[string][]string
+}
+
+var ghCache = newSessionCache()
+
+func newSessionCache() *sessionCache {
+	return &sessionCache{
+		repos:     make(map[string][]repoInfo),
+		workflows: make(map[string][]ghWorkflow),
+		branches:  make(map[string][]string),
+	}
+}
+
+// Repos returns the cached repositories for an owner.
+func (c *sessionCache) Repos(owner string) ([]repoInfo, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	repos, ok := c.repos[owner]
+	return repos, ok
+}
+
+// SetRepos caches the repositories listed for an owner.
+func (c *sessionCache) SetRepos(owner string, repos []repoInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.repos[owner] = repos
+}
+
+// Workflows returns the cached workflows for a repository.
+func (c *sessionCache) Workflows(repo string) ([]ghWorkflow, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	workflows, ok := c.workflows[repo]
+	return workflows, ok
+}
+
+// SetWorkflows caches the workflows listed for a repository.
+func (c *sessionCache) SetWorkflows(repo string, workflows []ghWorkflow) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.workflows[repo] = workflows
+}
+
+// Branches returns the cached branches for a repository.
+func (c *sessionCache) Branches(repo string) ([]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	branches, ok := c.branches[repo]
+	return branches, ok
+}
+
+// SetBranches caches the branches listed for a repository.
+func (c *sessionCache) SetBranches(repo string, branches []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.branches[repo] = branches
+}
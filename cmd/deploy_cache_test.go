@@ -0,0 +1,35 @@
+package cmd
+
+import "testing"
+
+// Test: Cache returns stored lookups per key
+func TestSessionCache_StoresPerKey(t *testing.T) {
+	c := newSessionCache()
+
+	if _, ok := c.Branches("owner/repo"); ok {
+		t.Fatalf("Empty cache should miss")
+	}
+
+	c.SetBranches("owner/repo", []string{"main", "develop"})
+	c.SetWorkflows("owner/repo", []ghWorkflow{{Name: "Deploy", Path: ".github/workflows/deploy.yml"}})
+	c.SetRepos("owner", []repoInfo{{NameWithOwner: "owner/repo"}})
+
+	branches, ok := c.Branches("owner/repo")
+	if !ok || len(branches) != 2 {
+		t.Errorf("Branches not cached: %v", branches)
+	}
+
+	if _, ok := c.Branches("owner/other"); ok {
+		t.Errorf("Cache should be keyed by repo")
+	}
+
+	if workflows, ok := c.Workflows("owner/repo"); !ok || workflows[0].Name != "Deploy" {
+		t.Errorf("Workflows not cached: %v", workflows)
+	}
+
+	if repos, ok := c.Repos("owner"); !ok || repos[0].NameWithOwner != "owner/repo" {
+		t.Errorf("Repos not cached: %v", repos)
+	}
+
+	t.Log("✓ Session cache stores lookups per key")
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/20uf/devcli/internal/completion"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	deployCmd.RegisterFlagCompletionFunc("repo", completeRepos)         //nolint:errcheck
+	deployCmd.RegisterFlagCompletionFunc("workflow", completeWorkflows) //nolint:errcheck
+}
+
+// completeRepos completes --repo with the repositories of the user and of
+// their organizations.
+func completeRepos(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	repos := cachedCompletion("repos", func() ([]string, error) {
+		var all []string
+		for _, owner := range listOwners() {
+			repos, err := listRepositoriesByOrg(owner, defaultListLimit)
+			if err != nil {
+				continue
+			}
+			all = append(all, repos...)
+		}
+		return all, nil
+	})
+	return filterPrefix(repos, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeWorkflows completes --workflow with the active workflow files of
+// the --repo given before it.
+func completeWorkflows(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if flagRepo == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	files := cachedCompletion("workflows:"+flagRepo, func() ([]string, error) {
+		workflows, err := listWorkflows(flagRepo)
+		if err != nil {
+			return nil, err
+		}
+		var files []string
+		for _, w := range workflows {
+			if w.State == "active" {
+				files = append(files, extractWorkflowFile(w.Path))
+			}
+		}
+		return files, nil
+	})
+	return filterPrefix(files, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// cachedCompletion returns the values cached under key, fetching and caching
+// them when missing or expired. Failures complete nothing.
+func cachedCompletion(key string, fetch func() ([]string, error)) []string {
+	store, _ := completion.Load()
+	now := time.Now()
+	if store != nil {
+		if values, ok := store.Get(key, now); ok {
+			return values
+		}
+	}
+
+	values, err := fetch()
+	if err != nil {
+		return nil
+	}
+	if store != nil {
+		store.Set(key, values, now)
+		store.Save() //nolint:errcheck
+	}
+	return values
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/20uf/devcli/internal/completion"
+)
+
+// Test: --workflow completes from the cache of the --repo being completed
+func TestCompleteWorkflows_Cached(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	orig := flagRepo
+	defer func() { flagRepo = orig }()
+
+	store, err := completion.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Set("workflows:owner/api", []string{"deploy.yml", "release.yml", "docs.yml"}, time.Now())
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	flagRepo = "owner/api"
+	got, _ := completeWorkflows(deployCmd, nil, "de")
+	if !reflect.DeepEqual(got, []string{"deploy.yml"}) {
+		t.Errorf("completeWorkflows(de) = %v, want [deploy.yml]", got)
+	}
+
+	flagRepo = ""
+	if got, _ := completeWorkflows(deployCmd, nil, ""); got != nil {
+		t.Errorf("completeWorkflows() without --repo = %v, want nil", got)
+	}
+
+	t.Log("✓ Workflows completed from cache")
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/20uf/devcli/internal/deployment/domain"
+	"github.com/20uf/devcli/internal/project"
+	"github.com/20uf/devcli/internal/ui"
+	"github.com/20uf/devcli/internal/verbose"
+)
+
+// schemaFile is the optional repository file overriding input conditions
+// and types, under workflows.<file>.inputs (see project.File).
+const schemaFile = project.FileName
+
+// fetchSchema reads the repository's .devcli.yaml. A missing file yields
+// no content.
+func fetchSchema(repo string) []byte {
+	out, err := verbose.Cmd(exec.Command("gh", "api",
+		fmt.Sprintf("repos/%s/contents/%s", repo, schemaFile),
+		"--jq", ".content")).Output()
+	if err != nil {
+		verbose.Log("no %s in %s: %s", schemaFile, repo, err)
+		return nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(strings.TrimSpace(string(out)), "\n", ""))
+	if err != nil {
+		verbose.Log("failed to decode %s: %s", schemaFile, err)
+		return nil
+	}
+	return decoded
+}
+
+// parseSchema decodes a .devcli.yaml file, warning when it is invalid.
+func parseSchema(content []byte) *project.File {
+	schema, err := project.Parse(content)
+	if err != nil {
+		ui.PrintWarning(fmt.Sprintf("Ignoring %s", err))
+		return &project.File{}
+	}
+	return schema
+}
+
+// parseSchemaConditions returns the input conditions declared for a workflow
+// in a .devcli.yaml file.
+func parseSchemaConditions(content []byte, workflowFileName string) map[string]string {
+	conditions := make(map[string]string)
+	for name, input := range parseSchema(content).Workflows[workflowFileName].Inputs {
+		if input.If != "" {
+			conditions[name] = input.If
+		}
+	}
+	return conditions
+}
+
+// parseSchemaTypes returns the input types declared for a workflow in a
+// .devcli.yaml file, e.g. to handle a string input as a number.
+func parseSchemaTypes(content []byte, workflowFileName string) map[string]string {
+	types := make(map[string]string)
+	for name, input := range parseSchema(content).Workflows[workflowFileName].Inputs {
+		if input.Type != "" {
+			types[name] = input.Type
+		}
+	}
+	return types
+}
+
+// applyInputTypes overrides the type of inputs declared in the workflow.
+func applyInputTypes(inputs map[string]workflowInput, types map[string]string) {
+	for name, inputType := range types {
+		if input, ok := inputs[name]; ok {
+			input.Type = inputType
+			inputs[name] = input
+		}
+	}
+}
+
+// applyInputConditions parses conditions and attaches them to inputs.
+// Invalid conditions are reported and ignored, keeping the input.
+func applyInputConditions(inputs map[string]workflowInput, conditions map[string]string) {
+	for name, expr := range conditions {
+		input, ok := inputs[name]
+		if !ok {
+			continue
+		}
+		cond, err := domain.ParseCondition(expr)
+		if err != nil {
+			ui.PrintWarning(fmt.Sprintf("Ignoring condition of input %s: %s", name, err))
+			continue
+		}
+		input.Condition = cond
+		inputs[name] = input
+	}
+}
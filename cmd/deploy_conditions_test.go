@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/20uf/devcli/internal/deployment/domain"
+	"github.com/20uf/devcli/internal/deployment/infra"
+)
+
+// Test: .devcli.yaml declares conditions per workflow file
+func TestParseSchemaConditions(t *testing.T) {
+	schema := `
+workflows:
+  deploy.yml:
+    inputs:
+      notify_channel:
+        if: send_notification == true
+  other.yml:
+    inputs:
+      tag:
+        if: environment == prod
+`
+	got := parseSchemaConditions([]byte(schema), "deploy.yml")
+	if len(got) != 1 || got["notify_channel"] != "send_notification == true" {
+		t.Errorf("parseSchemaConditions() = %v", got)
+	}
+
+	t.Log("✓ Conditions read from .devcli.yaml")
+}
+
+// Test: .devcli.yaml can declare an input as a number
+func TestParseSchemaTypes(t *testing.T) {
+	schema := `
+workflows:
+  deploy.yml:
+    inputs:
+      replicas:
+        type: number
+      notify_channel:
+        if: send_notification == true
+`
+	inputs := map[string]workflowInput{"replicas": {Type: "string"}, "notify_channel": {}}
+	applyInputTypes(inputs, parseSchemaTypes([]byte(schema), "deploy.yml"))
+
+	if got := inputs["replicas"].InputType(); got != domain.InputTypeNumber {
+		t.Errorf("replicas type = %s, want number", got)
+	}
+	if got := inputs["notify_channel"].InputType(); got != domain.InputTypeString {
+		t.Errorf("notify_channel type = %s, want string", got)
+	}
+
+	validate := numberValidator("replicas")
+	if err := validate("3"); err != nil {
+		t.Errorf("validate(3) error = %v", err)
+	}
+	if err := validate("three"); err == nil {
+		t.Error("validate(three) should fail")
+	}
+
+	t.Log("✓ Input types read from .devcli.yaml")
+}
+
+// Test: Inputs are prompted after the inputs their condition depends on
+func TestOrderInputNames(t *testing.T) {
+	inputs := map[string]workflowInput{
+		"environment":       {},
+		"notify_channel":    {},
+		"send_notification": {},
+		"a_tag":             {},
+	}
+	applyInputConditions(inputs, map[string]string{
+		"a_tag":          "environment == prod",
+		"notify_channel": "send_notification",
+	})
+
+	got := strings.Join(infra.OrderedInputKeys(inputs), ",")
+	want := "environment,a_tag,send_notification,notify_channel"
+	if got != want {
+		t.Errorf("infra.OrderedInputKeys() = %s, want %s", got, want)
+	}
+
+	if !inputs["environment"].Condition.IsZero() {
+		t.Errorf("Unconditioned input should keep a zero condition")
+	}
+
+	t.Log("✓ Dependent inputs ordered after their dependencies")
+}
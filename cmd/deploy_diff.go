@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/20uf/devcli/internal/history"
+	"github.com/20uf/devcli/internal/ui"
+	"github.com/20uf/devcli/internal/verbose"
+)
+
+// diffCommitLimit caps the commits listed by --show-diff.
+const diffCommitLimit = 20
+
+// workflowRunRef is a run of the workflow runs API, as used by --show-diff.
+type workflowRunRef struct {
+	RunNumber  int    `json:"run_number"`
+	HeadSHA    string `json:"head_sha"`
+	HeadBranch string `json:"head_branch"`
+}
+
+// deployTimeFormat renders the time of a deploy recorded in the history.
+const deployTimeFormat = "2006-01-02 15:04"
+
+// showDeployDiff prints what changed between the last two deploys of a
+// workflow: the image inputs of the last two deploys recorded in the history
+// when the workflow has some, the commits between its last two runs
+// otherwise. It only informs, so failures are warnings.
+func showDeployDiff(hist *history.Store, repo, workflow, workflowName string) {
+	if inputs, err := fetchWorkflowInputs(repo, workflow); err == nil {
+		names := imageInputNames(inputs)
+		deploys := recentDeploys(hist, repo, workflowName, 2)
+		if len(names) > 0 && len(deploys) == 2 {
+			latest, previous := deploys[0], deploys[1]
+			ui.PrintStep("◆", fmt.Sprintf("Image changes between your last two deploys (%s → %s)",
+				previous.Timestamp.Local().Format(deployTimeFormat), latest.Timestamp.Local().Format(deployTimeFormat)))
+			for _, line := range imageDiffLines(names, historyInputs(previous.Args), historyInputs(latest.Args)) {
+				fmt.Println("  " + line)
+			}
+			return
+		}
+	}
+
+	runs, err := lastWorkflowRuns(repo, workflow)
+	if err != nil {
+		ui.PrintWarning(fmt.Sprintf("Could not load the last runs: %s", err))
+		return
+	}
+	if len(runs) < 2 {
+		ui.PrintStep("·", "Nothing to compare: the workflow has run less than twice")
+		return
+	}
+	latest, previous := runs[0], runs[1]
+
+	commits, err := compareCommits(repo, previous.HeadSHA, latest.HeadSHA)
+	if err != nil {
+		ui.PrintWarning(fmt.Sprintf("Could not compare the last runs: %s", err))
+		return
+	}
+	ui.PrintStep("◆", fmt.Sprintf("Changes between run #%d (%s) and #%d (%s)",
+		previous.RunNumber, previous.HeadBranch, latest.RunNumber, latest.HeadBranch))
+	if len(commits) == 0 {
+		fmt.Println(ui.MutedStyle.Render("  Same commit"))
+		return
+	}
+	for _, line := range commits {
+		fmt.Println("  " + line)
+	}
+}
+
+// lastWorkflowRuns returns the two most recent runs of a workflow.
+func lastWorkflowRuns(repo, workflow string) ([]workflowRunRef, error) {
+	out, err := verbose.Cmd(exec.Command("gh", "api",
+		fmt.Sprintf("repos/%s/actions/workflows/%s/runs?per_page=2", repo, workflow))).Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseWorkflowRuns(out)
+}
+
+func parseWorkflowRuns(data []byte) ([]workflowRunRef, error) {
+	var page struct {
+		WorkflowRuns []workflowRunRef `json:"workflow_runs"`
+	}
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow runs: %w", err)
+	}
+	return page.WorkflowRuns, nil
+}
+
+// imageInputNames returns the inputs holding a container image or its tag,
+// such as image, imageTag or docker_tag.
+func imageInputNames(inputs map[string]workflowInput) []string {
+	var names []string
+	for name := range inputs {
+		lower := strings.ToLower(name)
+		if strings.Contains(lower, "image") || strings.HasSuffix(lower, "tag") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// recentDeploys returns the last n deploys of a workflow recorded in the
+// history, most recent first. The runs API does not expose the inputs of
+// a run.
+func recentDeploys(hist *history.Store, repo, workflowName string, n int) []history.Entry {
+	if hist == nil {
+		return nil
+	}
+
+	prefix := fmt.Sprintf("%s/%s @ ", repo, workflowName)
+	var deploys []history.Entry
+	for i := len(hist.Entries) - 1; i >= 0 && len(deploys) < n; i-- {
+		e := hist.Entries[i]
+		if e.Command == "deploy" && strings.HasPrefix(e.Label, prefix) {
+			deploys = append(deploys, e)
+		}
+	}
+	return deploys
+}
+
+// imageDiffLines renders "name: old → new" for each image input, with the
+// new value highlighted when it changed.
+func imageDiffLines(names []string, previous, latest map[string]string) []string {
+	var lines []string
+	for _, name := range names {
+		before, after := previous[name], latest[name]
+		if before == "" && after == "" {
+			continue
+		}
+		if before == after {
+			lines = append(lines, fmt.Sprintf("%s: %s", name, ui.MutedStyle.Render(after+" (unchanged)")))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s → %s", name, ui.ErrorStyle.Render(orNone(before)), ui.SuccessStyle.Render(orNone(after))))
+	}
+	return lines
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
+
+// compareCommits lists the commits between two refs as "sha message" lines.
+func compareCommits(repo, base, head string) ([]string, error) {
+	if base == head {
+		return nil, nil
+	}
+	out, err := verbose.Cmd(exec.Command("gh", "api",
+		fmt.Sprintf("repos/%s/compare/%s...%s", repo, base, head))).Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseCompareCommits(out)
+}
+
+func parseCompareCommits(data []byte) ([]string, error) {
+	var comparison struct {
+		Commits []struct {
+			SHA    string `json:"sha"`
+			Commit struct {
+				Message string `json:"message"`
+			} `json:"commit"`
+		} `json:"commits"`
+	}
+	if err := json.Unmarshal(data, &comparison); err != nil {
+		return nil, fmt.Errorf("failed to parse comparison: %w", err)
+	}
+
+	commits := comparison.Commits
+	skipped := 0
+	if len(commits) > diffCommitLimit {
+		skipped = len(commits) - diffCommitLimit
+		commits = commits[skipped:]
+	}
+
+	var lines []string
+	if skipped > 0 {
+		lines = append(lines, ui.MutedStyle.Render(fmt.Sprintf("… %d older commit(s)", skipped)))
+	}
+	for _, c := range commits {
+		sha := c.SHA
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		subject, _, _ := strings.Cut(c.Commit.Message, "\n")
+		lines = append(lines, fmt.Sprintf("%s %s", ui.WarningStyle.Render(sha), subject))
+	}
+	return lines, nil
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/20uf/devcli/internal/history"
+)
+
+// Test: Image inputs are recognized by name
+func TestImageInputNames(t *testing.T) {
+	inputs := map[string]workflowInput{
+		"imageTag":    {},
+		"docker_tag":  {},
+		"image":       {},
+		"environment": {},
+		"tagline":     {},
+	}
+
+	got := imageInputNames(inputs)
+	want := []string{"docker_tag", "image", "imageTag"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("imageInputNames() = %v, want %v", got, want)
+	}
+
+	t.Log("✓ Image inputs detected")
+}
+
+// Test: The last deploys of a workflow are read from the history
+func TestRecentDeploys(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	hist, err := history.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hist.Add("deploy", "octo/app/Deploy @ main", []string{"--input", "imageTag=sha256:abc"})
+	hist.Add("deploy", "octo/app/Release @ main", []string{"--input", "imageTag=v9"})
+	hist.Add("deploy", "octo/app/Deploy @ hotfix", []string{"--input", "imageTag=sha256:def"})
+
+	got := recentDeploys(hist, "octo/app", "Deploy", 2)
+	if len(got) != 2 || historyInputs(got[0].Args)["imageTag"] != "sha256:def" || historyInputs(got[1].Args)["imageTag"] != "sha256:abc" {
+		t.Errorf("recentDeploys() = %v, want the hotfix then the main deploy", got)
+	}
+
+	t.Log("✓ Recent deploy inputs found")
+}
+
+// Test: Image changes render as old → new
+func TestImageDiffLines(t *testing.T) {
+	lines := imageDiffLines([]string{"imageTag", "sidecar_image", "unused_tag"},
+		map[string]string{"imageTag": "sha256:abc123", "sidecar_image": "envoy:1"},
+		map[string]string{"imageTag": "sha256:def456", "sidecar_image": "envoy:1"})
+
+	if len(lines) != 2 {
+		t.Fatalf("imageDiffLines() = %v, want 2 lines", lines)
+	}
+	if !strings.Contains(lines[0], "sha256:abc123") || !strings.Contains(lines[0], "→") || !strings.Contains(lines[0], "sha256:def456") {
+		t.Errorf("Changed image line = %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "unchanged") {
+		t.Errorf("Unchanged image line = %q", lines[1])
+	}
+
+	t.Log("✓ Image diff rendered")
+}
+
+// Test: Runs and compared commits are parsed from the API
+func TestParseRunsAndCommits(t *testing.T) {
+	runs, err := parseWorkflowRuns([]byte(`{"workflow_runs": [
+		{"run_number": 12, "head_sha": "def456", "head_branch": "main"},
+		{"run_number": 11, "head_sha": "abc123", "head_branch": "main"}]}`))
+	if err != nil || len(runs) != 2 || runs[0].RunNumber != 12 || runs[1].HeadSHA != "abc123" {
+		t.Errorf("parseWorkflowRuns() = %v, %v", runs, err)
+	}
+
+	lines, err := parseCompareCommits([]byte(`{"commits": [
+		{"sha": "0123456789abcdef", "commit": {"message": "Fix login\n\nDetails"}}]}`))
+	if err != nil || len(lines) != 1 || !strings.Contains(lines[0], "0123456") || !strings.HasSuffix(lines[0], "Fix login") {
+		t.Errorf("parseCompareCommits() = %v, %v", lines, err)
+	}
+
+	t.Log("✓ API responses parsed")
+}
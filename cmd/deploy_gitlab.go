@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+
+	"github.com/20uf/devcli/internal/deployment/application"
+	"github.com/20uf/devcli/internal/deployment/domain"
+	"github.com/20uf/devcli/internal/deployment/infra"
+	"github.com/20uf/devcli/internal/history"
+	"github.com/20uf/devcli/internal/ui"
+	"github.com/20uf/devcli/internal/verbose"
+)
+
+// runGitLabDeploy runs the pipeline of a GitLab project through the glab CLI.
+// The project is given with --repo (group/project); its pipeline variables
+// with a description are the deploy inputs.
+func runGitLabDeploy(ctx context.Context) error {
+	if _, err := exec.LookPath("glab"); err != nil {
+		return fmt.Errorf("GitLab CLI (glab) is required with --provider gitlab.\n  Install: https://gitlab.com/gitlab-org/cli")
+	}
+
+	project := flagRepo
+	if project == "" {
+		p, err := ui.Input("GitLab project (group/project)", "")
+		if err != nil {
+			return err
+		}
+		if p == "" {
+			return fmt.Errorf("no project specified")
+		}
+		project = p
+	}
+
+	fileInputs, err := loadInputsFile()
+	if err != nil {
+		return err
+	}
+
+	repos := infra.CreateGitLabRepositories(project)
+	h := &DeployHandler{
+		orchestrator:   application.NewTriggerDeploymentOrchestrator(repos),
+		repos:          repos,
+		repoURL:        project,
+		cancelPrevious: flagCancelPrevious,
+		fileInputs:     fileInputs,
+		anyInputs:      true,
+	}
+
+	workflowName := flagWorkflow
+	if workflowName == "" {
+		workflowName = infra.GitLabPipelineFile
+	}
+	workflow, err := domain.NewWorkflow(workflowName)
+	if err != nil {
+		return err
+	}
+
+	interactive := flagBranch == ""
+	branch := flagBranch
+	if interactive {
+		branch, err = selectGitLabBranch(ctx, repos)
+		if err != nil {
+			return err
+		}
+	}
+
+	inputs, err := repos.Workflows.GetWorkflowInputs(ctx, workflow)
+	if err != nil {
+		return err
+	}
+	given, err := h.inputValues(inputs, flagInputs)
+	if err != nil {
+		return err
+	}
+	values := mergeInputDefaults(given, inputDefaults(inputs))
+	if interactive && len(inputs) > 0 {
+		collected, err := h.collectInputs(ctx, inputs, flagInputs)
+		if err != nil {
+			return err
+		}
+		// Variables the pipeline does not prefill are passed as given
+		values = mergeInputDefaults(h.inputsToMap(collected), given)
+	}
+
+	if h.cancelPrevious {
+		if err := cancelInFlightRuns(ctx, h.orchestrator, workflowName, branch); err != nil {
+			return err
+		}
+	}
+
+	// A branch picked from the list exists even when it is named like a tag
+	allowTag := flagAllowTagLikeBranch || interactive
+	if !allowTag && domain.ValidateBranch(branch) != nil {
+		branches, err := repos.Branches.ListBranches(ctx)
+		allowTag = err == nil && containsString(branches, branch)
+	}
+
+	ui.PrintStep("▶", fmt.Sprintf("Running %s on %s (branch: %s)", workflowName, project, branch))
+	deployment, err := h.orchestrator.Trigger(ctx, application.TriggerRequest{
+		WorkflowName:       &workflowName,
+		BranchName:         &branch,
+		Inputs:             values,
+		AllowTagLikeBranch: allowTag,
+	})
+	if errors.Is(err, domain.ErrBranchLooksLikeTag) {
+		return fmt.Errorf("%w\n  Pass --allow-tag-like-branch if it is a branch", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	recordGitLabDeploy(project, workflowName, branch, values)
+
+	run := deployment.Run()
+	if run == nil {
+		ui.PrintSuccess("Pipeline triggered")
+		return nil
+	}
+	ui.PrintSuccess(fmt.Sprintf("Pipeline #%s triggered", run.ID()))
+	if run.URL() != "" {
+		fmt.Println(ui.MutedStyle.Render("  " + run.URL()))
+	}
+
+	if flagWatch || flagWait {
+		return waitGitLabPipeline(ctx, h, deployment)
+	}
+	return nil
+}
+
+// checkGitLabFlags rejects the deploy flags that only apply to GitHub
+// Actions: a safety gate such as --require-approval must not be skipped
+// silently. --last is allowed when replaying a pipeline from the history.
+func checkGitLabFlags(replay bool) error {
+	unsupported := []struct {
+		name string
+		set  bool
+	}{
+		{"--require-approval", flagRequireApproval},
+		{"--last", flagLast && !replay},
+		{"--rerun-last", flagRerunLast},
+		{"--edit-inputs", flagEditInputs},
+		{"--reuse-inputs", flagReuseInputs},
+		{"--inputs", flagInputsEditor},
+		{"--auto-select", flagAutoSelect},
+		{"--show-diff", flagShowDiff},
+		{"--label", flagDeployLabel != ""},
+		{"--copy-url", flagCopyURL},
+	}
+	for _, f := range unsupported {
+		if f.set {
+			return fmt.Errorf("%s is not supported with --provider gitlab", f.name)
+		}
+	}
+	return nil
+}
+
+// recordGitLabDeploy adds the pipeline to the deploy history, replayed
+// from the history menu with --provider gitlab.
+func recordGitLabDeploy(project, workflowName, branch string, values map[string]string) {
+	hist, err := history.Load()
+	if err != nil {
+		return
+	}
+	args := []string{"--provider", infra.ProviderGitLab, "--repo", project, "--workflow", workflowName, "--branch", branch}
+	for _, input := range overrideInputs(values, nil) {
+		args = append(args, "--input", input)
+	}
+	hist.Add("deploy", fmt.Sprintf("%s/%s @ %s", project, workflowName, branch), verbose.RedactArgs(args))
+	hist.Save() //nolint:errcheck
+}
+
+// replayGitLabDeploy runs again the pipeline of a history entry.
+func replayGitLabDeploy(project, workflowName, branch string, inputs []string) error {
+	if err := checkGitLabFlags(true); err != nil {
+		return err
+	}
+	inputs, err := promptRedactedInputs(inputs)
+	if err != nil {
+		return err
+	}
+
+	ui.PrintStep("↻", fmt.Sprintf("Replaying: %s/%s @ %s", project, workflowName, branch))
+	flagRepo, flagWorkflow, flagBranch, flagInputs = project, workflowName, branch, inputs
+	return runGitLabDeploy(rootCmd.Context())
+}
+
+// waitGitLabPipeline follows the pipeline until it completes, failing with
+// the exit code of its conclusion like --wait does for GitHub runs. With
+// --watch-timeout, following stops once the duration elapses.
+func waitGitLabPipeline(ctx context.Context, h *DeployHandler, deployment domain.Deployment) error {
+	if flagWatchTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, flagWatchTimeout)
+		defer cancel()
+	}
+
+	err := h.watchDeployment(ctx, deployment)
+	if flagWatchTimeout > 0 && errors.Is(err, context.DeadlineExceeded) {
+		return &exitError{
+			err:  fmt.Errorf("pipeline #%s still running after %s", deployment.Run().ID(), flagWatchTimeout),
+			code: watchTimeoutExitCode,
+		}
+	}
+	return err
+}
+
+// selectGitLabBranch lets the user pick the branch to run the pipeline on,
+// the project's default branch preselected.
+func selectGitLabBranch(ctx context.Context, repos *domain.AllRepositories) (string, error) {
+	branches, err := repos.Branches.ListBranches(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	def, _ := repos.Branches.GetDefaultBranch(ctx)
+	return ui.SelectWithDefault("Select branch", branches, def)
+}
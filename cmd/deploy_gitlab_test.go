@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/20uf/devcli/internal/history"
+)
+
+// Test: GitHub-only deploy flags are rejected with --provider gitlab
+func TestCheckGitLabFlags(t *testing.T) {
+	defer func() { flagRequireApproval, flagLast = false, false }()
+
+	if err := checkGitLabFlags(false); err != nil {
+		t.Errorf("checkGitLabFlags() without flags = %v", err)
+	}
+
+	flagRequireApproval = true
+	if err := checkGitLabFlags(false); err == nil || !strings.Contains(err.Error(), "--require-approval") {
+		t.Errorf("checkGitLabFlags() = %v, want a --require-approval error", err)
+	}
+
+	flagRequireApproval, flagLast = false, true
+	if err := checkGitLabFlags(false); err == nil {
+		t.Error("checkGitLabFlags() accepted --last")
+	}
+	if err := checkGitLabFlags(true); err != nil {
+		t.Errorf("checkGitLabFlags() when replaying = %v", err)
+	}
+
+	t.Log("✓ Unsupported GitLab flags rejected")
+}
+
+// Test: GitLab pipelines in the history are not offered to GitHub lookups
+func TestRecentDeployArg_SkipsGitLab(t *testing.T) {
+	hist := &history.Store{Entries: []history.Entry{
+		{Command: "deploy", Args: []string{"--repo", "owner/api", "--workflow", "deploy.yml", "--branch", "main"}},
+		{Command: "deploy", Args: []string{"--provider", "gitlab", "--repo", "group/api", "--workflow", ".gitlab-ci.yml", "--branch", "main"}},
+	}}
+
+	if got := recentDeployArg(hist, "--repo"); got != "owner/api" {
+		t.Errorf("recentDeployArg() = %q, want owner/api", got)
+	}
+
+	t.Log("✓ GitLab entries skipped")
+}
@@ -2,23 +2,48 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os/exec"
 	"strings"
 
+	"github.com/20uf/devcli/internal/config"
 	"github.com/20uf/devcli/internal/deployment/application"
 	"github.com/20uf/devcli/internal/deployment/domain"
 	"github.com/20uf/devcli/internal/deployment/infra"
 	"github.com/20uf/devcli/internal/history"
+	"github.com/20uf/devcli/internal/retry"
 	"github.com/20uf/devcli/internal/ui"
+	"github.com/20uf/devcli/internal/verbose"
 	"github.com/spf13/cobra"
 )
 
+// defaultListLimit caps repository and branch listings when neither a flag nor
+// a config key overrides it.
+const defaultListLimit = 50
+
+// maxRepoLimit caps --repo-limit and deploy.repo_limit.
+const maxRepoLimit = 200
+
+const (
+	optionLoadMore    = "↓ Load more"
+	optionEnterManual = "✎ Enter manually"
+)
+
 // DeployHandler bridges the CLI layer and domain layer for deployments.
 type DeployHandler struct {
-	orchestrator *application.TriggerDeploymentOrchestrator
-	repos        *domain.AllRepositories
-	history      *history.Store
+	orchestrator   *application.TriggerDeploymentOrchestrator
+	repos          *domain.AllRepositories
+	history        *history.Store
+	repoURL        string
+	repoLimit      int
+	branchLimit    int
+	cancelPrevious bool
+	fileInputs     map[string]string // --inputs-file values, nil when unset
+
+	// anyInputs accepts inputs the workflow does not declare: GitLab
+	// pipelines take any variable, prefilled or not.
+	anyInputs bool
 }
 
 // NewDeployHandler creates a handler with all dependencies wired.
@@ -27,10 +52,25 @@ func NewDeployHandler(ctx context.Context, repoURL string) (*DeployHandler, erro
 
 	hist, _ := history.Load()
 
+	var deployCfg config.Deploy
+	if cfg, err := config.Load(); err == nil {
+		deployCfg = cfg.Deploy
+	}
+
+	fileInputs, err := loadInputsFile()
+	if err != nil {
+		return nil, err
+	}
+
 	return &DeployHandler{
-		orchestrator: application.NewTriggerDeploymentOrchestrator(repos),
-		repos:        repos,
-		history:      hist,
+		orchestrator:   application.NewTriggerDeploymentOrchestrator(repos),
+		repos:          repos,
+		history:        hist,
+		repoURL:        repoURL,
+		repoLimit:      min(resolveLimit(flagRepoLimit, deployCfg.RepoLimit, defaultListLimit), maxRepoLimit),
+		branchLimit:    resolveLimit(flagBranchLimit, deployCfg.BranchLimit, defaultListLimit),
+		cancelPrevious: flagCancelPrevious,
+		fileInputs:     fileInputs,
 	}, nil
 }
 
@@ -46,7 +86,7 @@ func (h *DeployHandler) Handle(
 	ctx := cmd.Context()
 
 	// Verify gh CLI is installed
-	if _, err := exec.LookPath("gh"); err != nil {
+	if _, err := verbose.LookPath("gh"); err != nil {
 		return fmt.Errorf("GitHub CLI (gh) is required.\n  Install: https://cli.github.com/")
 	}
 
@@ -57,11 +97,25 @@ func (h *DeployHandler) Handle(
 			return err
 		}
 		inputs := parseInputFlags(inputFlags)
+		if workflow, err := domain.NewWorkflow(workflowFlag); err == nil {
+			if typed, err := realHandler.repos.Workflows.GetWorkflowInputs(ctx, workflow); err == nil {
+				if inputs, err = realHandler.inputValues(typed, inputFlags); err != nil {
+					return err
+				}
+				inputs = mergeInputDefaults(inputs, inputDefaults(typed))
+			}
+		}
+		if realHandler.cancelPrevious {
+			if err := cancelInFlightRuns(ctx, realHandler.orchestrator, workflowFlag, branchFlag); err != nil {
+				return err
+			}
+		}
 		deployment, err := realHandler.orchestrator.Trigger(ctx, application.TriggerRequest{
-			WorkflowName: &workflowFlag,
-			BranchName:   &branchFlag,
-			Inputs:       inputs,
-			RepoURL:      "",
+			WorkflowName:       &workflowFlag,
+			BranchName:         &branchFlag,
+			Inputs:             inputs,
+			RepoURL:            "",
+			AllowTagLikeBranch: flagAllowTagLikeBranch,
 		})
 		if err != nil {
 			return err
@@ -114,20 +168,17 @@ func (h *DeployHandler) interactiveFlow(
 	}
 
 	// Step 2: Select repository (from selected organization)
-	repositories, err := listRepositoriesByOrg(selectedOrg)
-	if err != nil {
-		return fmt.Errorf("failed to list repositories for %s: %w", selectedOrg, err)
-	}
-
-	if len(repositories) == 0 {
-		return fmt.Errorf("no repositories found in %s", selectedOrg)
-	}
-
-	selectedRepo, err := ui.Select("Select repository", repositories)
-	if err != nil {
+	selectedRepo, err := selectFromLimitedList("Select repository", "Repository (owner/repo)", h.repoLimit,
+		func(limit int) ([]string, error) {
+			return listRepositoriesByOrg(selectedOrg, limit)
+		})
+	if errors.Is(err, ui.ErrUserAbort) {
 		ui.PrintWarning("Cancelled - returning to menu")
 		return nil
 	}
+	if err != nil {
+		return fmt.Errorf("failed to list repositories for %s: %w", selectedOrg, err)
+	}
 
 	// Step 2: Create handler with selected repository
 	realHandler, err := NewDeployHandler(ctx, selectedRepo)
@@ -141,13 +192,20 @@ func (h *DeployHandler) interactiveFlow(
 		return fmt.Errorf("failed to list workflows: %w", err)
 	}
 
-	workflowNames := make([]string, len(workflows))
-	for i, w := range workflows {
-		workflowNames[i] = w.Name()
-	}
-
+	var workflowNames []string
 	if workflowFlag != "" {
 		workflowNames = []string{workflowFlag}
+	} else {
+		runnable, skipped := filterDispatchable(ctx, realHandler.repos.Workflows, workflows)
+		if len(skipped) > 0 {
+			fmt.Println(ui.MutedStyle.Render(fmt.Sprintf("  %d workflow(s) hidden (no workflow_dispatch trigger)", len(skipped))))
+		}
+		if len(runnable) == 0 {
+			return fmt.Errorf("no workflow in %s can be triggered manually (add a workflow_dispatch trigger)", selectedRepo)
+		}
+		for _, w := range runnable {
+			workflowNames = append(workflowNames, w.Name())
+		}
 	}
 
 	selectedWorkflowName, err := ui.Select("Select workflow", workflowNames)
@@ -164,18 +222,35 @@ func (h *DeployHandler) interactiveFlow(
 	}
 
 	// Step 5: Select branch
-	branches, err := listBranches(selectedOrg, selectedRepo)
-	if err != nil {
-		return fmt.Errorf("failed to list branches for %s/%s: %w", selectedOrg, selectedRepo, err)
-	}
+	selectedBranch := branchFlag
+	if selectedBranch == "" {
+		current, currentErr := currentGitBranch("")
+		suggested := suggestBranch(current, currentErr, nil, func() string {
+			if b, err := realHandler.repos.Branches.GetDefaultBranch(ctx); err == nil {
+				return b
+			}
+			return fallbackBranch
+		})
 
-	if branchFlag != "" {
-		branches = []string{branchFlag}
+		useSuggested, err := ui.Confirm(fmt.Sprintf("Deploy branch %s?", suggested))
+		if errors.Is(err, ui.ErrUserAbort) {
+			return nil
+		}
+		if useSuggested {
+			selectedBranch = suggested
+		}
 	}
-
-	selectedBranch, err := ui.Select("Select branch", branches)
-	if err != nil {
-		return nil
+	if selectedBranch == "" {
+		selectedBranch, err = selectFromLimitedList("Select branch", "Branch name", h.branchLimit,
+			func(limit int) ([]string, error) {
+				return listBranches(selectedOrg, selectedRepo, limit)
+			})
+		if errors.Is(err, ui.ErrUserAbort) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list branches for %s/%s: %w", selectedOrg, selectedRepo, err)
+		}
 	}
 
 	// Step 6: Collect input values (with type validation)
@@ -188,11 +263,17 @@ func (h *DeployHandler) interactiveFlow(
 
 	// Step 7: Prepare and execute deployment
 	inputMap := realHandler.inputsToMap(inputs)
+	if realHandler.cancelPrevious {
+		if err := cancelInFlightRuns(ctx, realHandler.orchestrator, selectedWorkflowName, selectedBranch); err != nil {
+			return err
+		}
+	}
 	deployment, err := realHandler.orchestrator.Trigger(ctx, application.TriggerRequest{
-		WorkflowName: &selectedWorkflowName,
-		BranchName:   &selectedBranch,
-		Inputs:       inputMap,
-		RepoURL:      "",
+		WorkflowName:       &selectedWorkflowName,
+		BranchName:         &selectedBranch,
+		Inputs:             inputMap,
+		RepoURL:            "",
+		AllowTagLikeBranch: flagAllowTagLikeBranch,
 	})
 	if err != nil {
 		return err
@@ -201,9 +282,24 @@ func (h *DeployHandler) interactiveFlow(
 	return realHandler.executeDeployment(ctx, deployment, watchFlag)
 }
 
+// filterDispatchable splits workflows into those that can be triggered manually
+// and those lacking a workflow_dispatch trigger. Workflows that cannot be probed
+// are kept, so a transient API error never hides a valid choice.
+func filterDispatchable(ctx context.Context, repo domain.WorkflowRepository, workflows []domain.Workflow) (runnable, skipped []domain.Workflow) {
+	for _, w := range workflows {
+		ok, err := repo.IsDispatchable(ctx, w)
+		if err == nil && !ok {
+			skipped = append(skipped, w)
+			continue
+		}
+		runnable = append(runnable, w)
+	}
+	return runnable, skipped
+}
+
 // listOrganizations retrieves user's organizations using gh CLI.
 func listOrganizations() ([]string, error) {
-	cmd := exec.Command("gh", "api", "user/orgs", "--jq", ".[].login")
+	cmd := verbose.Cmd(exec.Command("gh", "api", "user/orgs", "--jq", ".[].login"))
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, err
@@ -219,9 +315,9 @@ func listOrganizations() ([]string, error) {
 	return orgs, nil
 }
 
-// listRepositoriesByOrg retrieves repositories for a specific organization.
-func listRepositoriesByOrg(org string) ([]string, error) {
-	cmd := exec.Command("gh", "repo", "list", org, "--limit", "50", "--json", "nameWithOwner", "-q", ".[].nameWithOwner")
+// listRepositoriesByOrg retrieves up to limit repositories for a specific organization.
+func listRepositoriesByOrg(org string, limit int) ([]string, error) {
+	cmd := verbose.Cmd(exec.Command("gh", "repo", "list", org, "--limit", fmt.Sprint(limit), "--json", "nameWithOwner", "-q", ".[].nameWithOwner"))
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, err
@@ -237,20 +333,22 @@ func listRepositoriesByOrg(org string) ([]string, error) {
 	return repos, nil
 }
 
-// listBranches retrieves the 50 most recently active branches.
-func listBranches(org, repo string) ([]string, error) {
+// listBranches retrieves up to limit branches.
+// The GitHub API paginates branches (100 per page max), so all pages are
+// fetched with --paginate and the result is truncated locally.
+func listBranches(org, repo string, limit int) ([]string, error) {
 	// repo might be "org/name" format from listRepositoriesByOrg
 	fullRepo := repo
 	if !strings.Contains(repo, "/") {
 		fullRepo = org + "/" + repo
 	}
 
-	// Fetch branches sorted by latest commit date (most active first)
-	cmd := exec.Command(
-		"gh", "api", "repos/"+fullRepo+"/branches",
-		"--jq", "sort_by(.commit.date) | reverse | .[0:50] | .[] | .name",
-	)
-	output, err := cmd.Output()
+	output, err := retry.Output(func() *exec.Cmd {
+		return exec.Command(
+			"gh", "api", "repos/"+fullRepo+"/branches?per_page=100",
+			"--paginate", "--jq", ".[].name",
+		)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -262,29 +360,100 @@ func listBranches(org, repo string) ([]string, error) {
 		}
 	}
 
+	if len(branches) > limit {
+		branches = branches[:limit]
+	}
+
 	return branches, nil
 }
 
+// selectFromLimitedList lets the user pick from a listing capped at limit.
+// When the listing is truncated, "Load more" doubles the limit and refetches,
+// and "Enter manually" prompts for a free-form value.
+func selectFromLimitedList(title, manualLabel string, limit int, fetch func(limit int) ([]string, error)) (string, error) {
+	for {
+		items, err := fetch(limit)
+		if err != nil {
+			return "", err
+		}
+
+		if len(items) == 0 {
+			return "", fmt.Errorf("no results found")
+		}
+
+		options := items
+		if len(items) >= limit {
+			options = append(append([]string{}, items...), optionLoadMore, optionEnterManual)
+		}
+
+		selected, err := ui.Select(title, options)
+		if err != nil {
+			return "", err
+		}
+
+		switch selected {
+		case optionLoadMore:
+			limit *= 2
+			continue
+		case optionEnterManual:
+			value, err := ui.Input(manualLabel, "")
+			if err != nil {
+				return "", err
+			}
+			if value == "" {
+				return "", fmt.Errorf("no value specified")
+			}
+			return value, nil
+		}
+
+		return selected, nil
+	}
+}
+
 // collectInputs guides user through providing typed input values.
+// Inputs whose condition does not hold for the values collected so far are dropped.
 func (h *DeployHandler) collectInputs(ctx context.Context, inputs []domain.Input, flags []string) ([]domain.Input, error) {
-	flagMap := parseInputFlags(flags)
+	if !h.anyInputs {
+		declared := make([]string, len(inputs))
+		for i, input := range inputs {
+			declared[i] = input.Key()
+		}
+		if err := checkInputKeys(flags, declared); err != nil {
+			return nil, err
+		}
+	}
+
+	flagMap, err := h.inputValues(inputs, flags)
+	if err != nil {
+		return nil, err
+	}
+	collected := make(map[string]string, len(inputs))
+	relevant := make([]domain.Input, 0, len(inputs))
+
+	for _, input := range inputs {
+		if !input.IsRelevant(collected) {
+			fmt.Println(ui.MutedStyle.Render(fmt.Sprintf("  Skipping %s (only when %s)", input.Key(), input.Condition())))
+			continue
+		}
 
-	for i, input := range inputs {
 		// Check if value was provided via flag
 		if val, ok := flagMap[input.Key()]; ok {
 			if err := input.SetValue(val); err != nil {
 				return nil, fmt.Errorf("input %s validation failed: %w", input.Key(), err)
 			}
-			inputs[i] = input
+			collected[input.Key()] = input.Value()
+			relevant = append(relevant, input)
 			continue
 		}
 
-		// Prompt user based on input type
+		// Prompt user based on input type, the workflow default preselected
+		def := input.Value()
 		switch input.Type() {
 		case domain.InputTypeChoice:
-			selectedValue, err := ui.Select(
+			selectedValue, err := ui.SelectWithDefault(
 				fmt.Sprintf("Select %s", input.Key()),
 				input.Options(),
+				def,
 			)
 			if err != nil {
 				return nil, err
@@ -294,7 +463,7 @@ func (h *DeployHandler) collectInputs(ctx context.Context, inputs []domain.Input
 			}
 
 		case domain.InputTypeBoolean:
-			confirmed, err := ui.Confirm(fmt.Sprintf("Enable %s?", input.Key()))
+			confirmed, err := ui.ConfirmWithDefault(fmt.Sprintf("Enable %s?", input.Key()), def == "true")
 			if err != nil {
 				return nil, err
 			}
@@ -306,29 +475,48 @@ func (h *DeployHandler) collectInputs(ctx context.Context, inputs []domain.Input
 				return nil, fmt.Errorf("input %s validation failed: %w", input.Key(), err)
 			}
 
+		case domain.InputTypeNumber:
+			value, err := ui.InputWithValidation(fmt.Sprintf("Enter %s", input.Key()), def, numberValidator(input.Key()))
+			if err != nil {
+				return nil, err
+			}
+			if value == "" {
+				value = def
+			}
+			if err := input.SetValue(value); err != nil {
+				return nil, fmt.Errorf("input %s validation failed: %w", input.Key(), err)
+			}
+
 		case domain.InputTypeString:
-			value, err := ui.Input(fmt.Sprintf("Enter %s", input.Key()), "")
+			value, err := ui.Input(fmt.Sprintf("Enter %s", input.Key()), def)
 			if err != nil {
 				return nil, err
 			}
+			if value == "" {
+				value = def
+			}
 			if err := input.SetValue(value); err != nil {
 				return nil, fmt.Errorf("input %s validation failed: %w", input.Key(), err)
 			}
 
 		default:
-			value, err := ui.Input(fmt.Sprintf("Enter %s", input.Key()), "")
+			value, err := ui.Input(fmt.Sprintf("Enter %s", input.Key()), def)
 			if err != nil {
 				return nil, err
 			}
+			if value == "" {
+				value = def
+			}
 			if err := input.SetValue(value); err != nil {
 				return nil, fmt.Errorf("input %s validation failed: %w", input.Key(), err)
 			}
 		}
 
-		inputs[i] = input
+		collected[input.Key()] = input.Value()
+		relevant = append(relevant, input)
 	}
 
-	return inputs, nil
+	return relevant, nil
 }
 
 // executeDeployment saves to history and executes the workflow trigger.
@@ -342,7 +530,7 @@ func (h *DeployHandler) executeDeployment(ctx context.Context, deployment domain
 			args = append(args, "--input", fmt.Sprintf("%s=%s", input.Key(), input.Value()))
 		}
 
-		h.history.Add("deploy", label, args)
+		h.history.Add("deploy", label, verbose.RedactArgs(args))
 		h.history.Save() //nolint:errcheck
 	}
 
@@ -351,14 +539,36 @@ func (h *DeployHandler) executeDeployment(ctx context.Context, deployment domain
 	if deployment.HasRun() {
 		ui.PrintSuccess(fmt.Sprintf("Workflow triggered: run %s", deployment.Run().ID()))
 
+		run := deployment.Run()
+		trackRun(h.repoURL, deployment.Workflow().Name(), deployment.Branch(), deployment.Workflow().Name(),
+			latestRun{ID: run.ID(), URL: run.URL()})
+
 		if watch {
-			ui.PrintInfo("Deployment tracking", "View progress with: devcli status")
+			return h.watchDeployment(ctx, deployment)
 		}
 	}
 
 	return nil
 }
 
+// watchDeployment streams the run until it completes. A run that did not
+// succeed is returned as an error carrying the exit code of its conclusion.
+func (h *DeployHandler) watchDeployment(ctx context.Context, deployment domain.Deployment) error {
+	runID := deployment.Run().ID()
+	conclusion, err := h.orchestrator.WatchDeployment(ctx, application.WatchDeploymentRequest{Deployment: deployment})
+	if err != nil {
+		return err
+	}
+
+	if code := conclusion.ExitCode(); code != 0 {
+		ui.PrintError(fmt.Sprintf("Workflow run #%s concluded: %s", runID, conclusion))
+		return &exitError{err: fmt.Errorf("workflow run #%s %s", runID, conclusion), code: code}
+	}
+
+	ui.PrintSuccess(fmt.Sprintf("Workflow run #%s completed successfully", runID))
+	return nil
+}
+
 // showHistoryMenu displays recent deployments for replay.
 func (h *DeployHandler) showHistoryMenu() (*domain.Deployment, error) {
 	if h.history == nil {
@@ -395,6 +605,62 @@ func (h *DeployHandler) showHistoryMenu() (*domain.Deployment, error) {
 
 // Helper functions
 
+// resolveLimit returns the flag value if set, then the config value, then the fallback.
+func resolveLimit(flagValue, configValue, fallback int) int {
+	if flagValue > 0 {
+		return flagValue
+	}
+	if configValue > 0 {
+		return configValue
+	}
+	return fallback
+}
+
+// resolveRepoLimit returns the number of repositories to list per owner:
+// --repo-limit, then deploy.repo_limit, then the default, at most maxRepoLimit.
+func resolveRepoLimit() int {
+	var configValue int
+	if cfg, err := config.Load(); err == nil {
+		configValue = cfg.Deploy.RepoLimit
+	}
+	return min(resolveLimit(flagRepoLimit, configValue, defaultListLimit), maxRepoLimit)
+}
+
+// resolveBranchLimit returns the number of branches listed at once:
+// --branch-limit, then deploy.branch_limit, then the default.
+func resolveBranchLimit() int {
+	var configValue int
+	if cfg, err := config.Load(); err == nil {
+		configValue = cfg.Deploy.BranchLimit
+	}
+	return resolveLimit(flagBranchLimit, configValue, defaultListLimit)
+}
+
+// inputValues returns the values given for inputs without prompting: those
+// of the inputs file, checked like in the GitHub flow, overridden by the
+// --input flags.
+func (h *DeployHandler) inputValues(inputs []domain.Input, flags []string) (map[string]string, error) {
+	if h.fileInputs == nil {
+		return parseInputFlags(flags), nil
+	}
+
+	specs := make(map[string]workflowInput, len(inputs))
+	declared := make(map[string]bool, len(inputs))
+	for _, input := range inputs {
+		specs[input.Key()] = workflowInput{Type: string(input.Type()), Options: input.Options()}
+		declared[input.Key()] = true
+	}
+	fileValues := h.fileInputs
+	if !h.anyInputs {
+		fileValues = dropUnknownInputs(fileValues, declared)
+	}
+	fileValues, err := coerceInputValues(specs, fileValues)
+	if err != nil {
+		return nil, err
+	}
+	return mergeInputDefaults(parseInputFlags(flags), fileValues), nil
+}
+
 func parseInputFlags(flags []string) map[string]string {
 	inputs := make(map[string]string)
 	for _, flag := range flags {
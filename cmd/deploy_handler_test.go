@@ -2,9 +2,14 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/20uf/devcli/internal/deployment/application"
 	"github.com/20uf/devcli/internal/deployment/domain"
+	"github.com/20uf/devcli/internal/deployment/infra"
 	"github.com/spf13/cobra"
 )
 
@@ -357,3 +362,232 @@ func TestDeployHandler_InvalidRepo(t *testing.T) {
 		t.Log("✓ Handler initialized with mocks for empty repo")
 	}
 }
+
+// Test: List limit resolution order (flag > config > default)
+func TestDeployHandler_ResolveLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		flag     int
+		config   int
+		expected int
+	}{
+		{"Default", 0, 0, 50},
+		{"Config overrides default", 0, 120, 120},
+		{"Flag overrides config", 200, 120, 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveLimit(tt.flag, tt.config, defaultListLimit); got != tt.expected {
+				t.Errorf("Got %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+// Test: the repository limit is capped at 200
+func TestResolveRepoLimit(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	orig := flagRepoLimit
+	defer func() { flagRepoLimit = orig }()
+
+	tests := []struct {
+		flag int
+		want int
+	}{
+		{0, defaultListLimit},
+		{120, 120},
+		{500, maxRepoLimit},
+	}
+	for _, tt := range tests {
+		flagRepoLimit = tt.flag
+		if got := resolveRepoLimit(); got != tt.want {
+			t.Errorf("resolveRepoLimit() with --repo-limit %d = %d, want %d", tt.flag, got, tt.want)
+		}
+	}
+
+	t.Log("✓ Repository limit capped")
+}
+
+// Test: the branch limit comes from --branch-limit, then deploy.branch_limit
+func TestResolveBranchLimit(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	orig := flagBranchLimit
+	defer func() { flagBranchLimit = orig }()
+
+	flagBranchLimit = 0
+	if got := resolveBranchLimit(); got != defaultListLimit {
+		t.Errorf("resolveBranchLimit() = %d, want %d", got, defaultListLimit)
+	}
+
+	if err := os.MkdirAll(filepath.Join(home, ".devcli"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".devcli", "config.yaml"), []byte("deploy:\n  branch_limit: 80\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := resolveBranchLimit(); got != 80 {
+		t.Errorf("resolveBranchLimit() with deploy.branch_limit = %d, want 80", got)
+	}
+	flagBranchLimit = 120
+	if got := resolveBranchLimit(); got != 120 {
+		t.Errorf("resolveBranchLimit() with --branch-limit = %d, want 120", got)
+	}
+
+	t.Log("✓ Branch limit resolved")
+}
+
+// Test: a truncated repository list tells how to see more
+func TestRepoLimitNote(t *testing.T) {
+	if got := repoLimitNote(50, 200); got != "(showing 50 of 200, use --repo-limit to see more)" {
+		t.Errorf("repoLimitNote(50, 200) = %q", got)
+	}
+	if got := repoLimitNote(50, 0); got != "(showing the first 50, use --repo-limit to see more)" {
+		t.Errorf("repoLimitNote(50, 0) = %q", got)
+	}
+
+	t.Log("✓ Repository limit note rendered")
+}
+
+// Test: repositories are listed by name with the current one first
+func TestSortRepos(t *testing.T) {
+	repos := []repoInfo{{NameWithOwner: "acme/web"}, {NameWithOwner: "acme/Api"}, {NameWithOwner: "acme/tools"}, {NameWithOwner: "acme/billing"}}
+
+	got := sortRepos(repos, "acme/tools")
+	want := []string{"acme/tools", "acme/Api", "acme/billing", "acme/web"}
+	for i, r := range got {
+		if r.NameWithOwner != want[i] {
+			t.Fatalf("sortRepos() = %v, want %v", got, want)
+		}
+	}
+	if repos[0].NameWithOwner != "acme/web" {
+		t.Error("sortRepos() reordered the cached list")
+	}
+
+	t.Log("✓ Repositories sorted")
+}
+
+// dispatchStub reports workflow_dispatch support from a fixed map.
+type dispatchStub struct {
+	domain.WorkflowRepository
+	dispatchable map[string]bool
+}
+
+func (s dispatchStub) IsDispatchable(ctx context.Context, w domain.Workflow) (bool, error) {
+	ok, known := s.dispatchable[w.Name()]
+	if !known {
+		return false, fmt.Errorf("workflow %s not found", w.Name())
+	}
+	return ok, nil
+}
+
+// Test: Workflows without workflow_dispatch are filtered out
+func TestDeployHandler_FilterDispatchable(t *testing.T) {
+	deploy, _ := domain.NewWorkflow("deploy.yml")
+	ci, _ := domain.NewWorkflow("ci.yml")
+	unknown, _ := domain.NewWorkflow("unknown.yml")
+
+	repo := dispatchStub{dispatchable: map[string]bool{
+		"deploy.yml": true,
+		"ci.yml":     false,
+	}}
+
+	runnable, skipped := filterDispatchable(context.Background(), repo, []domain.Workflow{deploy, ci, unknown})
+
+	if len(skipped) != 1 || skipped[0].Name() != "ci.yml" {
+		t.Errorf("Expected ci.yml to be skipped, got %v", skipped)
+	}
+
+	if len(runnable) != 2 || runnable[0].Name() != "deploy.yml" || runnable[1].Name() != "unknown.yml" {
+		t.Errorf("Expected deploy.yml and unknown.yml to be kept, got %v", runnable)
+	}
+
+	t.Log("✓ Non-dispatchable workflows filtered, unprobed ones kept")
+}
+
+// watchStub concludes every watched run with a fixed conclusion.
+type watchStub struct {
+	domain.RunRepository
+	conclusion domain.RunConclusion
+}
+
+func (s watchStub) WatchRun(ctx context.Context, runID string) (domain.RunConclusion, error) {
+	return s.conclusion, nil
+}
+
+// Test: Watching a deployment maps the run conclusion to the exit code
+func TestDeployHandler_WatchDeployment(t *testing.T) {
+	workflow, _ := domain.NewWorkflow("deploy.yml")
+	deployment, _ := domain.NewDeployment("test-1", workflow, "main", "owner/repo")
+	deployment.SetRun(domain.NewRun("99", 7, domain.RunStatusQueued, "main", ""))
+
+	tests := []struct {
+		conclusion domain.RunConclusion
+		wantCode   int
+	}{
+		{domain.RunConclusionSuccess, 0},
+		{domain.RunConclusionFailure, 1},
+		{domain.RunConclusionCancelled, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.conclusion), func(t *testing.T) {
+			repos := infra.CreateMockRepositories()
+			repos.Runs = watchStub{conclusion: tt.conclusion}
+			handler := &DeployHandler{orchestrator: application.NewTriggerDeploymentOrchestrator(repos)}
+
+			err := handler.watchDeployment(context.Background(), deployment)
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error for conclusion %s", tt.conclusion)
+			}
+			if got := exitCode(err); got != tt.wantCode {
+				t.Errorf("exitCode() = %d, want %d", got, tt.wantCode)
+			}
+		})
+	}
+
+	t.Log("✓ Run conclusion mapped to exit code")
+}
+
+// Test: File inputs are read once and checked against their input type
+func TestDeployHandler_InputValues(t *testing.T) {
+	env, _ := domain.NewChoiceInput("environment", "", []string{"staging", "production"}, true)
+	dryRun, _ := domain.NewInput("dry_run", domain.InputTypeBoolean, "false", false)
+	inputs := []domain.Input{env, dryRun}
+
+	handler := &DeployHandler{fileInputs: map[string]string{"environment": "staging", "dry_run": "TRUE"}}
+	for range 2 {
+		got, err := handler.inputValues(inputs, []string{"environment=production"})
+		if err != nil {
+			t.Fatalf("inputValues() error = %v", err)
+		}
+		if got["environment"] != "production" || got["dry_run"] != "true" {
+			t.Errorf("inputValues() = %v", got)
+		}
+	}
+
+	handler.fileInputs = map[string]string{"environment": "qa"}
+	if _, err := handler.inputValues(inputs, nil); err == nil {
+		t.Error("Expected an error for a value outside the choices")
+	}
+
+	// GitLab pipelines take variables they do not prefill
+	gitlab := &DeployHandler{fileInputs: map[string]string{"DEPLOY_TAG": "v1"}, anyInputs: true}
+	got, err := gitlab.inputValues(inputs, []string{"FORCE=1"})
+	if err != nil || got["DEPLOY_TAG"] != "v1" || got["FORCE"] != "1" {
+		t.Errorf("inputValues() = %v, %v, want the undeclared variables kept", got, err)
+	}
+	collected, err := gitlab.collectInputs(context.Background(), []domain.Input{dryRun}, []string{"dry_run=true", "FORCE=1"})
+	if err != nil || len(collected) != 1 {
+		t.Errorf("collectInputs() = %v, %v, want undeclared variables accepted", collected, err)
+	}
+
+	t.Log("✓ File inputs reused and validated")
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/20uf/devcli/internal/deployment/domain"
+	"github.com/20uf/devcli/internal/history"
+	"github.com/20uf/devcli/internal/ui"
+	"github.com/20uf/devcli/internal/verbose"
+)
+
+// lastUsedInputs returns the --input values of the most recent deploy of the
+// same repo and workflow, keyed by input name.
+func lastUsedInputs(hist *history.Store, repo, workflowName string) map[string]string {
+	if hist == nil {
+		return nil
+	}
+
+	entry := hist.FindByLabel("deploy", fmt.Sprintf("%s/%s @ ", repo, workflowName))
+	if entry == nil {
+		return nil
+	}
+	return historyInputs(entry.Args)
+}
+
+// historyInputs extracts key=value pairs from the --input args of a history entry.
+// Redacted secrets are left out so they are prompted again.
+func historyInputs(args []string) map[string]string {
+	inputs := make(map[string]string)
+	for i := 0; i < len(args)-1; i++ {
+		if args[i] != "--input" {
+			continue
+		}
+		key, value, ok := strings.Cut(args[i+1], "=")
+		if ok && key != "" && value != verbose.Redacted {
+			inputs[key] = value
+		}
+		i++
+	}
+	return inputs
+}
+
+// applyInputDefaults appends a key=value pair for every default whose input
+// was not given, so flag-driven deploys send the committed workflow defaults.
+func applyInputDefaults(values []string, defaults map[string]string) []string {
+	given := make(map[string]bool, len(values))
+	for _, v := range values {
+		key, _, _ := strings.Cut(v, "=")
+		given[key] = true
+	}
+
+	var missing []string
+	for key := range defaults {
+		if !given[key] {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+
+	for _, key := range missing {
+		values = append(values, fmt.Sprintf("%s=%s", key, defaults[key]))
+	}
+	return values
+}
+
+// inputDefaults returns the default value held by each typed input, if any.
+func inputDefaults(inputs []domain.Input) map[string]string {
+	defaults := make(map[string]string)
+	for _, input := range inputs {
+		if input.Value() != "" {
+			defaults[input.Key()] = input.Value()
+		}
+	}
+	return defaults
+}
+
+// mergeInputDefaults fills the inputs missing from values with their default.
+func mergeInputDefaults(values, defaults map[string]string) map[string]string {
+	merged := make(map[string]string, len(values)+len(defaults))
+	for key, value := range defaults {
+		merged[key] = value
+	}
+	for key, value := range values {
+		merged[key] = value
+	}
+	return merged
+}
+
+// numberValidator rejects prompt values that are not numbers, using the
+// domain rules of number inputs. An empty value keeps the default.
+func numberValidator(key string) func(string) error {
+	return func(value string) error {
+		input, err := domain.NewInput(key, domain.InputTypeNumber, value, false)
+		if err != nil {
+			return err
+		}
+		if err := input.Validate(); err != nil {
+			return fmt.Errorf("%s must be a number", key)
+		}
+		return nil
+	}
+}
+
+// promptRedactedInputs asks again for the secret inputs masked in history,
+// since their values were never stored.
+func promptRedactedInputs(values []string) ([]string, error) {
+	restored := make([]string, len(values))
+	for i, v := range values {
+		key, value, _ := strings.Cut(v, "=")
+		if value != verbose.Redacted {
+			restored[i] = v
+			continue
+		}
+		secret, err := ui.Input(fmt.Sprintf("Enter %s (not stored in history)", key), "")
+		if err != nil {
+			return nil, err
+		}
+		restored[i] = fmt.Sprintf("%s=%s", key, secret)
+	}
+	return restored, nil
+}
+
+// checkInputKeys fails when an --input names an input the workflow does not
+// declare, listing the valid names, rather than letting gh reject the run.
+func checkInputKeys(flags []string, declared []string) error {
+	valid := make(map[string]bool, len(declared))
+	for _, name := range declared {
+		valid[name] = true
+	}
+
+	var unknown []string
+	for _, flag := range flags {
+		key, _, _ := strings.Cut(flag, "=")
+		if !valid[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	names := append([]string(nil), declared...)
+	sort.Strings(names)
+	if len(names) == 0 {
+		return fmt.Errorf("unknown input(s): %s\n  The workflow declares no inputs", strings.Join(unknown, ", "))
+	}
+	return fmt.Errorf("unknown input(s): %s\n  Valid inputs: %s", strings.Join(unknown, ", "), strings.Join(names, ", "))
+}
@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/20uf/devcli/internal/deployment/infra"
+	"github.com/20uf/devcli/internal/ui"
+	"gopkg.in/yaml.v3"
+)
+
+// editInputs opens the workflow inputs in $EDITOR as a YAML file pre-filled
+// with prefill, like kubectl edit, and returns the saved values checked
+// against the input types. When they are invalid, the editor opens again
+// with the error on top until the file is fixed or saved unchanged. The
+// file may hold secrets: it is always removed.
+func editInputs(workflow string, inputs map[string]workflowInput, prefill map[string]string) (map[string]string, error) {
+	f, err := createInputsFile(workflow)
+	if err != nil {
+		return nil, fmt.Errorf("could not create the inputs file: %w", err)
+	}
+	path := f.Name()
+	defer os.Remove(path) //nolint:errcheck
+
+	content := inputsTemplate(workflow, inputs, prefill)
+	_, err = f.WriteString(content)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not write %s: %w", path, err)
+	}
+
+	for {
+		if err := runEditor(path); err != nil {
+			return nil, err
+		}
+		edited, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		values, err := editedInputs(path, inputs)
+		if err == nil || errors.Is(err, ui.ErrUserAbort) || stripEditorErrorHeader(string(edited)) == stripEditorErrorHeader(content) {
+			return values, err
+		}
+		content = editorErrorHeader(err) + stripEditorErrorHeader(string(edited))
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// createInputsFile creates the edited file, named after the workflow so
+// editors recognize it, in a directory of the user: $XDG_RUNTIME_DIR, else
+// ~/.devcli. The file is created exclusively, never through an existing
+// file or symlink.
+func createInputsFile(workflow string) (*os.File, error) {
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = filepath.Join(home, ".devcli")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, err
+		}
+	}
+	name := strings.TrimSuffix(filepath.Base(workflow), filepath.Ext(workflow))
+	return os.CreateTemp(dir, fmt.Sprintf("devcli-inputs-%s-*.yaml", name))
+}
+
+// editedInputs reads the saved inputs file, checked against the inputs.
+func editedInputs(path string, inputs map[string]workflowInput) (map[string]string, error) {
+	values, err := readInputsFile(path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, ui.ErrUserAbort
+	}
+
+	declared := make(map[string]bool, len(inputs))
+	for name := range inputs {
+		declared[name] = true
+	}
+	values, err = coerceInputValues(inputs, dropUnknownInputs(values, declared))
+	if err == nil {
+		err = checkRequiredInputs(inputs, values)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// editorErrorPrefix starts the comment lines reporting why an edit was rejected.
+const editorErrorPrefix = "# Error: "
+
+// editorErrorHeader reports err above the inputs when the editor reopens.
+func editorErrorHeader(err error) string {
+	var b strings.Builder
+	for _, line := range strings.Split(err.Error(), "\n") {
+		b.WriteString(editorErrorPrefix + strings.TrimSpace(line) + "\n")
+	}
+	b.WriteString("# Fix the inputs, or save the file unchanged to cancel.\n")
+	return b.String()
+}
+
+// stripEditorErrorHeader removes the error reported by a previous attempt.
+func stripEditorErrorHeader(content string) string {
+	for strings.HasPrefix(content, editorErrorPrefix) || strings.HasPrefix(content, "# Fix the inputs,") {
+		_, rest, _ := strings.Cut(content, "\n")
+		content = rest
+	}
+	return content
+}
+
+// inputsTemplate lists each input with its description, type and options
+// as a comment above its value.
+func inputsTemplate(workflow string, inputs map[string]workflowInput, prefill map[string]string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Inputs of %s: save and quit to deploy, empty the file to cancel.\n", workflow)
+
+	for _, name := range infra.OrderedInputKeys(inputs) {
+		input := inputs[name]
+		b.WriteString("\n")
+		if input.Description != "" {
+			fmt.Fprintf(&b, "# %s\n", strings.ReplaceAll(strings.TrimSpace(input.Description), "\n", "\n# "))
+		}
+		fmt.Fprintf(&b, "# %s\n", inputSummary(input))
+		fmt.Fprintf(&b, "%s: %s\n", name, yamlScalar(prefill[name]))
+	}
+	return b.String()
+}
+
+// inputSummary describes the type of an input and whether it is required.
+func inputSummary(input workflowInput) string {
+	summary := string(input.InputType())
+	if len(input.Options) > 0 {
+		summary += ": " + strings.Join(input.Options, ", ")
+	}
+	if input.Required {
+		summary += " (required)"
+	}
+	return summary
+}
+
+// yamlScalar quotes value when YAML would read it as another type or
+// structure, so "true" or "1.0" stay strings until the input types apply.
+func yamlScalar(value string) string {
+	out, err := yaml.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%q", value)
+	}
+	return strings.TrimSuffix(string(out), "\n")
+}
+
+// checkRequiredInputs fails when a required input was left empty.
+func checkRequiredInputs(inputs map[string]workflowInput, values map[string]string) error {
+	var missing []string
+	for name, input := range inputs {
+		if input.Required && values[name] == "" {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("required input(s) left empty: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// runEditor opens path in $EDITOR, vi when unset. EDITOR may carry
+// arguments, such as "code --wait".
+func runEditor(path string) error {
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{"vi"}
+	}
+	cmd := exec.Command(editor[0], append(editor[1:], path)...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("editor %s failed: %w", editor[0], err)
+	}
+	return nil
+}
+
+// checkInputsEditorFlags rejects --inputs with the flags that already
+// provide the inputs.
+func checkInputsEditorFlags() error {
+	if !flagInputsEditor {
+		return nil
+	}
+	conflicts := []struct {
+		name string
+		set  bool
+	}{
+		{"--inputs-file", flagInputsFile != ""},
+		{"--auto-select", flagAutoSelect},
+		{"--last", flagLast},
+		{"--rerun-last", flagRerunLast},
+	}
+	for _, c := range conflicts {
+		if c.set {
+			return fmt.Errorf("--inputs cannot be combined with %s", c.name)
+		}
+	}
+	return nil
+}
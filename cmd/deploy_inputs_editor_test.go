@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+// Test: The inputs edited in $EDITOR are read back and checked against their types
+func TestEditInputs(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_RUNTIME_DIR", dir)
+	inputs := map[string]workflowInput{
+		"environment": {Description: "Target environment", Type: "choice", Options: []string{"staging", "production"}, Required: true},
+		"dry_run":     {Type: "boolean"},
+		"config":      {Type: "string"},
+	}
+	prefill := map[string]string{"environment": "staging", "dry_run": "true", "config": "{\"replicas\": 2}\n"}
+
+	t.Setenv("EDITOR", "sed -i s/staging/production/")
+	values, err := editInputs(".github/workflows/deploy.yml", inputs, prefill)
+	if err != nil {
+		t.Fatalf("editInputs() error = %v", err)
+	}
+	want := map[string]string{"environment": "production", "dry_run": "true", "config": "{\"replicas\": 2}\n"}
+	for key, value := range want {
+		if values[key] != value {
+			t.Errorf("Input %s = %q, want %q", key, values[key], value)
+		}
+	}
+
+	// An invalid edit reopens the editor until the file is saved unchanged
+	t.Setenv("EDITOR", "sed -i s/staging/qa/")
+	_, err = editInputs("deploy.yml", inputs, prefill)
+	if err == nil || !strings.Contains(err.Error(), "qa") {
+		t.Errorf("Invalid choice should fail, got %v", err)
+	}
+
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("Edited files left behind: %v", entries)
+	}
+
+	t.Log("✓ Inputs edited in $EDITOR")
+}
+
+// Test: The error of a rejected edit is shown once above the inputs
+func TestEditorErrorHeader(t *testing.T) {
+	content := editorErrorHeader(errors.New("invalid value for environment")) + "environment: qa\n"
+	again := editorErrorHeader(errors.New("still invalid")) + stripEditorErrorHeader(content)
+
+	if strings.Count(again, editorErrorPrefix) != 1 || !strings.HasSuffix(again, "environment: qa\n") {
+		t.Errorf("Unexpected content:\n%s", again)
+	}
+
+	t.Log("✓ Error header replaced")
+}
+
+// Test: The template documents each input above its value
+func TestInputsTemplate(t *testing.T) {
+	inputs := map[string]workflowInput{
+		"environment": {Description: "Target environment", Type: "choice", Options: []string{"staging", "production"}, Required: true},
+		"version":     {Type: "string"},
+	}
+	got := inputsTemplate("deploy.yml", inputs, map[string]string{"environment": "staging", "version": "1.0"})
+
+	for _, want := range []string{
+		"# Target environment\n# choice: staging, production (required)\nenvironment: staging\n",
+		"# string\nversion: \"1.0\"\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Template missing %q:\n%s", want, got)
+		}
+	}
+
+	t.Log("✓ Inputs template generated")
+}
+
+// Test: A positional key=value after --inputs is rejected instead of dropped
+func TestDeployArgs(t *testing.T) {
+	if err := deployCmd.Args(deployCmd, []string{"env=prod"}); err == nil {
+		t.Error("deploy accepted a positional argument")
+	}
+	if err := deployCmd.Args(deployCmd, nil); err != nil {
+		t.Errorf("deploy without arguments = %v", err)
+	}
+
+	t.Log("✓ Positional deploy arguments rejected")
+}
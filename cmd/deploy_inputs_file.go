@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/20uf/devcli/internal/deployment/domain"
+	"github.com/20uf/devcli/internal/ui"
+	"gopkg.in/yaml.v3"
+)
+
+// envPlaceholder matches the ${VAR} references interpolated in inputs files.
+var envPlaceholder = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// loadInputsFile reads the --inputs-file once, nil when the flag is unset:
+// stdin ("-") cannot be read a second time.
+func loadInputsFile() (map[string]string, error) {
+	if flagInputsFile == "" {
+		return nil, nil
+	}
+	return readInputsFile(flagInputsFile, os.Stdin)
+}
+
+// readInputsFile loads the workflow inputs of an --inputs-file, "-" reading
+// them from stdin. Files ending in .json are read as JSON, others as YAML.
+func readInputsFile(path string, stdin io.Reader) (map[string]string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read inputs file: %w", err)
+	}
+
+	values, err := parseInputsFile(data, strings.EqualFold(filepath.Ext(path), ".json"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid inputs file %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// parseInputsFile reads a YAML or JSON object of input names to scalar
+// values, then replaces ${VAR} in string values with the environment
+// variable VAR. Values are substituted as is, never parsed as YAML or JSON.
+func parseInputsFile(data []byte, isJSON bool) (map[string]string, error) {
+	if !isJSON {
+		return parseYAMLInputs(data)
+	}
+
+	var raw map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber() // keep 12345678 rather than 1.2345678e+07
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(raw))
+	var missing []string
+	for key, value := range raw {
+		switch v := value.(type) {
+		case nil:
+			values[key] = ""
+		case string:
+			values[key] = interpolateEnv(v, &missing)
+		case bool, json.Number:
+			values[key] = fmt.Sprint(v)
+		default:
+			return nil, fmt.Errorf("input %s must be a string, number or boolean", key)
+		}
+	}
+	if err := missingEnvError(missing); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// parseYAMLInputs reads the YAML scalars as they are written: decoding them
+// would turn 1.10 into 1.1, 0123 into 83 and reject dates or big numbers.
+func parseYAMLInputs(data []byte) (map[string]string, error) {
+	var raw map[string]yaml.Node
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(raw))
+	var missing []string
+	for key, node := range raw {
+		if node.Kind == yaml.AliasNode {
+			node = *node.Alias
+		}
+		switch {
+		case node.Kind != yaml.ScalarNode:
+			return nil, fmt.Errorf("input %s must be a string, number or boolean", key)
+		case node.Tag == "!!null":
+			values[key] = ""
+		default:
+			values[key] = interpolateEnv(node.Value, &missing)
+		}
+	}
+	if err := missingEnvError(missing); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// missingEnvError lists the unset variables referenced by an inputs file.
+func missingEnvError(missing []string) error {
+	if len(missing) == 0 {
+		return nil
+	}
+	sort.Strings(missing)
+	return fmt.Errorf("environment variable(s) not set: %s", strings.Join(slices.Compact(missing), ", "))
+}
+
+// interpolateEnv replaces each ${VAR} of value with the value of VAR. Unset
+// variables are appended to missing: a missing secret must not be sent as
+// an empty input.
+func interpolateEnv(value string, missing *[]string) string {
+	return envPlaceholder.ReplaceAllStringFunc(value, func(match string) string {
+		name := envPlaceholder.FindStringSubmatch(match)[1]
+		env, ok := os.LookupEnv(name)
+		if !ok {
+			*missing = append(*missing, name)
+		}
+		return env
+	})
+}
+
+// coerceInputValues applies the rules of the interactive prompts to values
+// loaded from a file: a choice must be one of its options, a boolean is
+// normalized to true or false and a number must parse.
+func coerceInputValues(inputs map[string]workflowInput, values map[string]string) (map[string]string, error) {
+	coerced := make(map[string]string, len(values))
+	for key, value := range values {
+		input, ok := inputs[key]
+		if !ok {
+			coerced[key] = value
+			continue
+		}
+
+		switch {
+		case input.Type == "choice" && len(input.Options) > 0:
+			if !containsString(input.Options, value) {
+				return nil, fmt.Errorf("input %s must be one of %s, got %q", key, strings.Join(input.Options, ", "), value)
+			}
+		case input.Type == "boolean":
+			b, err := strconv.ParseBool(strings.ToLower(value))
+			if err != nil {
+				return nil, fmt.Errorf("input %s must be true or false, got %q", key, value)
+			}
+			value = strconv.FormatBool(b)
+		case input.InputType() == domain.InputTypeNumber && value != "":
+			if err := numberValidator(key)(value); err != nil {
+				return nil, err
+			}
+		}
+		coerced[key] = value
+	}
+	return coerced, nil
+}
+
+// dropUnknownInputs removes the file values of inputs the workflow does not
+// declare, with a warning: a shared inputs file may cover several workflows.
+func dropUnknownInputs(values map[string]string, declared map[string]bool) map[string]string {
+	kept := make(map[string]string, len(values))
+	var unknown []string
+	for key, value := range values {
+		if declared[key] {
+			kept[key] = value
+		} else {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		ui.PrintWarning(fmt.Sprintf("Ignoring input(s) not declared by the workflow: %s", strings.Join(unknown, ", ")))
+	}
+	return kept
+}
+
+// overrideInputs returns the file values as key=value pairs sorted by key,
+// each replaced by the --input flag of the same key, followed by the flags
+// setting other keys.
+func overrideInputs(fileValues map[string]string, flags []string) []string {
+	merged := make(map[string]string, len(fileValues)+len(flags))
+	for key, value := range fileValues {
+		merged[key] = value
+	}
+	for _, flag := range flags {
+		if key, value, ok := strings.Cut(flag, "="); ok && key != "" {
+			merged[key] = value
+		}
+	}
+
+	keys := make([]string, 0, len(merged))
+	for key := range merged {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = fmt.Sprintf("%s=%s", key, merged[key])
+	}
+	return pairs
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Test: Inputs files are YAML mappings with ${VAR} interpolation
+func TestParseInputsFile(t *testing.T) {
+	t.Setenv("IMAGE_TAG", "sha256:def456")
+
+	got, err := parseInputsFile([]byte(`
+environment: prod
+image_tag: ${IMAGE_TAG}
+replicas: 3
+dry_run: false
+note:
+`), false)
+	if err != nil {
+		t.Fatalf("parseInputsFile() error = %v", err)
+	}
+	want := map[string]string{
+		"environment": "prod",
+		"image_tag":   "sha256:def456",
+		"replicas":    "3",
+		"dry_run":     "false",
+		"note":        "",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseInputsFile() = %v, want %v", got, want)
+	}
+
+	// Scalars are kept as written, not decoded
+	got, err = parseInputsFile([]byte("tag: 1.10\nsha: 0123\ndate: 2024-01-01\nbuild: 12345678901234567890\nquoted: \"null\"\n"), false)
+	want = map[string]string{"tag": "1.10", "sha": "0123", "date": "2024-01-01", "build": "12345678901234567890", "quoted": "null"}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("parseInputsFile() = %v, %v, want %v", got, err, want)
+	}
+
+	// Values are substituted after parsing: YAML syntax in a variable stays text
+	t.Setenv("NOTE", "release: 2.5 # hotfix\nrollback: true")
+	got, err = parseInputsFile([]byte("note: ${NOTE}\n"), false)
+	if err != nil || len(got) != 1 || got["note"] != "release: 2.5 # hotfix\nrollback: true" {
+		t.Errorf("parseInputsFile() = %v, %v, want the variable as is", got, err)
+	}
+	t.Setenv("QUOTED", `say "hi"`)
+	got, err = parseInputsFile([]byte(`{"message": "${QUOTED}"}`), true)
+	if err != nil || got["message"] != `say "hi"` {
+		t.Errorf("parseInputsFile(json) = %v, %v", got, err)
+	}
+
+	if _, err := parseInputsFile([]byte("tag: ${DEVCLI_TEST_UNSET}"), false); err == nil || !strings.Contains(err.Error(), "DEVCLI_TEST_UNSET") {
+		t.Errorf("Expected an unset variable error, got %v", err)
+	}
+	if _, err := parseInputsFile([]byte("nested:\n  key: value"), false); err == nil {
+		t.Error("Expected an error for a nested value")
+	}
+
+	t.Log("✓ Inputs file parsed")
+}
+
+// Test: JSON inputs files keep their numbers intact
+func TestReadInputsFile_JSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "inputs.json")
+	if err := os.WriteFile(path, []byte(`{"environment": "prod", "build": 12345678, "dry_run": true}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := readInputsFile(path, nil)
+	if err != nil {
+		t.Fatalf("readInputsFile() error = %v", err)
+	}
+	want := map[string]string{"environment": "prod", "build": "12345678", "dry_run": "true"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readInputsFile() = %v, want %v", got, want)
+	}
+
+	fromStdin, err := readInputsFile("-", strings.NewReader("environment: staging"))
+	if err != nil || fromStdin["environment"] != "staging" {
+		t.Errorf("readInputsFile(-) = %v, %v", fromStdin, err)
+	}
+
+	t.Log("✓ JSON and stdin inputs read")
+}
+
+// Test: File inputs the workflow does not declare are dropped
+func TestDropUnknownInputs(t *testing.T) {
+	got := dropUnknownInputs(map[string]string{"environment": "prod", "region": "eu"}, map[string]bool{"environment": true})
+	if want := map[string]string{"environment": "prod"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("dropUnknownInputs() = %v, want %v", got, want)
+	}
+
+	t.Log("✓ Unknown file inputs dropped")
+}
+
+// Test: File values follow the prompt rules of their input
+func TestCoerceInputValues(t *testing.T) {
+	inputs := map[string]workflowInput{
+		"environment": {Type: "choice", Options: []string{"staging", "prod"}},
+		"dry_run":     {Type: "boolean"},
+		"replicas":    {Type: "number"},
+	}
+
+	got, err := coerceInputValues(inputs, map[string]string{"environment": "prod", "dry_run": "TRUE", "replicas": "3", "extra": "x"})
+	if err != nil {
+		t.Fatalf("coerceInputValues() error = %v", err)
+	}
+	if got["dry_run"] != "true" || got["extra"] != "x" {
+		t.Errorf("coerceInputValues() = %v", got)
+	}
+
+	invalid := []map[string]string{
+		{"environment": "qa"},
+		{"dry_run": "maybe"},
+		{"replicas": "three"},
+	}
+	for _, values := range invalid {
+		if _, err := coerceInputValues(inputs, values); err == nil {
+			t.Errorf("coerceInputValues(%v) should fail", values)
+		}
+	}
+
+	t.Log("✓ File values validated")
+}
+
+// Test: --input flags win over the inputs file
+func TestOverrideInputs(t *testing.T) {
+	got := overrideInputs(map[string]string{"environment": "staging", "tag": "v1"}, []string{"environment=prod", "url=a=b"})
+	want := []string{"environment=prod", "tag=v1", "url=a=b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("overrideInputs() = %v, want %v", got, want)
+	}
+
+	t.Log("✓ Flags override file inputs")
+}
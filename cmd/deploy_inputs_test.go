@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/20uf/devcli/internal/deployment/domain"
+	"github.com/20uf/devcli/internal/deployment/infra"
+	"github.com/20uf/devcli/internal/history"
+	"github.com/20uf/devcli/internal/verbose"
+)
+
+// Test: the last deploy of the same repo+workflow provides the prefilled inputs
+func TestLastUsedInputs(t *testing.T) {
+	hist := &history.Store{}
+	hist.Add("deploy", "owner/api/Deploy @ main", []string{
+		"--repo", "owner/api", "--workflow", "deploy.yml", "--branch", "main",
+		"--input", "environment=staging", "--input", "version=1.0",
+	})
+	hist.Add("deploy", "owner/web/Deploy @ main", []string{
+		"--repo", "owner/web", "--workflow", "deploy.yml", "--branch", "main",
+		"--input", "environment=dev",
+	})
+	hist.Add("deploy", "owner/api/Deploy @ release", []string{
+		"--repo", "owner/api", "--workflow", "deploy.yml", "--branch", "release",
+		"--input", "environment=prod", "--input", "notes=a=b",
+	})
+
+	tests := []struct {
+		name     string
+		repo     string
+		workflow string
+		want     map[string]string
+	}{
+		{"Most recent entry wins", "owner/api", "Deploy", map[string]string{"environment": "prod", "notes": "a=b"}},
+		{"Other repo", "owner/web", "Deploy", map[string]string{"environment": "dev"}},
+		{"Unknown workflow", "owner/api", "Release", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := lastUsedInputs(hist, tt.repo, tt.workflow)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("lastUsedInputs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := lastUsedInputs(nil, "owner/api", "Deploy"); got != nil {
+		t.Errorf("lastUsedInputs(nil) = %v, want nil", got)
+	}
+
+	t.Log("✓ Last used inputs recovered from history")
+}
+
+// Test: a --label deploy keeps its history entry found by repo+workflow
+func TestHistoryLabel(t *testing.T) {
+	if got := historyLabel("owner/api/Deploy @ main", ""); got != "owner/api/Deploy @ main" {
+		t.Errorf("historyLabel() = %q, want the auto label", got)
+	}
+
+	label := historyLabel("owner/api/Deploy @ main", "release-2.5.0")
+	if label != "owner/api/Deploy @ main [release-2.5.0]" {
+		t.Errorf("historyLabel() = %q", label)
+	}
+
+	hist := &history.Store{}
+	hist.Add("deploy", label, []string{
+		"--repo", "owner/api", "--workflow", "deploy.yml", "--branch", "main",
+		"--input", "environment=prod", "--label", "release-2.5.0",
+	})
+	want := map[string]string{"environment": "prod"}
+	if got := lastUsedInputs(hist, "owner/api", "Deploy"); !reflect.DeepEqual(got, want) {
+		t.Errorf("lastUsedInputs() = %v, want %v", got, want)
+	}
+
+	t.Log("✓ Labeled deploys recorded in history")
+}
+
+// Test: secret inputs are masked in history and never prefilled from it
+func TestHistoryInputs_RedactedSecrets(t *testing.T) {
+	args := verbose.RedactArgs([]string{
+		"--repo", "owner/api", "--workflow", "deploy.yml", "--branch", "main",
+		"--input", "environment=prod", "--input", "api_token=s3cr3t",
+	})
+	if args[len(args)-1] != "api_token=***" {
+		t.Fatalf("RedactArgs() = %v, want the token masked", args)
+	}
+
+	got := historyInputs(args)
+	want := map[string]string{"environment": "prod"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("historyInputs() = %v, want %v", got, want)
+	}
+
+	t.Log("✓ Secrets masked in history and prompted again")
+}
+
+// Test: a workflow default is applied identically by the legacy and handler flows
+func TestWorkflowDefaults_SameOnEveryPath(t *testing.T) {
+	workflow := []byte(`
+on:
+  workflow_dispatch:
+    inputs:
+      environment:
+        type: choice
+        options: [dev, prod]
+        default: dev
+      dry_run:
+        type: boolean
+        default: false
+      replicas:
+        default: 2
+      tag:
+        type: string
+`)
+	specs, err := infra.ParseWorkflowInputs(workflow)
+	if err != nil {
+		t.Fatalf("ParseWorkflowInputs() error = %v", err)
+	}
+
+	// Legacy flow: flag values completed with the YAML defaults
+	legacy := parseInputFlags(applyInputDefaults([]string{"tag=v1"}, infra.WorkflowInputDefaults(specs)))
+
+	// Handler flow: typed inputs holding their default
+	var typed []domain.Input
+	for key, spec := range specs {
+		input, err := spec.ToInput(key)
+		if err != nil {
+			t.Fatalf("ToInput(%s) error = %v", key, err)
+		}
+		typed = append(typed, input)
+	}
+	handler := mergeInputDefaults(map[string]string{"tag": "v1"}, inputDefaults(typed))
+
+	want := map[string]string{"environment": "dev", "dry_run": "false", "replicas": "2", "tag": "v1"}
+	if !reflect.DeepEqual(legacy, want) {
+		t.Errorf("legacy inputs = %v, want %v", legacy, want)
+	}
+	if !reflect.DeepEqual(handler, want) {
+		t.Errorf("handler inputs = %v, want %v", handler, want)
+	}
+
+	t.Log("✓ Workflow defaults applied identically on both paths")
+}
+
+// Test: values given explicitly are never replaced by defaults
+func TestApplyInputDefaults_KeepsGivenValues(t *testing.T) {
+	got := applyInputDefaults([]string{"environment=prod"}, map[string]string{"environment": "dev", "region": "eu"})
+	want := []string{"environment=prod", "region=eu"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("applyInputDefaults() = %v, want %v", got, want)
+	}
+
+	t.Log("✓ Explicit inputs take precedence over defaults")
+}
+
+// Test: --input keys must be declared by the workflow
+func TestCheckInputKeys(t *testing.T) {
+	declared := []string{"environment", "dry_run"}
+
+	if err := checkInputKeys([]string{"environment=prod", "dry_run=true"}, declared); err != nil {
+		t.Errorf("Declared inputs rejected: %v", err)
+	}
+
+	err := checkInputKeys([]string{"enviroment=prod"}, declared)
+	if err == nil || !strings.Contains(err.Error(), "enviroment") || !strings.Contains(err.Error(), "Valid inputs: dry_run, environment") {
+		t.Errorf("Expected the unknown key and the valid ones, got %v", err)
+	}
+
+	if err := checkInputKeys([]string{"tag=v1"}, nil); err == nil || !strings.Contains(err.Error(), "declares no inputs") {
+		t.Errorf("Expected an error for a workflow without inputs, got %v", err)
+	}
+
+	t.Log("✓ Unknown --input keys rejected")
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/20uf/devcli/internal/ui"
+	"github.com/20uf/devcli/internal/verbose"
+	"github.com/spf13/cobra"
+)
+
+var deployListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the workflows of a repository",
+	Long: `List the workflows of a repository with their file and state.
+
+Examples:
+  devcli deploy list                          Workflows of the current repository
+  devcli deploy list --repo owner/api -o json  Same, as JSON`,
+	Args: cobra.NoArgs,
+	RunE: runDeployList,
+}
+
+var (
+	flagDeployListRepo   string
+	flagDeployListOutput string
+)
+
+func init() {
+	deployListCmd.Flags().StringVar(&flagDeployListRepo, "repo", "", "GitHub repository (owner/repo, default: the current repository)")
+	addOutputFlag(deployListCmd, &flagDeployListOutput)
+	deployCmd.AddCommand(deployListCmd)
+}
+
+// workflowRow is a workflow as listed by `deploy list`.
+type workflowRow struct {
+	Name  string `json:"name"`
+	File  string `json:"file"`
+	State string `json:"state"`
+}
+
+func runDeployList(cmd *cobra.Command, args []string) error {
+	format, err := ui.ParseOutputFormat(flagDeployListOutput)
+	if err != nil {
+		return err
+	}
+	if _, err := verbose.LookPath("gh"); err != nil {
+		return fmt.Errorf("GitHub CLI (gh) is required.\n  Install: https://cli.github.com/")
+	}
+
+	repo := flagDeployListRepo
+	if repo == "" {
+		repo = currentGitHubRepo()
+	}
+	if repo == "" {
+		return fmt.Errorf("no repository specified, pass --repo owner/name")
+	}
+
+	workflows, err := listWorkflows(repo)
+	if err != nil {
+		return err
+	}
+	return ui.RenderList(os.Stdout, format, workflowRows(workflows))
+}
+
+// workflowRows converts workflows to list rows.
+func workflowRows(workflows []ghWorkflow) []workflowRow {
+	rows := make([]workflowRow, len(workflows))
+	for i, w := range workflows {
+		rows[i] = workflowRow{Name: w.Name, File: extractWorkflowFile(w.Path), State: w.State}
+	}
+	return rows
+}
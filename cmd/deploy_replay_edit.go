@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/20uf/devcli/internal/ui"
+	"github.com/20uf/devcli/internal/verbose"
+)
+
+// inputChange is an input whose value differs from the replayed deployment.
+type inputChange struct {
+	Key string
+	Old string
+	New string
+}
+
+// editReplayInputs runs the input prompts of the workflow pre-filled with
+// the saved values of a replayed deployment, then shows what changed.
+// Redacted secrets are asked again. When the workflow inputs cannot be read,
+// the saved values are replayed unchanged.
+func editReplayInputs(repo, workflow string, saved []string) ([]string, error) {
+	declared, err := fetchWorkflowInputs(repo, workflow)
+	if err != nil || len(declared) == 0 {
+		ui.PrintWarning("Could not read the workflow inputs, replaying the saved values")
+		return promptRedactedInputs(saved)
+	}
+
+	before := inputPairs(saved)
+	edited, err := promptWorkflowInputs(declared, before)
+	if err != nil {
+		return nil, err
+	}
+
+	// Redacted secrets were asked again: there is no saved value to compare
+	after := inputPairs(edited)
+	for _, key := range redactedInputKeys(saved) {
+		delete(after, key)
+	}
+
+	changes := diffInputs(before, after)
+	if len(changes) == 0 {
+		fmt.Println(ui.MutedStyle.Render("  Inputs unchanged"))
+		return edited, nil
+	}
+	fmt.Println(renderInputChanges(changes))
+	return edited, nil
+}
+
+// inputPairs maps key=value inputs, leaving out redacted values so they are
+// prompted for again.
+func inputPairs(values []string) map[string]string {
+	pairs := make(map[string]string, len(values))
+	for _, v := range values {
+		key, value, ok := strings.Cut(v, "=")
+		if ok && key != "" && value != verbose.Redacted {
+			pairs[key] = value
+		}
+	}
+	return pairs
+}
+
+// redactedInputKeys returns the keys of the key=value inputs whose value was
+// redacted in the history.
+func redactedInputKeys(values []string) []string {
+	var keys []string
+	for _, v := range values {
+		if key, value, ok := strings.Cut(v, "="); ok && value == verbose.Redacted {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// checkEditInputsFlags rejects --edit-inputs when the flags skip the history:
+// it edits the inputs of a replayed deployment, given by --last or picked
+// from the history menu.
+func checkEditInputsFlags() error {
+	if !flagEditInputs || flagLast {
+		return nil
+	}
+	if flagRepo != "" || flagWorkflow != "" || flagBranch != "" || flagAutoSelect || flagRerunLast {
+		return errEditInputsNoReplay
+	}
+	return nil
+}
+
+// errEditInputsNoReplay is returned when --edit-inputs has no deployment to replay.
+var errEditInputsNoReplay = errors.New("--edit-inputs edits a replayed deployment: use it with --last or pick a deployment from the history")
+
+// diffInputs lists the inputs added, removed or changed from before to
+// after, sorted by key.
+func diffInputs(before, after map[string]string) []inputChange {
+	keys := make(map[string]bool, len(before)+len(after))
+	for k := range before {
+		keys[k] = true
+	}
+	for k := range after {
+		keys[k] = true
+	}
+
+	var changes []inputChange
+	for k := range keys {
+		if before[k] != after[k] {
+			changes = append(changes, inputChange{Key: k, Old: before[k], New: after[k]})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
+
+// renderInputChanges shows each change as "key: old → new", the new value
+// highlighted. Sensitive values are masked.
+func renderInputChanges(changes []inputChange) string {
+	lines := []string{ui.MutedStyle.Render("  Changed inputs:")}
+	for _, c := range changes {
+		old, value := c.Old, c.New
+		if verbose.IsSensitive(c.Key) {
+			old, value = maskInput(old), maskInput(value)
+		}
+		if old == "" {
+			old = "(unset)"
+		}
+		if value == "" {
+			value = "(unset)"
+		}
+		lines = append(lines, fmt.Sprintf("    %s: %s → %s",
+			c.Key, ui.MutedStyle.Render(old), ui.WarningStyle.Render(value)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func maskInput(value string) string {
+	if value == "" {
+		return ""
+	}
+	return verbose.Redacted
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/20uf/devcli/internal/verbose"
+)
+
+// Test: Edited replay inputs are compared with the saved ones
+func TestDiffInputs(t *testing.T) {
+	before := inputPairs([]string{"environment=staging", "version=1.0", "notes=a=b", "api_token=" + verbose.Redacted})
+	if want := map[string]string{"environment": "staging", "version": "1.0", "notes": "a=b"}; !reflect.DeepEqual(before, want) {
+		t.Fatalf("inputPairs() = %v, want %v", before, want)
+	}
+
+	after := map[string]string{"environment": "prod", "version": "1.0", "dry_run": "true"}
+	got := diffInputs(before, after)
+	want := []inputChange{
+		{Key: "dry_run", New: "true"},
+		{Key: "environment", Old: "staging", New: "prod"},
+		{Key: "notes", Old: "a=b"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diffInputs() = %+v, want %+v", got, want)
+	}
+
+	if got := redactedInputKeys([]string{"environment=staging", "api_token=" + verbose.Redacted}); !reflect.DeepEqual(got, []string{"api_token"}) {
+		t.Errorf("redactedInputKeys() = %v, want [api_token]", got)
+	}
+
+	t.Log("✓ Input changes listed")
+}
+
+// Test: --edit-inputs needs a replayed deployment
+func TestCheckEditInputsFlags(t *testing.T) {
+	defer func() { flagEditInputs, flagLast, flagRepo = false, false, "" }()
+
+	flagEditInputs, flagRepo = true, "owner/api"
+	if err := checkEditInputsFlags(); !errors.Is(err, errEditInputsNoReplay) {
+		t.Errorf("checkEditInputsFlags() with --repo = %v", err)
+	}
+	flagLast = true
+	if err := checkEditInputsFlags(); err != nil {
+		t.Errorf("checkEditInputsFlags() with --last = %v", err)
+	}
+	flagLast, flagRepo = false, ""
+	if err := checkEditInputsFlags(); err != nil {
+		t.Errorf("checkEditInputsFlags() before the history menu = %v", err)
+	}
+
+	t.Log("✓ --edit-inputs without a replay rejected")
+}
+
+// Test: Changes render old → new with secrets masked
+func TestRenderInputChanges(t *testing.T) {
+	out := renderInputChanges([]inputChange{
+		{Key: "environment", Old: "staging", New: "prod"},
+		{Key: "api_token", Old: "old-secret", New: "new-secret"},
+		{Key: "dry_run", New: "true"},
+	})
+
+	for _, want := range []string{"environment:", "staging", "prod", "(unset)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "secret") {
+		t.Errorf("Expected the token to be masked:\n%s", out)
+	}
+
+	t.Log("✓ Input changes rendered")
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+
+	"github.com/20uf/devcli/internal/history"
+	"github.com/20uf/devcli/internal/tracker"
+	"github.com/20uf/devcli/internal/ui"
+	"github.com/20uf/devcli/internal/verbose"
+)
+
+// rerunLast reruns the latest run of the last deployed workflow with
+// `gh run rerun`, keeping the commit and inputs of that run. The run is
+// looked up on the branch of that deployment, or --branch. Unlike --last,
+// no new workflow_dispatch run is triggered.
+func rerunLast(hist *history.Store) error {
+	repo := recentDeployArg(hist, "--repo")
+	if flagRepo != "" {
+		repo = flagRepo
+	}
+	if repo == "" {
+		return fmt.Errorf("no deployment history found")
+	}
+	workflow := flagWorkflow
+	if workflow == "" {
+		workflow = recentDeployArg(hist, "--workflow", "--repo", repo)
+	}
+	if workflow == "" {
+		return fmt.Errorf("no deployment of %s found in the history\n  Use --workflow to choose the workflow", repo)
+	}
+
+	branch := flagBranch
+	if branch == "" {
+		branch = recentDeployArg(hist, "--branch", "--repo", repo, "--workflow", workflow)
+	}
+
+	run, err := latestWorkflowRun(repo, workflow, branch)
+	if err != nil {
+		if branch != "" {
+			return fmt.Errorf("could not find the latest run of %s on %s in %s: %w", workflow, branch, repo, err)
+		}
+		return fmt.Errorf("could not find the latest run of %s in %s: %w", workflow, repo, err)
+	}
+
+	ui.PrintStep("↻", fmt.Sprintf("Rerunning %s", rerunLabel(repo, workflow, run)))
+	out, err := verbose.Cmd(exec.Command("gh", "run", "rerun", run.ID, "--repo", repo)).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to rerun run %s: %s", run.ID, string(out))
+	}
+	ui.PrintSuccess("Run restarted")
+
+	if runs, loadErr := tracker.Load(); loadErr == nil {
+		runs.Upsert(tracker.Run{
+			Repo: repo, Workflow: workflow, Branch: run.Branch, RunID: run.ID,
+			URL: run.URL, Label: rerunLabel(repo, workflow, run), Status: "queued",
+		})
+		runs.Save() //nolint:errcheck
+	}
+	if run.URL != "" {
+		fmt.Println(ui.MutedStyle.Render("  " + run.URL))
+	}
+
+	if flagWatch {
+		return watchRunJobs(repo, run.ID)
+	}
+	if flagWait {
+		return waitForRun(repo, run.ID)
+	}
+	return nil
+}
+
+// rerunLabel names a rerun run as "repo/workflow @ branch (run #N)".
+func rerunLabel(repo, workflow string, run latestRun) string {
+	label := fmt.Sprintf("%s/%s", repo, workflow)
+	if run.Branch != "" {
+		label += " @ " + run.Branch
+	}
+	if run.Number > 0 {
+		return fmt.Sprintf("%s (run #%d)", label, run.Number)
+	}
+	return fmt.Sprintf("%s (run %s)", label, run.ID)
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+// Test: Reruns are labeled with the run number, or the ID when unknown
+func TestRerunLabel(t *testing.T) {
+	tests := []struct {
+		name string
+		run  latestRun
+		want string
+	}{
+		{"With number and branch", latestRun{ID: "123", Number: 42, Branch: "main"}, "owner/api/deploy.yml @ main (run #42)"},
+		{"Without number", latestRun{ID: "123"}, "owner/api/deploy.yml (run 123)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rerunLabel("owner/api", "deploy.yml", tt.run); got != tt.want {
+				t.Errorf("rerunLabel() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Log("✓ Rerun labeled")
+}
+
+// Test: Rerunning without history asks for a deployment first
+func TestRerunLast_NoHistory(t *testing.T) {
+	err := rerunLast(nil)
+	if err == nil || !strings.Contains(err.Error(), "no deployment history") {
+		t.Errorf("rerunLast(nil) error = %v, want no deployment history", err)
+	}
+
+	t.Log("✓ Rerun without history rejected")
+}
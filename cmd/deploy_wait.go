@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/20uf/devcli/internal/deployment/domain"
+	"github.com/20uf/devcli/internal/retry"
+	"github.com/20uf/devcli/internal/telemetry"
+	"github.com/20uf/devcli/internal/tracker"
+	"github.com/20uf/devcli/internal/ui"
+	"github.com/20uf/devcli/internal/verbose"
+)
+
+// waitPollInterval is how often --wait checks the status of the run.
+const waitPollInterval = 10 * time.Second
+
+// waitFetchAttempts is how many status checks in a row may fail before
+// --wait gives up; the delay between them doubles up to waitMaxBackoff.
+const (
+	waitFetchAttempts = 5
+	waitMaxBackoff    = time.Minute
+)
+
+// waitForRun blocks until the run completes, without streaming its jobs like
+// --watch, and fails with the exit code of its conclusion: 1 for a failure,
+// 2 for a cancellation. With --watch-timeout, waiting stops once the
+// duration elapses.
+func waitForRun(repo, runID string) error {
+	ui.PrintStep("◌", fmt.Sprintf("Waiting for run #%s to complete…", runID))
+	_, span := telemetry.Start(rootCmd.Context(), "run-wait")
+	status, err := pollRunUntilDone(runID, repo, flagWatchTimeout, fetchRunStatus, time.Sleep)
+	telemetry.End(span, err)
+
+	if store, loadErr := tracker.Load(); loadErr == nil && status.Status != "" {
+		applyRunStatus(store, runID, status)
+		store.Save() //nolint:errcheck
+	}
+	if err != nil {
+		return err
+	}
+
+	conclusion := domain.RunConclusion(status.Conclusion)
+	if code := conclusion.ExitCode(); code != 0 {
+		ui.PrintError(fmt.Sprintf("Workflow run #%s concluded: %s", runID, conclusion))
+		return &exitError{err: fmt.Errorf("workflow run #%s %s", runID, conclusion), code: code}
+	}
+	ui.PrintSuccess(fmt.Sprintf("Workflow run #%s completed successfully", runID))
+	return nil
+}
+
+// pollRunUntilDone fetches the status of a run every waitPollInterval until
+// it is completed or timeout (when not 0) elapses. A failed check is retried
+// with a growing delay, up to waitFetchAttempts in a row, so a gh or API blip
+// does not end the wait; authentication errors are not retried.
+func pollRunUntilDone(runID, repo string, timeout time.Duration,
+	fetch func(runID, repo string) (remoteRunStatus, error), sleep func(time.Duration)) (remoteRunStatus, error) {
+	var waited time.Duration
+	var last remoteRunStatus
+	failures := 0
+	backoff := waitPollInterval
+	for {
+		delay := waitPollInterval
+		status, err := fetch(runID, repo)
+		switch {
+		case err != nil:
+			failures++
+			if failures >= waitFetchAttempts || retry.IsAuthError(err) {
+				return last, fmt.Errorf("could not read the status of run #%s: %w", runID, err)
+			}
+			verbose.Log("status check %d/%d of run #%s failed, retrying in %s: %s", failures, waitFetchAttempts, runID, backoff, err)
+			delay = backoff
+			backoff = min(backoff*2, waitMaxBackoff)
+		case status.Status == "completed":
+			return status, nil
+		default:
+			last, failures, backoff = status, 0, waitPollInterval
+		}
+
+		if timeout > 0 && waited >= timeout {
+			return last, &exitError{
+				err:  fmt.Errorf("workflow run #%s still %s after %s", runID, last.Status, timeout),
+				code: watchTimeoutExitCode,
+			}
+		}
+		sleep(delay)
+		waited += delay
+	}
+}
+
+// checkWaitFlags rejects --wait with --watch, which already blocks until the
+// run completes.
+func checkWaitFlags() error {
+	if flagWait && flagWatch {
+		return fmt.Errorf("--wait cannot be combined with --watch: --watch already waits for the run and streams its jobs")
+	}
+	return nil
+}
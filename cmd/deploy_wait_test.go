@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// Test: --wait polls until the run completes, then reports its conclusion
+func TestPollRunUntilDone(t *testing.T) {
+	statuses := []remoteRunStatus{
+		{Status: "queued"},
+		{Status: "in_progress"},
+		{Status: "completed", Conclusion: "cancelled"},
+	}
+	var calls int
+	var slept time.Duration
+	fetch := func(runID, repo string) (remoteRunStatus, error) {
+		s := statuses[calls]
+		calls++
+		return s, nil
+	}
+
+	status, err := pollRunUntilDone("42", "owner/api", 0, fetch, func(d time.Duration) { slept += d })
+	if err != nil || status.Conclusion != "cancelled" {
+		t.Fatalf("pollRunUntilDone() = %+v, %v", status, err)
+	}
+	if calls != 3 || slept != 2*waitPollInterval {
+		t.Errorf("Polled %d times, slept %s", calls, slept)
+	}
+
+	t.Log("✓ Run polled until completed")
+}
+
+// Test: --wait gives up with the timeout exit code after --watch-timeout
+func TestPollRunUntilDone_Timeout(t *testing.T) {
+	fetch := func(runID, repo string) (remoteRunStatus, error) {
+		return remoteRunStatus{Status: "in_progress"}, nil
+	}
+
+	_, err := pollRunUntilDone("42", "owner/api", 30*time.Second, fetch, func(time.Duration) {})
+	if code := exitCode(err); code != watchTimeoutExitCode {
+		t.Errorf("exitCode() = %d, want %d (err %v)", code, watchTimeoutExitCode, err)
+	}
+
+	fetchErr := errors.New("gh: not found")
+	_, err = pollRunUntilDone("42", "owner/api", 0, func(string, string) (remoteRunStatus, error) {
+		return remoteRunStatus{}, fetchErr
+	}, func(time.Duration) {})
+	if !errors.Is(err, fetchErr) {
+		t.Errorf("Expected the fetch error, got %v", err)
+	}
+
+	t.Log("✓ Waiting stops on timeout and errors")
+}
+
+// Test: --wait rides out failed status checks but not auth errors
+func TestPollRunUntilDone_Retries(t *testing.T) {
+	var calls int
+	var slept []time.Duration
+	fetch := func(runID, repo string) (remoteRunStatus, error) {
+		calls++
+		if calls < 3 {
+			return remoteRunStatus{}, errors.New("gh: exit status 1")
+		}
+		return remoteRunStatus{Status: "completed", Conclusion: "success"}, nil
+	}
+
+	status, err := pollRunUntilDone("42", "owner/api", 0, fetch, func(d time.Duration) { slept = append(slept, d) })
+	if err != nil || status.Conclusion != "success" {
+		t.Fatalf("pollRunUntilDone() = %+v, %v", status, err)
+	}
+	if len(slept) != 2 || slept[0] != waitPollInterval || slept[1] != 2*waitPollInterval {
+		t.Errorf("Slept %v, want a doubling backoff", slept)
+	}
+
+	calls = 0
+	_, err = pollRunUntilDone("42", "owner/api", 0, func(string, string) (remoteRunStatus, error) {
+		calls++
+		return remoteRunStatus{}, errors.New("HTTP 401: Bad credentials")
+	}, func(time.Duration) {})
+	if err == nil || calls != 1 {
+		t.Errorf("Auth error retried %d times, err %v", calls, err)
+	}
+
+	t.Log("✓ Failed status checks retried")
+}
+
+// Test: --wait and --watch are exclusive
+func TestCheckWaitFlags(t *testing.T) {
+	oldWait, oldWatch := flagWait, flagWatch
+	defer func() { flagWait, flagWatch = oldWait, oldWatch }()
+
+	flagWait, flagWatch = true, true
+	if err := checkWaitFlags(); err == nil {
+		t.Error("Expected --wait and --watch to conflict")
+	}
+	flagWatch = false
+	if err := checkWaitFlags(); err != nil {
+		t.Errorf("checkWaitFlags() = %v, want nil", err)
+	}
+
+	t.Log("✓ Wait flags checked")
+}
@@ -0,0 +1,409 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/20uf/devcli/internal/retry"
+	"github.com/20uf/devcli/internal/ui"
+	"github.com/20uf/devcli/internal/verbose"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/mattn/go-isatty"
+)
+
+// jobsPollInterval is the delay between two refreshes of the jobs view.
+const jobsPollInterval = 3 * time.Second
+
+// watchTimeoutExitCode is returned when --watch-timeout elapses before the
+// run completes.
+const watchTimeoutExitCode = 3
+
+// runStep is a single step of a workflow job.
+type runStep struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+	Number     int    `json:"number"`
+}
+
+// runJob is a workflow job as returned by `gh run view --json jobs`.
+type runJob struct {
+	Name        string    `json:"name"`
+	Status      string    `json:"status"`
+	Conclusion  string    `json:"conclusion"`
+	StartedAt   time.Time `json:"startedAt"`
+	CompletedAt time.Time `json:"completedAt"`
+	Steps       []runStep `json:"steps"`
+}
+
+// Duration returns the elapsed time of the job, or the time since it started if still running.
+func (j runJob) Duration(now time.Time) time.Duration {
+	if j.StartedAt.IsZero() {
+		return 0
+	}
+	end := j.CompletedAt
+	if end.IsZero() || end.Before(j.StartedAt) {
+		end = now
+	}
+	return end.Sub(j.StartedAt).Round(time.Second)
+}
+
+// runJobs is the run state polled by the jobs view.
+type runJobs struct {
+	Status     string   `json:"status"`
+	Conclusion string   `json:"conclusion"`
+	Jobs       []runJob `json:"jobs"`
+}
+
+// fetchRunJobs retrieves the run status and its jobs with their steps.
+func fetchRunJobs(repo, runID string) (runJobs, error) {
+	out, err := verbose.Cmd(exec.Command("gh", "run", "view", runID,
+		"--repo", repo,
+		"--json", "status,conclusion,jobs")).Output()
+	if err != nil {
+		return runJobs{}, fmt.Errorf("failed to fetch jobs for run #%s: %w", runID, err)
+	}
+
+	var run runJobs
+	if err := json.Unmarshal(out, &run); err != nil {
+		return runJobs{}, fmt.Errorf("failed to parse jobs for run #%s: %w", runID, err)
+	}
+	return run, nil
+}
+
+type jobsMsg struct {
+	run runJobs
+	err error
+}
+
+type jobsTickMsg struct{}
+
+type jobsTimeoutMsg struct{}
+
+// jobsModel is a bubbletea model rendering a live table of the jobs of a run.
+type jobsModel struct {
+	repo     string
+	runID    string
+	fetch    func(repo, runID string) (runJobs, error)
+	run      runJobs
+	cursor   int
+	expanded map[string]bool
+	err      error
+	detached bool
+	timeout  time.Duration // stop watching after this long, 0 for never
+	timedOut bool
+	failures int   // failed refreshes in a row
+	lastErr  error // error of the last refresh when it failed
+}
+
+func newJobsModel(repo, runID string) jobsModel {
+	return jobsModel{
+		repo:     repo,
+		runID:    runID,
+		fetch:    fetchRunJobs,
+		expanded: map[string]bool{},
+	}
+}
+
+func (m jobsModel) poll() tea.Cmd {
+	return func() tea.Msg {
+		run, err := m.fetch(m.repo, m.runID)
+		return jobsMsg{run: run, err: err}
+	}
+}
+
+func (m jobsModel) Init() tea.Cmd {
+	if m.timeout > 0 {
+		return tea.Batch(m.poll(), tea.Tick(m.timeout, func(time.Time) tea.Msg { return jobsTimeoutMsg{} }))
+	}
+	return m.poll()
+}
+
+func (m jobsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			m.detached = true
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.run.Jobs)-1 {
+				m.cursor++
+			}
+		case "enter", " ":
+			if m.cursor < len(m.run.Jobs) {
+				name := m.run.Jobs[m.cursor].Name
+				m.expanded[name] = !m.expanded[name]
+			}
+		}
+		return m, nil
+
+	case jobsMsg:
+		if msg.err != nil {
+			m.failures++
+			if m.failures >= waitFetchAttempts || retry.IsAuthError(msg.err) {
+				m.err = msg.err
+				return m, tea.Quit
+			}
+			m.lastErr = msg.err
+			return m, tea.Tick(jobsRetryDelay(m.failures), func(time.Time) tea.Msg { return jobsTickMsg{} })
+		}
+		m.failures, m.lastErr = 0, nil
+		m.run = msg.run
+		if m.cursor >= len(m.run.Jobs) && len(m.run.Jobs) > 0 {
+			m.cursor = len(m.run.Jobs) - 1
+		}
+		if m.run.Status == "completed" {
+			return m, tea.Quit
+		}
+		return m, tea.Tick(jobsPollInterval, func(time.Time) tea.Msg { return jobsTickMsg{} })
+
+	case jobsTickMsg:
+		return m, m.poll()
+
+	case jobsTimeoutMsg:
+		if m.run.Status != "completed" {
+			m.timedOut = true
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+func (m jobsModel) View() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s %s\n\n", ui.TitleStyle.Render("◉"), fmt.Sprintf("Run #%s on %s", m.runID, m.repo))
+	if m.lastErr != nil {
+		b.WriteString(ui.WarningStyle.Render(fmt.Sprintf("  Refresh failed, retrying: %s", m.lastErr)) + "\n\n")
+	}
+
+	if len(m.run.Jobs) == 0 {
+		b.WriteString(ui.MutedStyle.Render("  Waiting for jobs...") + "\n")
+		return b.String()
+	}
+
+	completed := 0
+	for _, j := range m.run.Jobs {
+		if j.Status == "completed" {
+			completed++
+		}
+	}
+	b.WriteString("  " + ui.ProgressBar("jobs", completed, len(m.run.Jobs)).View() + "\n\n")
+
+	now := time.Now()
+	rows := make([][]string, len(m.run.Jobs))
+	for i, j := range m.run.Jobs {
+		marker := "▸"
+		if m.expanded[j.Name] {
+			marker = "▾"
+		}
+		rows[i] = []string{marker + " " + j.Name, j.Status, j.Conclusion, formatJobDuration(j.Duration(now))}
+	}
+
+	t := table.New().
+		Border(lipgloss.RoundedBorder()).
+		BorderStyle(ui.MutedStyle).
+		Headers("JOB", "STATUS", "CONCLUSION", "DURATION").
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			style := lipgloss.NewStyle().Padding(0, 1)
+			if row == table.HeaderRow {
+				return style.Bold(true).Foreground(ui.Text)
+			}
+			if col == 2 {
+				style = style.Foreground(conclusionColor(m.run.Jobs[row].Conclusion))
+			}
+			if row == m.cursor {
+				style = style.Bold(true).Foreground(ui.Accent)
+			}
+			return style
+		})
+	b.WriteString(t.Render() + "\n")
+
+	for _, j := range m.run.Jobs {
+		if !m.expanded[j.Name] {
+			continue
+		}
+		b.WriteString("\n" + lipgloss.NewStyle().Foreground(ui.Text).Bold(true).Render("  "+j.Name) + "\n")
+		for _, s := range j.Steps {
+			fmt.Fprintf(&b, "    %s %s\n", stepIcon(s), s.Name)
+		}
+	}
+
+	b.WriteString("\n" + ui.MutedStyle.Render("  ↑/↓ move • enter expand steps • q detach") + "\n")
+	return b.String()
+}
+
+// jobsRetryDelay is the delay before the next refresh after failures failed
+// refreshes in a row, doubling from jobsPollInterval up to waitMaxBackoff.
+func jobsRetryDelay(failures int) time.Duration {
+	delay := jobsPollInterval
+	for i := 1; i < failures && delay < waitMaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, waitMaxBackoff)
+}
+
+// conclusionColor maps a job conclusion to the theme color used to render it.
+func conclusionColor(conclusion string) lipgloss.Color {
+	switch conclusion {
+	case "success":
+		return ui.Success
+	case "failure", "timed_out", "startup_failure":
+		return ui.Error
+	case "cancelled", "skipped":
+		return ui.Warning
+	default:
+		return ui.Muted
+	}
+}
+
+// stepIcon returns a styled status icon for a step.
+func stepIcon(s runStep) string {
+	switch {
+	case s.Status != "completed":
+		if s.Status == "in_progress" {
+			return ui.TitleStyle.Render("◉")
+		}
+		return ui.MutedStyle.Render("○")
+	case s.Conclusion == "success":
+		return ui.SuccessStyle.Render("✓")
+	case s.Conclusion == "skipped":
+		return ui.MutedStyle.Render("⊘")
+	default:
+		return ui.ErrorStyle.Render("✗")
+	}
+}
+
+// formatJobDuration renders a duration as "1m05s", or "-" if the job has not started.
+func formatJobDuration(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+}
+
+// watchRunJobs displays the live jobs view until the run completes and returns
+// an error if the run did not succeed. With --watch-timeout, watching stops
+// once the duration elapses and an error reports the last known status.
+//
+// When stdout is not a terminal, as in CI, job changes are printed as plain
+// lines instead of the live view.
+func watchRunJobs(repo, runID string) error {
+	model := newJobsModel(repo, runID)
+	model.timeout = flagWatchTimeout
+
+	var m jobsModel
+	if fd := os.Stdout.Fd(); isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd) {
+		final, err := tea.NewProgram(model).Run()
+		if err != nil {
+			return fmt.Errorf("failed to display jobs view: %w", err)
+		}
+		m = final.(jobsModel)
+	} else {
+		m = watchJobsPlain(model, os.Stdout, time.Sleep)
+	}
+
+	if m.err != nil {
+		return m.err
+	}
+	if m.detached {
+		ui.PrintWarning(fmt.Sprintf("Stopped watching run #%s", runID))
+		fmt.Println(ui.MutedStyle.Render(fmt.Sprintf("  Resume with: gh run watch %s --repo %s", runID, repo)))
+		return nil
+	}
+	if m.timedOut {
+		return watchTimeoutError(m, repo)
+	}
+
+	if m.run.Conclusion != "success" {
+		ui.PrintError(fmt.Sprintf("Workflow run #%s concluded: %s", runID, m.run.Conclusion))
+		fmt.Printf("\nView full logs: gh run view %s --repo %s --log\n", runID, repo)
+		return fmt.Errorf("workflow run #%s %s", runID, m.run.Conclusion)
+	}
+
+	ui.PrintSuccess(fmt.Sprintf("Workflow run #%s completed successfully", runID))
+	return nil
+}
+
+// watchJobsPlain polls the run like the live view does, printing a line for
+// each job whose status changes, until the run completes, the refreshes keep
+// failing or the timeout of m elapses.
+func watchJobsPlain(m jobsModel, w io.Writer, sleep func(time.Duration)) jobsModel {
+	fmt.Fprintf(w, "Watching run #%s on %s\n", m.runID, m.repo) //nolint:errcheck
+	seen := make(map[string]string)
+	var waited time.Duration
+	for {
+		delay := jobsPollInterval
+		run, err := m.fetch(m.repo, m.runID)
+		if err != nil {
+			m.failures++
+			if m.failures >= waitFetchAttempts || retry.IsAuthError(err) {
+				m.err = err
+				return m
+			}
+			delay = jobsRetryDelay(m.failures)
+			fmt.Fprintf(w, "Refresh failed, retrying in %s: %s\n", delay, err) //nolint:errcheck
+		} else {
+			m.failures = 0
+			m.run = run
+			printJobChanges(w, run, seen, time.Now())
+			if run.Status == "completed" {
+				return m
+			}
+		}
+
+		if m.timeout > 0 && waited >= m.timeout {
+			m.timedOut = true
+			return m
+		}
+		sleep(delay)
+		waited += delay
+	}
+}
+
+// printJobChanges prints the jobs whose status or conclusion differs from
+// the one recorded in seen, then records it.
+func printJobChanges(w io.Writer, run runJobs, seen map[string]string, now time.Time) {
+	for _, j := range run.Jobs {
+		state := j.Status
+		if j.Conclusion != "" {
+			state = fmt.Sprintf("%s: %s (%s)", j.Status, j.Conclusion, formatJobDuration(j.Duration(now)))
+		}
+		if seen[j.Name] == state {
+			continue
+		}
+		seen[j.Name] = state
+		fmt.Fprintf(w, "  %s  %s\n", j.Name, state) //nolint:errcheck
+	}
+}
+
+// watchTimeoutError reports a run still going when --watch-timeout elapsed.
+func watchTimeoutError(m jobsModel, repo string) error {
+	status := m.run.Status
+	if status == "" {
+		status = "unknown"
+	}
+	ui.PrintWarning(fmt.Sprintf("Stopped watching run #%s after %s (status: %s)", m.runID, m.timeout, status))
+	fmt.Println(ui.MutedStyle.Render(fmt.Sprintf("  Resume with: gh run watch %s --repo %s", m.runID, repo)))
+	return &exitError{
+		err:  fmt.Errorf("workflow run #%s still %s after %s", m.runID, status, m.timeout),
+		code: watchTimeoutExitCode,
+	}
+}
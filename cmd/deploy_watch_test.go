@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Test: Jobs view keeps polling while the run is in progress and quits once completed
+func TestJobsModel_PollsUntilCompleted(t *testing.T) {
+	m := newJobsModel("owner/repo", "42")
+
+	running := runJobs{Status: "in_progress", Jobs: []runJob{{Name: "build", Status: "in_progress"}}}
+	next, cmd := m.Update(jobsMsg{run: running})
+	if cmd == nil {
+		t.Fatalf("Expected a tick while the run is in progress")
+	}
+
+	done := runJobs{Status: "completed", Conclusion: "failure", Jobs: []runJob{{Name: "build", Status: "completed", Conclusion: "failure"}}}
+	next, cmd = next.Update(jobsMsg{run: done})
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Errorf("Expected quit once the run is completed")
+	}
+	if next.(jobsModel).run.Conclusion != "failure" {
+		t.Errorf("Conclusion not propagated")
+	}
+
+	t.Log("✓ Jobs view polls until the run completes")
+}
+
+// Test: A failed refresh is retried instead of ending the watch
+func TestJobsModel_RetriesFailedPoll(t *testing.T) {
+	m := newJobsModel("owner/repo", "42")
+
+	next, cmd := m.Update(jobsMsg{err: errors.New("gh: exit status 1")})
+	if cmd == nil || next.(jobsModel).err != nil || next.(jobsModel).failures != 1 {
+		t.Fatalf("Expected a retry after a failed refresh")
+	}
+
+	for i := 1; i < waitFetchAttempts; i++ {
+		next, _ = next.Update(jobsMsg{err: errors.New("gh: exit status 1")})
+	}
+	if next.(jobsModel).err == nil {
+		t.Errorf("Expected the watch to stop after %d failures", waitFetchAttempts)
+	}
+	if got := jobsRetryDelay(2); got != 2*jobsPollInterval {
+		t.Errorf("jobsRetryDelay(2) = %s", got)
+	}
+
+	t.Log("✓ Failed refreshes retried")
+}
+
+// Test: Without a terminal, job changes are printed as plain lines
+func TestWatchJobsPlain(t *testing.T) {
+	runs := []runJobs{
+		{Status: "in_progress", Jobs: []runJob{{Name: "build", Status: "in_progress"}}},
+		{Status: "in_progress", Jobs: []runJob{{Name: "build", Status: "in_progress"}}},
+		{Status: "completed", Conclusion: "success", Jobs: []runJob{{Name: "build", Status: "completed", Conclusion: "success"}}},
+	}
+	calls := 0
+	m := newJobsModel("owner/repo", "42")
+	m.fetch = func(repo, runID string) (runJobs, error) {
+		if calls == 1 {
+			calls++
+			return runJobs{}, errors.New("HTTP 502")
+		}
+		run := runs[min(calls, len(runs)-1)]
+		calls++
+		return run, nil
+	}
+
+	var out strings.Builder
+	final := watchJobsPlain(m, &out, func(time.Duration) {})
+	if final.err != nil || final.run.Conclusion != "success" {
+		t.Fatalf("watchJobsPlain() = %+v", final)
+	}
+	text := out.String()
+	if strings.Contains(text, "\x1b[") {
+		t.Errorf("Plain output holds escape sequences: %q", text)
+	}
+	if strings.Count(text, "build  in_progress") != 1 || !strings.Contains(text, "build  completed: success") {
+		t.Errorf("Unexpected output:\n%s", text)
+	}
+
+	t.Log("✓ Plain watch output")
+}
+
+// Test: --watch-timeout stops a running watch and reports the last status
+func TestJobsModel_WatchTimeout(t *testing.T) {
+	m := newJobsModel("owner/repo", "42")
+	m.timeout = 20 * time.Minute
+
+	running := runJobs{Status: "in_progress", Jobs: []runJob{{Name: "build", Status: "in_progress"}}}
+	next, _ := m.Update(jobsMsg{run: running})
+	next, cmd := next.Update(jobsTimeoutMsg{})
+	if cmd == nil {
+		t.Fatalf("Expected quit when the timeout elapses")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Errorf("Expected quit when the timeout elapses")
+	}
+
+	jm := next.(jobsModel)
+	if !jm.timedOut {
+		t.Fatalf("Expected the model to be marked as timed out")
+	}
+
+	err := watchTimeoutError(jm, "owner/repo")
+	if exitCode(err) != watchTimeoutExitCode {
+		t.Errorf("exitCode() = %d, want %d", exitCode(err), watchTimeoutExitCode)
+	}
+	if !strings.Contains(err.Error(), "still in_progress") {
+		t.Errorf("Error should report the last known status: %v", err)
+	}
+
+	t.Log("✓ Watch stops at the timeout with a non-zero exit code")
+}
+
+// Test: A timeout arriving after completion keeps the terminal state
+func TestJobsModel_WatchTimeoutAfterCompletion(t *testing.T) {
+	m := newJobsModel("owner/repo", "42")
+	m.timeout = time.Minute
+
+	done := runJobs{Status: "completed", Conclusion: "success"}
+	next, _ := m.Update(jobsMsg{run: done})
+	next, _ = next.Update(jobsTimeoutMsg{})
+
+	jm := next.(jobsModel)
+	if jm.timedOut || jm.run.Conclusion != "success" {
+		t.Errorf("Completed run should keep its conclusion: timedOut=%v conclusion=%s", jm.timedOut, jm.run.Conclusion)
+	}
+
+	t.Log("✓ Terminal state reported when the run completes in time")
+}
+
+// Test: Enter toggles the step list of the selected job
+func TestJobsModel_ExpandJob(t *testing.T) {
+	m := newJobsModel("owner/repo", "42")
+	m.run = runJobs{Jobs: []runJob{{Name: "build"}, {Name: "deploy"}}}
+
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	next, _ = next.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	jm := next.(jobsModel)
+	if !jm.expanded["deploy"] || jm.expanded["build"] {
+		t.Errorf("Expected only deploy to be expanded: %v", jm.expanded)
+	}
+
+	next, _ = jm.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if next.(jobsModel).expanded["deploy"] {
+		t.Errorf("Second enter should collapse the job")
+	}
+
+	t.Log("✓ Jobs expand and collapse on enter")
+}
+
+// Test: Job durations are formatted compactly
+func TestFormatJobDuration(t *testing.T) {
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		job  runJob
+		want string
+	}{
+		{runJob{}, "-"},
+		{runJob{StartedAt: start, CompletedAt: start.Add(42 * time.Second)}, "42s"},
+		{runJob{StartedAt: start, CompletedAt: start.Add(65 * time.Second)}, "1m05s"},
+	}
+
+	for _, tt := range tests {
+		if got := formatJobDuration(tt.job.Duration(start)); got != tt.want {
+			t.Errorf("Got %s, want %s", got, tt.want)
+		}
+	}
+
+	t.Log("✓ Job durations formatted")
+}
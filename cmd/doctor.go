@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	awsutil "github.com/20uf/devcli/internal/aws"
+	"github.com/20uf/devcli/internal/ui"
+	"github.com/20uf/devcli/internal/verbose"
+	"github.com/spf13/cobra"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose the tools and configuration devcli relies on",
+	Long: `Check that the CLIs and configuration used by deploy and connect are in place.
+
+Checks gh (installed and authenticated), aws and session-manager-plugin,
+the AWS config file and its SSO profiles. Nothing is installed or changed.`,
+	Args: cobra.NoArgs,
+	RunE: runDoctor,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
+
+// doctorCheck is one line of the doctor checklist.
+type doctorCheck struct {
+	Name   string
+	OK     bool
+	Detail string
+	Hint   string // how to fix a failed check
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	checks := []doctorCheck{checkGitHubCLI(exec.LookPath, ghAuthStatus)}
+	checks = append(checks, checkAWSDependencies(awsutil.RequiredDependencies(), awsutil.MissingDependencies())...)
+	checks = append(checks, checkAWSConfig(awsutil.ConfigPath, awsutil.ListSSOProfiles)...)
+
+	printDoctorChecks(checks)
+
+	failed := 0
+	for _, c := range checks {
+		if !c.OK {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d check(s) failed", failed)
+	}
+	ui.PrintSuccess("Everything looks good")
+	return nil
+}
+
+// ghAuthStatus returns an error when gh has no valid login.
+func ghAuthStatus() error {
+	out, err := verbose.Cmd(exec.Command("gh", "auth", "status")).CombinedOutput()
+	if err != nil {
+		return errors.New(strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// checkGitHubCLI checks that gh is installed and logged in.
+func checkGitHubCLI(lookPath func(string) (string, error), authStatus func() error) doctorCheck {
+	check := doctorCheck{Name: "GitHub CLI (gh)"}
+	if _, err := lookPath("gh"); err != nil {
+		check.Detail = "not found in PATH"
+		check.Hint = "Install: https://cli.github.com/"
+		return check
+	}
+	if err := authStatus(); err != nil {
+		check.Detail = "not authenticated"
+		check.Hint = "Run: gh auth login"
+		return check
+	}
+	check.OK = true
+	check.Detail = "installed and authenticated"
+	return check
+}
+
+// checkAWSDependencies reports every required AWS tool, using the install
+// hints of the missing ones.
+func checkAWSDependencies(required, missing []awsutil.Dependency) []doctorCheck {
+	absent := make(map[string]awsutil.Dependency, len(missing))
+	for _, dep := range missing {
+		absent[dep.Name] = dep
+	}
+
+	checks := make([]doctorCheck, 0, len(required))
+	for _, dep := range required {
+		check := doctorCheck{Name: dep.Name, OK: true, Detail: "installed"}
+		if d, ok := absent[dep.Name]; ok {
+			check.OK = false
+			check.Detail = "not found in PATH"
+			check.Hint = "Install: " + d.InstallHint()
+			if d.InstallHint() != d.InstallURL {
+				check.Hint += "\n  Docs: " + d.InstallURL
+			}
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// checkAWSConfig checks that the AWS config file exists and declares at
+// least one SSO profile.
+func checkAWSConfig(configPath func() (string, error), ssoProfiles func() ([]string, error)) []doctorCheck {
+	file := doctorCheck{Name: "AWS config file"}
+	path, err := configPath()
+	if err != nil {
+		file.Detail = err.Error()
+		return []doctorCheck{file}
+	}
+	if _, err := os.Stat(path); err != nil {
+		file.Detail = path + " not found"
+		file.Hint = "Run: aws configure sso"
+		return []doctorCheck{file}
+	}
+	file.OK = true
+	file.Detail = path
+
+	sso := doctorCheck{Name: "AWS SSO profiles"}
+	profiles, err := ssoProfiles()
+	switch {
+	case err != nil:
+		sso.Detail = err.Error()
+		sso.Hint = "Check the syntax of " + path
+	case len(profiles) == 0:
+		sso.Detail = "no profile uses SSO"
+		sso.Hint = "Run: aws configure sso"
+	default:
+		sso.OK = true
+		sso.Detail = strings.Join(profiles, ", ")
+	}
+	return []doctorCheck{file, sso}
+}
+
+// printDoctorChecks renders the checklist with ✓/✗ marks and fix hints.
+func printDoctorChecks(checks []doctorCheck) {
+	for _, c := range checks {
+		mark := ui.SuccessStyle.Render("✓")
+		if !c.OK {
+			mark = ui.ErrorStyle.Render("✗")
+		}
+		fmt.Printf("  %s %s %s\n", mark, c.Name, ui.MutedStyle.Render(c.Detail))
+		if !c.OK && c.Hint != "" {
+			for _, line := range strings.Split(c.Hint, "\n") {
+				fmt.Println(ui.MutedStyle.Render("      " + strings.TrimSpace(line)))
+			}
+		}
+	}
+	fmt.Println()
+}
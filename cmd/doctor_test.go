@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	awsutil "github.com/20uf/devcli/internal/aws"
+)
+
+// Test: gh is reported missing, unauthenticated or ready with a fix hint
+func TestCheckGitHubCLI(t *testing.T) {
+	found := func(string) (string, error) { return "/usr/bin/gh", nil }
+	missing := func(string) (string, error) { return "", errors.New("not found") }
+	loggedIn := func() error { return nil }
+	loggedOut := func() error { return errors.New("not logged in") }
+
+	tests := []struct {
+		name     string
+		lookPath func(string) (string, error)
+		auth     func() error
+		wantOK   bool
+		wantHint string
+	}{
+		{"Missing", missing, loggedIn, false, "https://cli.github.com/"},
+		{"Unauthenticated", found, loggedOut, false, "gh auth login"},
+		{"Ready", found, loggedIn, true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := checkGitHubCLI(tt.lookPath, tt.auth)
+			if got.OK != tt.wantOK || !strings.Contains(got.Hint, tt.wantHint) {
+				t.Errorf("checkGitHubCLI() = %+v", got)
+			}
+		})
+	}
+
+	t.Log("✓ gh checked for installation and login")
+}
+
+// Test: missing AWS tools fail with the install hint of their Dependency
+func TestCheckAWSDependencies(t *testing.T) {
+	required := awsutil.RequiredDependencies()
+	checks := checkAWSDependencies(required, required[1:])
+
+	if len(checks) != len(required) {
+		t.Fatalf("Expected one check per dependency, got %d", len(checks))
+	}
+	if !checks[0].OK {
+		t.Errorf("%s should be reported installed", checks[0].Name)
+	}
+	if checks[1].OK || !strings.Contains(checks[1].Hint, required[1].InstallURL) {
+		t.Errorf("%s should fail with its docs URL: %+v", checks[1].Name, checks[1])
+	}
+
+	t.Log("✓ AWS tools checked without installing them")
+}
+
+// Test: the AWS config file must exist and declare an SSO profile
+func TestCheckAWSConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config")
+	configPath := func() (string, error) { return path, nil }
+	profiles := func(names ...string) func() ([]string, error) {
+		return func() ([]string, error) { return names, nil }
+	}
+
+	checks := checkAWSConfig(configPath, profiles())
+	if len(checks) != 1 || checks[0].OK || !strings.Contains(checks[0].Hint, "aws configure sso") {
+		t.Fatalf("Missing file should fail alone: %+v", checks)
+	}
+
+	if err := os.WriteFile(path, []byte("[default]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	checks = checkAWSConfig(configPath, profiles())
+	if len(checks) != 2 || !checks[0].OK || checks[1].OK {
+		t.Errorf("Config without SSO profile should fail the profile check: %+v", checks)
+	}
+
+	checks = checkAWSConfig(configPath, profiles("dev", "prod"))
+	if !checks[1].OK || checks[1].Detail != "dev, prod" {
+		t.Errorf("SSO profiles should be listed: %+v", checks[1])
+	}
+
+	t.Log("✓ AWS config and SSO profiles checked")
+}
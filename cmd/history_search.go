@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/20uf/devcli/internal/history"
+	"github.com/20uf/devcli/internal/ui"
+)
+
+// historyMenuSize is the number of recent entries listed before offering search.
+const historyMenuSize = 10
+
+// optionSearchHistory is appended to history menus that were truncated.
+const optionSearchHistory = "⌕ Search history"
+
+// searchHistory prompts for a query and lets the user pick a matching entry.
+// Returns nil when nothing matches.
+func searchHistory(hist *history.Store, command string) (*history.Entry, error) {
+	query, err := ui.Input("Search history", "repo, branch, cluster...")
+	if err != nil {
+		return nil, err
+	}
+
+	results := hist.Search(command, query, 20)
+	if len(results) == 0 {
+		ui.PrintWarning(fmt.Sprintf("No history entry matches %q", query))
+		return nil, nil
+	}
+
+	options := make([]ui.SelectOption, len(results))
+	for i, e := range results {
+		options[i] = ui.SelectOption{
+			Display: e.DisplayLabel(),
+			Value:   strconv.Itoa(i),
+		}
+	}
+
+	selected, err := ui.SelectWithOptions(fmt.Sprintf("%d match(es)", len(results)), options)
+	if err != nil {
+		return nil, err
+	}
+
+	i, err := strconv.Atoi(selected)
+	if err != nil || i < 0 || i >= len(results) {
+		return nil, nil
+	}
+	return &results[i], nil
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/20uf/devcli/internal/deployment/infra"
+	"github.com/20uf/devcli/internal/project"
+	"github.com/20uf/devcli/internal/ui"
+	"github.com/20uf/devcli/internal/verbose"
+	"github.com/spf13/cobra"
+)
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Scaffold a .devcli.yaml with the project's deploy defaults",
+	Long: `Create a .devcli.yaml in the current repository.
+
+The wizard asks for the default deploy workflow, the default branch and static
+input values. Once the file is committed, devcli deploy run inside the
+repository starts from these defaults; flags still take precedence.`,
+	Args: cobra.NoArgs,
+	RunE: runInit,
+}
+
+func init() {
+	rootCmd.AddCommand(initCmd)
+}
+
+func runInit(cmd *cobra.Command, args []string) error {
+	if _, err := verbose.LookPath("gh"); err != nil {
+		return fmt.Errorf("GitHub CLI (gh) is required.\n  Install: https://cli.github.com/")
+	}
+
+	repo := currentGitHubRepo()
+	if repo == "" {
+		return fmt.Errorf("no GitHub repository found in the current directory.\n  Run devcli init from a clone of the repository")
+	}
+
+	file, err := project.Load(".")
+	if err != nil {
+		return err
+	}
+	if file.HasDeployDefaults() {
+		overwrite, err := ui.Confirm(fmt.Sprintf("%s already sets deploy defaults. Replace them?", project.FileName))
+		if err != nil || !overwrite {
+			return err
+		}
+	}
+
+	ui.PrintStep("◆", fmt.Sprintf("Repository: %s", repo))
+
+	workflow, _, err := selectDeployWorkflow(repo, file.Deploy.Workflow)
+	if err != nil {
+		return err
+	}
+
+	suggested := repoDefaultBranch(repo)
+	branch, err := ui.Input("Default branch", suggested)
+	if err != nil {
+		return err
+	}
+	if branch == "" {
+		branch = suggested
+	}
+
+	var known map[string]workflowInput
+	if inputs, err := fetchWorkflowInputs(repo, workflow); err == nil {
+		known = inputs
+	}
+	inputs, err := promptStaticInputs(known)
+	if err != nil {
+		return err
+	}
+
+	file.Deploy = project.Deploy{Workflow: workflow, Branch: branch, Inputs: inputs}
+	if err := file.Save(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", project.FileName, err)
+	}
+
+	ui.PrintSuccess(fmt.Sprintf("Wrote %s", file.Path()))
+	fmt.Println(ui.MutedStyle.Render("  Commit it so devcli deploy uses these defaults for everyone"))
+	return nil
+}
+
+// promptStaticInputs asks for key=value input defaults until an empty entry.
+// Keys unknown to the workflow are accepted with a warning.
+func promptStaticInputs(known map[string]workflowInput) (map[string]string, error) {
+	if len(known) > 0 {
+		fmt.Println(ui.MutedStyle.Render("  Workflow inputs: " + strings.Join(infra.OrderedInputKeys(known), ", ")))
+	}
+
+	inputs := make(map[string]string)
+	for {
+		entry, err := ui.Input("Static input default (key=value, empty to finish)", "environment=prod")
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(entry) == "" {
+			break
+		}
+
+		key, value, err := parseStaticInput(entry)
+		if err != nil {
+			ui.PrintWarning(err.Error())
+			continue
+		}
+		if _, ok := known[key]; known != nil && !ok {
+			ui.PrintWarning(fmt.Sprintf("%s is not an input of the workflow", key))
+		}
+		inputs[key] = value
+	}
+
+	if len(inputs) == 0 {
+		return nil, nil
+	}
+	return inputs, nil
+}
+
+// parseStaticInput splits a key=value entry.
+func parseStaticInput(entry string) (string, string, error) {
+	key, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" {
+		return "", "", fmt.Errorf("invalid input %q (expected key=value)", entry)
+	}
+	return key, strings.TrimSpace(value), nil
+}
+
+// projectDefaults are the deploy defaults of a .devcli.yaml. They only
+// preselect the answers of the interactive steps, so the history menu and the
+// prompts still show up, and only for the repository the file belongs to.
+type projectDefaults struct {
+	Repo     string
+	Workflow string
+	Branch   string
+	Inputs   map[string]string
+}
+
+// loadProjectDefaults reads the deploy defaults of the .devcli.yaml in dir,
+// the current repository being the one they target. An invalid file is
+// ignored with a warning rather than failing the deploy.
+func loadProjectDefaults(dir string) projectDefaults {
+	return readProjectDefaults(dir, currentGitHubRepo)
+}
+
+// readProjectDefaults reads the deploy defaults of the .devcli.yaml in dir
+// for the repository returned by currentRepo, only called when the file sets
+// defaults.
+func readProjectDefaults(dir string, currentRepo func() string) projectDefaults {
+	file, err := project.Load(dir)
+	if err != nil {
+		ui.PrintWarning(fmt.Sprintf("Ignoring %s", err))
+		return projectDefaults{}
+	}
+	if !file.HasDeployDefaults() {
+		return projectDefaults{}
+	}
+
+	repo := currentRepo()
+	if repo == "" {
+		return projectDefaults{}
+	}
+	return projectDefaults{
+		Repo:     repo,
+		Workflow: file.Deploy.Workflow,
+		Branch:   file.Deploy.Branch,
+		Inputs:   file.Deploy.Inputs,
+	}
+}
+
+// forRepo returns the defaults when they target repo, none otherwise.
+func (d projectDefaults) forRepo(repo string) projectDefaults {
+	if d.Repo == "" || !strings.EqualFold(d.Repo, repo) {
+		return projectDefaults{}
+	}
+	return d
+}
+
+// owner returns the owner of the repository of the defaults.
+func (d projectDefaults) owner() string {
+	owner, _, _ := strings.Cut(d.Repo, "/")
+	return owner
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/20uf/devcli/internal/project"
+)
+
+// Test: static input defaults are entered as key=value
+func TestParseStaticInput(t *testing.T) {
+	tests := []struct {
+		entry     string
+		wantKey   string
+		wantValue string
+		wantErr   bool
+	}{
+		{"environment=prod", "environment", "prod", false},
+		{" notes = a=b ", "notes", "a=b", false},
+		{"dry_run=", "dry_run", "", false},
+		{"environment", "", "", true},
+		{"=prod", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.entry, func(t *testing.T) {
+			key, value, err := parseStaticInput(tt.entry)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseStaticInput(%q) error = %v, wantErr %v", tt.entry, err, tt.wantErr)
+			}
+			if key != tt.wantKey || value != tt.wantValue {
+				t.Errorf("parseStaticInput(%q) = %q, %q, want %q, %q", tt.entry, key, value, tt.wantKey, tt.wantValue)
+			}
+		})
+	}
+
+	t.Log("✓ Static input entries parsed")
+}
+
+// Test: .devcli.yaml deploy defaults only apply to the current repository
+func TestLoadProjectDefaults(t *testing.T) {
+	dir := t.TempDir()
+	file, err := project.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Deploy = project.Deploy{
+		Workflow: "deploy.yml",
+		Branch:   "main",
+		Inputs:   map[string]string{"environment": "staging"},
+	}
+	if err := file.Save(); err != nil {
+		t.Fatal(err)
+	}
+	current := func() string { return "owner/api" }
+
+	defaults := readProjectDefaults(dir, current)
+	if defaults.owner() != "owner" {
+		t.Errorf("owner() = %q, want owner", defaults.owner())
+	}
+	got := defaults.forRepo("Owner/API")
+	if got.Workflow != "deploy.yml" || got.Branch != "main" {
+		t.Errorf("forRepo() = %+v", got)
+	}
+	if !reflect.DeepEqual(got.Inputs, map[string]string{"environment": "staging"}) {
+		t.Errorf("inputs = %v", got.Inputs)
+	}
+
+	// Deploying another repository ignores the file
+	if other := defaults.forRepo("other/repo"); other.Workflow != "" || other.Inputs != nil {
+		t.Errorf("defaults applied to another repo: %+v", other)
+	}
+
+	// No file, no defaults
+	if empty := readProjectDefaults(t.TempDir(), current); empty.Repo != "" {
+		t.Errorf("empty dir: %+v", empty)
+	}
+
+	// An invalid file is ignored
+	invalid := t.TempDir()
+	if err := os.WriteFile(filepath.Join(invalid, project.FileName), []byte("deploy: [\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if broken := readProjectDefaults(invalid, current); broken.Repo != "" {
+		t.Errorf("invalid file: %+v", broken)
+	}
+
+	t.Log("✓ Project defaults loaded")
+}
+
+// Test: the workflow of .devcli.yaml is listed first
+func TestPreferWorkflow(t *testing.T) {
+	workflows := []ghWorkflow{{Path: ".github/workflows/ci.yml"}, {Path: ".github/workflows/deploy.yml"}, {Path: ".github/workflows/lint.yml"}}
+
+	got := preferWorkflow(workflows, "deploy.yml")
+	var files []string
+	for _, w := range got {
+		files = append(files, extractWorkflowFile(w.Path))
+	}
+	if want := []string{"deploy.yml", "ci.yml", "lint.yml"}; !reflect.DeepEqual(files, want) {
+		t.Errorf("preferWorkflow() = %v, want %v", files, want)
+	}
+	if got := preferWorkflow(workflows, ""); !reflect.DeepEqual(got, workflows) {
+		t.Errorf("preferWorkflow() without preference reordered the list")
+	}
+
+	t.Log("✓ Default workflow listed first")
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/20uf/devcli/internal/mock"
+	"github.com/20uf/devcli/internal/ui"
+	"github.com/20uf/devcli/internal/verbose"
+	"github.com/spf13/cobra"
+)
+
+var mockCmd = &cobra.Command{
+	Use:   "mock",
+	Short: "Simulate GitHub for deploy and status",
+	Long: `Run deploy and status against canned gh responses instead of GitHub.
+
+Start the server, then point devcli at it with DEVCLI_MOCK_URL: every gh
+command is answered from a fixture file named after the command, e.g.
+<fixtures>/workflow/list.json for "gh workflow list" and
+<fixtures>/api/repos/octo/app/actions/runs.json for "gh api repos/octo/app/actions/runs".
+Common commands have built-in fixtures.
+
+Examples:
+  devcli mock serve --port 8080 --fixtures ./fixtures
+  DEVCLI_MOCK_URL=http://localhost:8080 devcli deploy --repo octo/app --workflow deploy.yml --branch main`,
+}
+
+var mockServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Start the mock gh server",
+	Args:  cobra.NoArgs,
+	RunE:  runMockServe,
+}
+
+// mockGHCmd answers a redirected gh command line; see verbose.Cmd.
+var mockGHCmd = &cobra.Command{
+	Use:                "gh",
+	Hidden:             true,
+	DisableFlagParsing: true,
+	SilenceUsage:       true,
+	RunE:               runMockGH,
+}
+
+var (
+	flagMockPort     int
+	flagMockFixtures string
+)
+
+func init() {
+	mockServeCmd.Flags().IntVar(&flagMockPort, "port", 8080, "Port to listen on")
+	mockServeCmd.Flags().StringVar(&flagMockFixtures, "fixtures", "", "Directory of fixture files (default: built-in fixtures only)")
+	mockCmd.AddCommand(mockServeCmd)
+	mockCmd.AddCommand(mockGHCmd)
+	rootCmd.AddCommand(mockCmd)
+}
+
+func runMockServe(cmd *cobra.Command, args []string) error {
+	if flagMockFixtures != "" {
+		if info, err := os.Stat(flagMockFixtures); err != nil || !info.IsDir() {
+			return fmt.Errorf("fixtures directory %s not found", flagMockFixtures)
+		}
+	}
+
+	addr := net.JoinHostPort("localhost", strconv.Itoa(flagMockPort))
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	ui.PrintSuccess(fmt.Sprintf("Mock gh server listening on http://%s", addr))
+	fmt.Println(ui.MutedStyle.Render(fmt.Sprintf("  export %s=http://%s", verbose.MockURLEnv, addr)))
+
+	server := &http.Server{Handler: mock.Handler(flagMockFixtures, os.Stdout), ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		<-cmd.Context().Done()
+		server.Close() //nolint:errcheck
+	}()
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func runMockGH(cmd *cobra.Command, args []string) error {
+	baseURL := verbose.MockURL()
+	if baseURL == "" {
+		return fmt.Errorf("%s is not set", verbose.MockURLEnv)
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	return mock.Call(client, baseURL, args, os.Stdout)
+}
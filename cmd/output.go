@@ -0,0 +1,11 @@
+package cmd
+
+import (
+	"github.com/20uf/devcli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// addOutputFlag registers the --output flag of a listing command.
+func addOutputFlag(cmd *cobra.Command, p *string) {
+	cmd.Flags().StringVarP(p, "output", "o", string(ui.OutputText), "Output format: text or json")
+}
@@ -5,25 +5,37 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
+	"strconv"
 	"sync"
+	"time"
 
+	"github.com/20uf/devcli/internal/config"
+	"github.com/20uf/devcli/internal/errmap"
+	"github.com/20uf/devcli/internal/telemetry"
 	"github.com/20uf/devcli/internal/tracker"
 	"github.com/20uf/devcli/internal/ui"
 	"github.com/20uf/devcli/internal/updater"
 	"github.com/20uf/devcli/internal/verbose"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"go.opentelemetry.io/otel/attribute"
 )
 
-var flagVerbose bool
+var (
+	flagVerbose  int
+	flagLogLevel string
+	flagQuiet    bool
+)
 
 var rootCmd = &cobra.Command{
 	Use:   "devcli",
 	Short: "Focus on coding, not on tooling.",
 	Long:  `Devcli is a modular CLI toolbox to manage your dev environment, workflows, and infrastructure interactions.`,
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
-		if flagVerbose {
-			verbose.Enable()
-		}
+	// Errors are rendered by Execute with guidance from errmap.
+	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return applyLogLevel(flagLogLevel, flagVerbose)
 	},
 	Run: func(cmd *cobra.Command, args []string) {
 		showHome(cmd)
@@ -36,52 +48,17 @@ var (
 )
 
 func showHome(cmd *cobra.Command) {
-	// Print banner with inline update check
-	var checkFn func() (string, bool, error)
-	if appVersion != "dev" {
-		checkFn = func() (string, bool, error) {
-			return updater.Check(appVersion, false)
-		}
-	}
-
-	result := ui.PrintBannerWithUpdateCheck(appVersion, checkFn)
-
-	// If update available, invite user to update
-	if result != nil && result.HasUpdate {
-		confirmed, err := ui.Confirm(fmt.Sprintf("Update to v%s?", result.Latest))
-		if err == nil && confirmed {
-			fmt.Println()
-			if err := updater.Apply(result.Latest); err != nil {
-				ui.PrintError(fmt.Sprintf("Update failed: %s", err))
-			} else {
-				ui.PrintSuccess(fmt.Sprintf("Updated to v%s!", result.Latest))
-			}
-			fmt.Println()
-		}
+	if !quietMode() {
+		offerUpdate()
 	}
 
 	// Interactive command selection loop
 	for {
-		commands := []ui.SelectOption{
-			{Display: "connect    Connect to an ECS container interactively", Value: "connect"},
-			{Display: "deploy     Trigger a GitHub Actions deployment workflow", Value: "deploy"},
-		}
-
-		// Dynamic entry: show tracked runs count
+		activeRuns := 0
 		if runs, err := tracker.Load(); err == nil {
-			active := runs.Active()
-			if len(active) > 0 {
-				label := fmt.Sprintf("status     Deployments in progress (%d)", len(active))
-				commands = append(commands, ui.SelectOption{Display: ui.WarningStyle.Render(label), Value: "status"})
-			} else if len(runs.All()) > 0 {
-				commands = append(commands, ui.SelectOption{Display: "status     Recent deployments", Value: "status"})
-			}
+			activeRuns = len(runs.Active())
 		}
-
-		commands = append(commands,
-			ui.SelectOption{Display: "update     Update devcli to the latest version", Value: "update"},
-			ui.SelectOption{Display: "version    Print version information", Value: "version"},
-		)
+		commands := homeCommands(cmd.Root(), activeRuns)
 
 		selected, err := ui.SelectWithOptions("Available Commands", commands)
 		if err != nil {
@@ -99,29 +76,176 @@ func showHome(cmd *cobra.Command) {
 		// Set context so cmd.Context() is not nil when called from home
 		subcmd.SetContext(context.Background())
 
-		var runErr error
-		if subcmd.RunE != nil {
-			runErr = subcmd.RunE(subcmd, []string{})
-		} else if subcmd.Run != nil {
-			subcmd.Run(subcmd, []string{})
+		args, runErr := homeArgs(subcmd)
+		if runErr == nil {
+			if subcmd.RunE != nil {
+				runErr = subcmd.RunE(subcmd, args)
+			} else if subcmd.Run != nil {
+				subcmd.Run(subcmd, args)
+			}
 		}
 
 		if runErr != nil && !errors.Is(runErr, ui.ErrUserAbort) {
-			ui.PrintError(runErr.Error())
+			if _, known := errmap.Lookup(runErr); known {
+				fmt.Println(errmap.Format(runErr))
+			} else {
+				ui.PrintError(runErr.Error())
+			}
 		}
 
 		fmt.Println()
 	}
 }
 
+// homeFirst are the commands listed first in the home menu, the others
+// following alphabetically.
+var homeFirst = []string{"connect", "deploy", "status"}
+
+// homeCommands lists the registered commands that can be launched from the
+// home menu: visible, runnable, and either taking no argument or one picked
+// among their valid arguments. The status entry shows the runs in progress.
+func homeCommands(root *cobra.Command, activeRuns int) []ui.SelectOption {
+	var first, rest []ui.SelectOption
+	for _, c := range root.Commands() {
+		if !c.IsAvailableCommand() || !c.Runnable() {
+			continue
+		}
+		if c.ValidateArgs(nil) != nil && len(c.ValidArgs) == 0 {
+			continue
+		}
+
+		option := ui.SelectOption{Display: fmt.Sprintf("%-10s %s", c.Name(), c.Short), Value: c.Name()}
+		if c.Name() == "status" && activeRuns > 0 {
+			label := fmt.Sprintf("%-10s Deployments in progress (%d)", c.Name(), activeRuns)
+			option.Display = ui.WarningStyle.Render(label)
+		}
+		if slices.Contains(homeFirst, c.Name()) {
+			first = append(first, option)
+		} else {
+			rest = append(rest, option)
+		}
+	}
+
+	slices.SortStableFunc(first, func(a, b ui.SelectOption) int {
+		return slices.Index(homeFirst, a.Value) - slices.Index(homeFirst, b.Value)
+	})
+	return append(first, rest...)
+}
+
+// homeArgs asks for the argument of a command launched from the home menu
+// that needs one, such as the shell of completion.
+func homeArgs(c *cobra.Command) ([]string, error) {
+	if c.ValidateArgs(nil) == nil {
+		return nil, nil
+	}
+	arg, err := ui.Select(c.Name(), c.ValidArgs)
+	if err != nil {
+		return nil, err
+	}
+	return []string{arg}, nil
+}
+
+// offerUpdate prints the banner with an inline update check and offers to
+// apply the update when one is available.
+func offerUpdate() {
+	var checkFn func() (string, bool, error)
+	if appVersion != "dev" {
+		checkFn = func() (string, bool, error) {
+			return updater.Check(appVersion, false)
+		}
+	}
+
+	result := ui.PrintBannerWithUpdateCheck(appVersion, buildSummary(appCommit, appDate), checkFn)
+
+	// If update available, invite user to update
+	if result != nil && result.HasUpdate {
+		confirmed, err := ui.Confirm(fmt.Sprintf("Update to v%s?", result.Latest))
+		if err == nil && confirmed {
+			fmt.Println()
+			if err := updater.Apply(result.Latest); err != nil {
+				ui.PrintError(fmt.Sprintf("Update failed: %s", err))
+			} else {
+				ui.PrintSuccess(fmt.Sprintf("Updated to v%s!", result.Latest))
+			}
+			fmt.Println()
+		}
+	}
+}
+
 func init() {
-	rootCmd.PersistentFlags().BoolVarP(&flagVerbose, "verbose", "v", false, "Enable verbose output (show executed commands and API calls)")
+	rootCmd.PersistentFlags().CountVarP(&flagVerbose, "verbose", "v", "Verbose output: -v shows executed commands, -vv adds full arguments and timing")
+	rootCmd.PersistentFlags().StringVar(&flagLogLevel, "log-level", "", "Output level: quiet, normal, verbose or debug")
+	rootCmd.PersistentFlags().BoolVarP(&flagQuiet, "quiet", "q", false, "Skip the banner and the update check, as --log-level quiet does (or set DEVCLI_QUIET=1)")
+}
+
+// quietMode reports whether the banner and update notices are suppressed,
+// by --quiet, --log-level quiet or DEVCLI_QUIET.
+func quietMode() bool {
+	if level, err := verbose.ParseLevel(flagLogLevel); err == nil && level == verbose.LevelQuiet {
+		return true
+	}
+	return flagQuiet || quietEnv(os.Getenv("DEVCLI_QUIET"))
+}
+
+// quietEnv reads DEVCLI_QUIET as a boolean, any unparsable value but the
+// empty string counting as set.
+func quietEnv(value string) bool {
+	if value == "" {
+		return false
+	}
+	quiet, err := strconv.ParseBool(value)
+	return err != nil || quiet
+}
+
+// applyLogLevel sets the output level from --log-level, falling back to
+// the -v count: -v for verbose, -vv for debug.
+func applyLogLevel(logLevel string, verbosity int) error {
+	if logLevel == "" {
+		switch {
+		case verbosity >= 2:
+			verbose.SetLevel(verbose.LevelDebug)
+		case verbosity == 1:
+			verbose.SetLevel(verbose.LevelVerbose)
+		default:
+			verbose.SetLevel(verbose.LevelNormal)
+		}
+		return nil
+	}
+
+	level, err := verbose.ParseLevel(logLevel)
+	if err != nil {
+		return err
+	}
+	verbose.SetLevel(level)
+	return nil
+}
+
+// exitError carries a specific process exit code for an error.
+type exitError struct {
+	err  error
+	code int
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+
+func (e *exitError) Unwrap() error { return e.err }
+
+// exitCode returns the exit code carried by err, 1 by default.
+func exitCode(err error) int {
+	var exitErr *exitError
+	if errors.As(err, &exitErr) {
+		return exitErr.code
+	}
+	return 1
 }
 
 func Execute() {
+	applyGitHubHost()
+	applySensitiveInputKeys()
+
 	// Background update check only for direct subcommand usage
 	var wg sync.WaitGroup
-	if appVersion != "dev" && len(os.Args) > 1 {
+	if appVersion != "dev" && len(os.Args) > 1 && os.Args[1] != "mock" && !quietMode() {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
@@ -129,17 +253,118 @@ func Execute() {
 		}()
 	}
 
+	shutdownTelemetry := initTelemetry()
+	traceCommands(deployCmd, connectCmd)
+
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		shutdownTelemetry()
+		fmt.Fprintln(os.Stderr, errmap.Format(err))
+		os.Exit(exitCode(err))
 	}
+	shutdownTelemetry()
 
+	// --quiet is only parsed once the command ran: drop the pending check.
+	if quietMode() {
+		return
+	}
 	wg.Wait()
 	if updateNotice != "" {
 		fmt.Fprintln(os.Stderr, updateNotice)
 	}
 }
 
+// initTelemetry starts OpenTelemetry tracing when an OTLP endpoint is
+// configured and returns the function flushing spans before exit.
+func initTelemetry() func() {
+	shutdown, err := telemetry.Init(context.Background(), appVersion)
+	if err != nil {
+		ui.PrintWarning(fmt.Sprintf("Tracing disabled: %s", err))
+		return func() {}
+	}
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdown(ctx); err != nil {
+			verbose.Log("failed to flush traces: %s", err)
+		}
+	}
+}
+
+// traceCommands wraps the run function of each command in a span named
+// after its command path, so that every invocation is traced, including the
+// GitHub deploy and connect paths that bypass the application orchestrators
+// and the runs started from the home menu. Spans opened further down become
+// its children through the command context.
+func traceCommands(cmds ...*cobra.Command) {
+	for _, c := range cmds {
+		run := c.RunE
+		if run == nil {
+			continue
+		}
+		c.RunE = func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			ctx, span := telemetry.Start(ctx, cmd.CommandPath(), commandAttributes(cmd)...)
+			cmd.SetContext(ctx)
+			// The step helpers read the context of the root command
+			cmd.Root().SetContext(ctx)
+			err := run(cmd, args)
+			telemetry.End(span, err)
+			return err
+		}
+	}
+}
+
+// spanFlags maps the flags identifying a deploy or connect target to the
+// span attribute they are recorded under.
+var spanFlags = map[string]attribute.Key{
+	"cluster":  telemetry.ClusterKey,
+	"service":  telemetry.ServiceKey,
+	"workflow": telemetry.WorkflowKey,
+	"branch":   telemetry.BranchKey,
+}
+
+// commandAttributes returns the span attributes of the target flags given
+// on the command line. Other flags may carry secrets and are left out.
+func commandAttributes(cmd *cobra.Command) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if key, ok := spanFlags[f.Name]; ok {
+			attrs = append(attrs, key.String(f.Value.String()))
+		}
+	})
+	return attrs
+}
+
+// applyGitHubHost targets a GitHub Enterprise host when configured: gh subprocesses
+// inherit GH_HOST. devcli's own update check stays on github.com, where it
+// is released.
+func applyGitHubHost() {
+	cfg, err := config.Load()
+	if err != nil {
+		return
+	}
+
+	host := cfg.ResolveGitHubHost()
+	if host == "" {
+		return
+	}
+
+	if os.Getenv("GH_HOST") == "" {
+		_ = os.Setenv("GH_HOST", host)
+	}
+}
+
+// applySensitiveInputKeys masks the configured input names on top of the
+// built-in secret pattern.
+func applySensitiveInputKeys() {
+	if cfg, err := config.Load(); err == nil {
+		verbose.AddSensitiveKeys(cfg.Deploy.SensitiveInputKeys...)
+	}
+}
+
 func checkForUpdate() {
 	latest, hasUpdate, err := updater.Check(appVersion, false)
 	if err != nil || !hasUpdate {
@@ -150,7 +375,7 @@ func checkForUpdate() {
 		updateNotice = fmt.Sprintf(
 			"\n%s %s → %s\n%s\n",
 			ui.WarningStyle.Render("Update available:"),
-			ui.MutedStyle.Render(appVersion),
+			ui.MutedStyle.Render(versionLabel()),
 			ui.SuccessStyle.Render(latest),
 			ui.MutedStyle.Render("Run \"devcli update\" to update."),
 		)
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/20uf/devcli/internal/telemetry"
+	"github.com/20uf/devcli/internal/verbose"
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// Test: -v and -vv select the verbose and debug levels, --log-level wins
+func TestApplyLogLevel(t *testing.T) {
+	defer verbose.SetLevel(verbose.LevelNormal)
+
+	tests := []struct {
+		logLevel  string
+		verbosity int
+		want      verbose.Level
+	}{
+		{"", 0, verbose.LevelNormal},
+		{"", 1, verbose.LevelVerbose},
+		{"", 2, verbose.LevelDebug},
+		{"", 3, verbose.LevelDebug},
+		{"quiet", 2, verbose.LevelQuiet},
+	}
+
+	for _, tt := range tests {
+		if err := applyLogLevel(tt.logLevel, tt.verbosity); err != nil {
+			t.Fatalf("applyLogLevel(%q, %d) error = %v", tt.logLevel, tt.verbosity, err)
+		}
+		if got := verbose.CurrentLevel(); got != tt.want {
+			t.Errorf("applyLogLevel(%q, %d) level = %s, want %s", tt.logLevel, tt.verbosity, got, tt.want)
+		}
+	}
+
+	if err := applyLogLevel("loud", 0); err == nil {
+		t.Errorf("Invalid --log-level should fail")
+	}
+
+	t.Log("✓ Verbosity flags mapped to levels")
+}
+
+// Test: DEVCLI_QUIET accepts boolean values, anything else non-empty enables it
+func TestQuietEnv(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"", false},
+		{"0", false},
+		{"false", false},
+		{"1", true},
+		{"true", true},
+		{"yes", true},
+	}
+
+	for _, tt := range tests {
+		if got := quietEnv(tt.value); got != tt.want {
+			t.Errorf("quietEnv(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+
+	t.Log("✓ DEVCLI_QUIET parsed")
+}
+
+// Test: --log-level quiet implies --quiet
+func TestQuietMode_LogLevel(t *testing.T) {
+	t.Setenv("DEVCLI_QUIET", "")
+	defer func() { flagLogLevel, flagQuiet = "", false }()
+
+	tests := []struct {
+		logLevel string
+		quiet    bool
+		want     bool
+	}{
+		{"", false, false},
+		{"normal", false, false},
+		{"quiet", false, true},
+		{"QUIET", false, true},
+		{"debug", true, true},
+	}
+
+	for _, tt := range tests {
+		flagLogLevel, flagQuiet = tt.logLevel, tt.quiet
+		if got := quietMode(); got != tt.want {
+			t.Errorf("quietMode() with --log-level %q, --quiet=%v = %v, want %v", tt.logLevel, tt.quiet, got, tt.want)
+		}
+	}
+
+	t.Log("✓ Quiet log level skips the banner")
+}
+
+// Test: The home menu lists the runnable commands, main ones first
+func TestHomeCommands(t *testing.T) {
+	options := homeCommands(rootCmd, 2)
+
+	var names []string
+	for _, o := range options {
+		names = append(names, o.Value)
+	}
+	got := strings.Join(names, ",")
+	if !strings.HasPrefix(got, "connect,deploy,status,") {
+		t.Errorf("Home menu should start with connect, deploy and status, got %s", got)
+	}
+	for _, name := range []string{"completion", "doctor", "update", "version"} {
+		if !slices.Contains(names, name) {
+			t.Errorf("Home menu is missing %s: %s", name, got)
+		}
+	}
+	for _, name := range []string{"help", "cp", "alias"} {
+		if slices.Contains(names, name) {
+			t.Errorf("Home menu should not offer %s: %s", name, got)
+		}
+	}
+	if !strings.Contains(options[2].Display, "Deployments in progress (2)") {
+		t.Errorf("Status entry = %q, want the runs in progress", options[2].Display)
+	}
+
+	t.Log("✓ Home menu built from the registered commands")
+}
+
+// Test: Traced commands run inside a span named after them and tagged with their target flags
+func TestTraceCommands(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	root := &cobra.Command{Use: "devcli"}
+	sub := &cobra.Command{
+		Use: "connect",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Helpers open their spans from the root command context
+			_, span := telemetry.Start(cmd.Root().Context(), "cluster-list")
+			telemetry.End(span, nil)
+			return errors.New("no task running")
+		},
+	}
+	sub.Flags().String("cluster", "", "")
+	sub.Flags().String("command", "", "")
+	root.AddCommand(sub)
+	traceCommands(sub)
+
+	root.SetArgs([]string{"connect", "--cluster", "production", "--command", "env"})
+	if err := root.Execute(); err == nil {
+		t.Fatal("Expected the command error")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("Expected 2 spans, got %d", len(spans))
+	}
+	list, command := spans[0], spans[1]
+	if command.Name() != "devcli connect" || list.Parent().SpanID() != command.SpanContext().SpanID() {
+		t.Errorf("Unexpected spans %q, %q", command.Name(), list.Name())
+	}
+	if command.Status().Code != codes.Error {
+		t.Errorf("Expected error status, got %+v", command.Status())
+	}
+	attrs := command.Attributes()
+	if len(attrs) != 1 || attrs[0].Key != telemetry.ClusterKey || attrs[0].Value.AsString() != "production" {
+		t.Errorf("Unexpected attributes %v", attrs)
+	}
+
+	t.Log("✓ Commands traced")
+}
@@ -5,9 +5,16 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/20uf/devcli/internal/clipboard"
+	"github.com/20uf/devcli/internal/config"
+	"github.com/20uf/devcli/internal/retry"
+	"github.com/20uf/devcli/internal/telemetry"
 	"github.com/20uf/devcli/internal/tracker"
 	"github.com/20uf/devcli/internal/ui"
 	"github.com/20uf/devcli/internal/verbose"
@@ -23,16 +30,87 @@ Tracked runs persist across sessions. After triggering a deploy, it appears
 here automatically. You can watch logs, open in browser, or dismiss runs.
 
 Examples:
-  devcli status             Open the live dashboard`,
+  devcli status                     Open the live dashboard
+  devcli status --repo owner/api    Import active runs of a repository and show only its runs
+  devcli status --repo 'owner/*'    Show only the runs of the matching repositories
+  devcli status --label 'release-*' Show only the runs labeled with deploy --label
+  devcli status --watch 123456789   Stream the logs of a run until it completes
+  devcli status list -o json        Print tracked runs as JSON
+  devcli status cancel 123456789    Cancel an in-progress run`,
 	RunE: runStatus,
 }
 
+var statusCancelCmd = &cobra.Command{
+	Use:   "cancel <runID>",
+	Short: "Cancel an in-progress workflow run",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runStatusCancel,
+}
+
+var statusRerunCmd = &cobra.Command{
+	Use:   "rerun <runID>",
+	Short: "Re-run the failed jobs of a workflow run",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runStatusRerun,
+}
+
+var statusListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Print the tracked workflow runs",
+	Args:  cobra.NoArgs,
+	RunE:  runStatusList,
+}
+
+var (
+	flagStatusRepo   string
+	flagCancelRepo   string
+	flagRerunRepo    string
+	flagStatusOutput string
+	flagStatusWatch  string
+	flagStatusLabel  string
+)
+
 func init() {
+	statusCmd.Flags().StringVar(&flagStatusRepo, "repo", "", "Show only the runs of a repository (owner/name, imported first) or of a glob pattern (owner/*)")
+	statusCmd.Flags().StringVar(&flagStatusLabel, "label", "", "Show only the runs whose label matches a glob pattern (set with deploy --label)")
+	statusCmd.Flags().StringVar(&flagStatusWatch, "watch", "", "Stream the logs of a run until it completes, without the dashboard")
+	statusCancelCmd.Flags().StringVar(&flagCancelRepo, "repo", "", "Repository of the run (default: the tracked run's repository)")
+	addOutputFlag(statusListCmd, &flagStatusOutput)
+	statusRerunCmd.Flags().StringVar(&flagRerunRepo, "repo", "", "Repository of the run (default: the tracked run's repository)")
+	statusCmd.AddCommand(statusCancelCmd)
+	statusCmd.AddCommand(statusRerunCmd)
+	statusCmd.AddCommand(statusListCmd)
 	rootCmd.AddCommand(statusCmd)
 }
 
-func runStatus(cmd *cobra.Command, args []string) error {
-	if _, err := exec.LookPath("gh"); err != nil {
+func runStatusCancel(cmd *cobra.Command, args []string) error {
+	if _, err := verbose.LookPath("gh"); err != nil {
+		return fmt.Errorf("GitHub CLI (gh) is required.\n  Install: https://cli.github.com/")
+	}
+
+	store, err := tracker.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load tracker: %w", err)
+	}
+
+	runID := args[0]
+	repo := flagCancelRepo
+	if repo == "" {
+		repo = trackedRunRepo(store, runID)
+	}
+	if repo == "" {
+		return fmt.Errorf("run #%s is not tracked, pass its repository with --repo owner/name", runID)
+	}
+
+	if err := cancelRun(store, runID, repo); err != nil {
+		return err
+	}
+	ui.PrintStep("⊘", fmt.Sprintf("Run #%s cancelled", runID))
+	return nil
+}
+
+func runStatusRerun(cmd *cobra.Command, args []string) error {
+	if _, err := verbose.LookPath("gh"); err != nil {
 		return fmt.Errorf("GitHub CLI (gh) is required.\n  Install: https://cli.github.com/")
 	}
 
@@ -41,24 +119,225 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load tracker: %w", err)
 	}
 
+	runID := args[0]
+	repo := flagRerunRepo
+	if repo == "" {
+		repo = trackedRunRepo(store, runID)
+	}
+	if repo == "" {
+		return fmt.Errorf("run #%s is not tracked, pass its repository with --repo owner/name", runID)
+	}
+
+	if err := rerunFailedJobs(store, runID, repo); err != nil {
+		return err
+	}
+	ui.PrintStep("↻", fmt.Sprintf("Failed jobs of run #%s re-run", runID))
+	return nil
+}
+
+// trackedRunRepo returns the repository of a tracked run, "" when the run
+// is not tracked.
+func trackedRunRepo(store *tracker.Store, runID string) string {
+	for _, r := range store.All() {
+		if r.RunID == runID {
+			return r.Repo
+		}
+	}
+	return ""
+}
+
+// trackedRunRow is a tracked run as listed by `status list`.
+type trackedRunRow struct {
+	RunID      string    `json:"run_id"`
+	Repo       string    `json:"repo"`
+	Workflow   string    `json:"workflow"`
+	Branch     string    `json:"branch"`
+	Status     string    `json:"status"`
+	Conclusion string    `json:"conclusion"`
+	StartedAt  time.Time `json:"started_at"`
+}
+
+func runStatusList(cmd *cobra.Command, args []string) error {
+	format, err := ui.ParseOutputFormat(flagStatusOutput)
+	if err != nil {
+		return err
+	}
+
+	store, err := tracker.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load tracker: %w", err)
+	}
 	store.Cleanup()
 
-	if len(store.Runs) == 0 {
+	return ui.RenderList(os.Stdout, format, trackedRunRows(store.All()))
+}
+
+// trackedRunRows converts tracked runs to list rows.
+func trackedRunRows(runs []tracker.Run) []trackedRunRow {
+	rows := make([]trackedRunRow, len(runs))
+	for i, r := range runs {
+		rows[i] = trackedRunRow{
+			RunID: r.RunID, Repo: r.Repo, Workflow: r.Workflow, Branch: r.Branch,
+			Status: r.Status, Conclusion: r.Conclusion, StartedAt: r.StartedAt,
+		}
+	}
+	return rows
+}
+
+// cancelRun cancels a workflow run and marks it cancelled in the tracker.
+func cancelRun(store *tracker.Store, runID, repo string) error {
+	c := verbose.Cmd(exec.Command("gh", "run", "cancel", runID, "--repo", repo))
+	if out, err := c.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to cancel run #%s: %s", runID, strings.TrimSpace(string(out)))
+	}
+
+	store.Update(runID, "completed", "cancelled")
+	return store.Save()
+}
+
+// rerunFailedJobs re-runs the failed jobs of a run, which is queued again.
+func rerunFailedJobs(store *tracker.Store, runID, repo string) error {
+	c := verbose.Cmd(exec.Command("gh", "run", "rerun", runID, "--failed", "--repo", repo))
+	if out, err := c.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to re-run run #%s: %s", runID, strings.TrimSpace(string(out)))
+	}
+
+	store.Update(runID, "queued", "")
+	return store.Save()
+}
+
+// isRerunnable reports whether a run has failed jobs to re-run.
+func isRerunnable(run tracker.Run) bool {
+	return run.Status == "completed" && run.Conclusion == "failure"
+}
+
+// isCancellable reports whether a run can still be cancelled.
+func isCancellable(run tracker.Run) bool {
+	return run.Status == "in_progress" || run.Status == "queued"
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	if _, err := verbose.LookPath("gh"); err != nil {
+		return fmt.Errorf("GitHub CLI (gh) is required.\n  Install: https://cli.github.com/")
+	}
+
+	store, err := tracker.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load tracker: %w", err)
+	}
+
+	if flagStatusWatch != "" {
+		store.Cleanup()
+		return runStatusWatch(store, flagStatusWatch)
+	}
+
+	filter := runFilter{repo: flagStatusRepo, label: flagStatusLabel}
+	if err := filter.validate(); err != nil {
+		return err
+	}
+	store.CleanupMatching(filter.matches)
+
+	if flagStatusRepo != "" && !filter.isRepoPattern() {
+		added, err := importRepoRuns(store, flagStatusRepo)
+		if err != nil {
+			return err
+		}
+		store.Save() //nolint:errcheck
+		ui.PrintStep("↓", fmt.Sprintf("Imported %d active run(s) from %s", added, flagStatusRepo))
+	}
+
+	if len(filter.runs(store.All())) == 0 {
+		if !filter.isEmpty() {
+			ui.PrintWarning(fmt.Sprintf("No tracked deployments for %s", filter))
+			return nil
+		}
 		ui.PrintWarning("No tracked deployments")
 		fmt.Println(ui.MutedStyle.Render("  Trigger a deploy with `devcli deploy` — it will appear here automatically."))
 		return nil
 	}
 
-	return showDashboard(store)
+	return showDashboard(store, filter)
+}
+
+// runFilter scopes the dashboard to the runs of a repository and to the
+// runs of a label, each a glob pattern such as owner/* or release-*. An
+// empty field matches every run.
+type runFilter struct {
+	repo  string
+	label string
+}
+
+func (f runFilter) validate() error {
+	if _, err := path.Match(f.repo, ""); err != nil {
+		return fmt.Errorf("invalid --repo pattern %q: %w", f.repo, err)
+	}
+	if _, err := path.Match(f.label, ""); err != nil {
+		return fmt.Errorf("invalid --label pattern %q: %w", f.label, err)
+	}
+	return nil
+}
+
+func (f runFilter) isEmpty() bool {
+	return f.repo == "" && f.label == ""
+}
+
+// isRepoPattern reports whether the repository holds glob characters, so it
+// names no single repository to import runs from.
+func (f runFilter) isRepoPattern() bool {
+	return strings.ContainsAny(f.repo, "*?[")
 }
 
-func showDashboard(store *tracker.Store) error {
+func (f runFilter) matches(run tracker.Run) bool {
+	return matchesPattern(f.repo, run.Repo) && matchesPattern(f.label, run.Label)
+}
+
+// matchesPattern reports whether value matches pattern, "" matching anything.
+func matchesPattern(pattern, value string) bool {
+	if pattern == "" {
+		return true
+	}
+	ok, _ := path.Match(pattern, value)
+	return ok
+}
+
+// String describes the filter as shown in the dashboard title.
+func (f runFilter) String() string {
+	var parts []string
+	if f.repo != "" {
+		parts = append(parts, f.repo)
+	}
+	if f.label != "" {
+		parts = append(parts, "label "+f.label)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// runs returns the runs matching the filter.
+func (f runFilter) runs(all []tracker.Run) []tracker.Run {
+	if f.isEmpty() {
+		return all
+	}
+	var runs []tracker.Run
+	for _, r := range all {
+		if f.matches(r) {
+			runs = append(runs, r)
+		}
+	}
+	return runs
+}
+
+func showDashboard(store *tracker.Store, filter runFilter) error {
+	title := "Tracked Deployments"
+	if !filter.isEmpty() {
+		title = fmt.Sprintf("Tracked Deployments (%s)", filter)
+	}
+
 	for {
 		// Refresh statuses from GitHub
-		refreshRunStatuses(store)
+		refreshRunStatuses(store, filter)
 		store.Save() //nolint:errcheck
 
-		runs := store.All()
+		runs := filter.runs(store.All())
 		if len(runs) == 0 {
 			ui.PrintSuccess("All deployments completed!")
 			return nil
@@ -84,7 +363,7 @@ func showDashboard(store *tracker.Store) error {
 			Value:   "__back",
 		})
 
-		selected, err := ui.SelectWithOptions("Tracked Deployments", options)
+		selected, err := ui.SelectWithOptions(title, options)
 		if err != nil {
 			return nil // ESC → back to home
 		}
@@ -123,6 +402,12 @@ func showRunActions(store *tracker.Store, run *tracker.Run) error {
 	if run.Status == "completed" {
 		actions = append(actions, "View full logs")
 	}
+	if isCancellable(*run) {
+		actions = append(actions, "Cancel run")
+	}
+	if isRerunnable(*run) {
+		actions = append(actions, "Re-run failed jobs")
+	}
 	actions = append(actions, "Dismiss (stop tracking)")
 	actions = append(actions, "Back to dashboard")
 
@@ -133,21 +418,12 @@ func showRunActions(store *tracker.Store, run *tracker.Run) error {
 
 	switch action {
 	case "Stream logs (watch)":
-		c := verbose.Cmd(exec.Command("gh", "run", "watch", run.RunID, "--repo", run.Repo, "--exit-status"))
-		c.Stdin = os.Stdin
-		c.Stdout = os.Stdout
-		c.Stderr = os.Stderr
-
-		if err := c.Run(); err != nil {
-			ui.PrintError(fmt.Sprintf("Workflow run failed (#%s)", run.RunID))
-		} else {
-			ui.PrintSuccess(fmt.Sprintf("Workflow run #%s completed!", run.RunID))
-		}
-		// Refresh status after watching
-		refreshSingleRun(store, run.RunID, run.Repo)
-		store.Save() //nolint:errcheck
+		watchTrackedRun(store, run.RunID, run.Repo) //nolint:errcheck
 
 	case "View in browser":
+		if run.URL != "" {
+			fmt.Println(ui.MutedStyle.Render("  " + run.URL))
+		}
 		verbose.Cmd(exec.Command("gh", "run", "view", run.RunID, "--repo", run.Repo, "--web")).Run() //nolint:errcheck
 
 	case "View full logs":
@@ -157,6 +433,20 @@ func showRunActions(store *tracker.Store, run *tracker.Run) error {
 		c.Stderr = os.Stderr
 		c.Run() //nolint:errcheck
 
+	case "Cancel run":
+		if err := cancelRun(store, run.RunID, run.Repo); err != nil {
+			ui.PrintError(err.Error())
+		} else {
+			ui.PrintStep("⊘", fmt.Sprintf("Run #%s cancelled", run.RunID))
+		}
+
+	case "Re-run failed jobs":
+		if err := rerunFailedJobs(store, run.RunID, run.Repo); err != nil {
+			ui.PrintError(err.Error())
+		} else {
+			ui.PrintStep("↻", fmt.Sprintf("Failed jobs of run #%s re-run", run.RunID))
+		}
+
 	case "Dismiss (stop tracking)":
 		store.Remove(run.RunID)
 		store.Save() //nolint:errcheck
@@ -169,33 +459,136 @@ func showRunActions(store *tracker.Store, run *tracker.Run) error {
 	return nil
 }
 
-func refreshRunStatuses(store *tracker.Store) {
-	for i := range store.Runs {
-		r := &store.Runs[i]
-		if r.Status == "completed" {
+// refreshConcurrency bounds the number of `gh run view` calls in flight.
+const refreshConcurrency = 4
+
+// remoteRunStatus is the remote state of a workflow run.
+type remoteRunStatus struct {
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+	URL        string `json:"url"`
+}
+
+func refreshRunStatuses(store *tracker.Store, filter runFilter) {
+	if failed := refreshRuns(store, filter, fetchRunStatus); failed > 0 {
+		ui.PrintWarning(fmt.Sprintf("%d run(s) failed to refresh", failed))
+	}
+}
+
+// refreshRuns fetches the status of every active run matching the filter in
+// parallel and applies the successful results. A failing run does not abort
+// the others; the number of failures is returned.
+func refreshRuns(store *tracker.Store, filter runFilter, fetch func(runID, repo string) (remoteRunStatus, error)) int {
+	type result struct {
+		runID  string
+		status remoteRunStatus
+		err    error
+	}
+
+	var pending []tracker.Run
+	for _, r := range filter.runs(store.Runs) {
+		if r.Status != "completed" {
+			pending = append(pending, r)
+		}
+	}
+
+	results := make([]result, len(pending))
+	sem := make(chan struct{}, refreshConcurrency)
+	var wg sync.WaitGroup
+	for i, r := range pending {
+		wg.Add(1)
+		go func(i int, r tracker.Run) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			status, err := fetch(r.RunID, r.Repo)
+			results[i] = result{runID: r.RunID, status: status, err: err}
+		}(i, r)
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, res := range results {
+		if res.err != nil {
+			verbose.Log("refresh run %s: %v", res.runID, res.err)
+			failed++
 			continue
 		}
-		refreshSingleRun(store, r.RunID, r.Repo)
+		applyRunStatus(store, res.runID, res.status)
+	}
+	return failed
+}
+
+// runStatusWatch streams the logs of one run, its repository taken from
+// --repo or the tracker, and fails when the run does not succeed.
+func runStatusWatch(store *tracker.Store, runID string) error {
+	repo := flagStatusRepo
+	if repo == "" {
+		repo = trackedRunRepo(store, runID)
 	}
+	if repo == "" {
+		return fmt.Errorf("run #%s is not tracked, pass its repository with --repo owner/name", runID)
+	}
+	return watchTrackedRun(store, runID, repo)
+}
+
+// watchTrackedRun streams the logs of a run with gh run watch until it
+// completes, then records its final status in the tracker.
+func watchTrackedRun(store *tracker.Store, runID, repo string) error {
+	c := verbose.Cmd(exec.Command("gh", "run", "watch", runID, "--repo", repo, "--exit-status"))
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+
+	err := c.Run()
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Workflow run failed (#%s)", runID))
+	} else {
+		ui.PrintSuccess(fmt.Sprintf("Workflow run #%s completed!", runID))
+	}
+	// Refresh status after watching
+	refreshSingleRun(store, runID, repo)
+	store.Save() //nolint:errcheck
+
+	if err != nil {
+		return fmt.Errorf("workflow run #%s did not succeed", runID)
+	}
+	return nil
 }
 
 func refreshSingleRun(store *tracker.Store, runID, repo string) {
-	out, err := verbose.Cmd(exec.Command("gh", "run", "view", runID,
-		"--repo", repo,
-		"--json", "status,conclusion")).Output()
+	status, err := fetchRunStatus(runID, repo)
 	if err != nil {
 		return
 	}
+	applyRunStatus(store, runID, status)
+}
 
-	var result struct {
-		Status     string `json:"status"`
-		Conclusion string `json:"conclusion"`
+// fetchRunStatus reads the status of a run from GitHub.
+func fetchRunStatus(runID, repo string) (remoteRunStatus, error) {
+	out, err := retry.Output(func() *exec.Cmd {
+		return verbose.Cmd(exec.Command("gh", "run", "view", runID,
+			"--repo", repo,
+			"--json", "status,conclusion,url"))
+	})
+	if err != nil {
+		return remoteRunStatus{}, err
 	}
+
+	var result remoteRunStatus
 	if err := json.Unmarshal(out, &result); err != nil {
-		return
+		return remoteRunStatus{}, fmt.Errorf("failed to parse run %s: %w", runID, err)
 	}
+	return result, nil
+}
 
-	store.Update(runID, result.Status, result.Conclusion)
+// applyRunStatus records a fetched status in the store.
+func applyRunStatus(store *tracker.Store, runID string, status remoteRunStatus) {
+	store.Update(runID, status.Status, status.Conclusion)
+	if status.URL != "" {
+		store.SetURL(runID, status.URL)
+	}
 }
 
 func runStatusIcon(status, conclusion string) string {
@@ -215,24 +608,121 @@ func runStatusIcon(status, conclusion string) string {
 	}
 }
 
-// findLatestRunID finds the most recent run ID for a workflow after trigger.
-func findLatestRunID(repo, workflow string) (string, error) {
+// latestRun identifies a workflow run found right after a trigger.
+type latestRun struct {
+	ID     string
+	URL    string
+	Number int // run number shown by GitHub, 0 when unknown
+	Branch string
+}
+
+// findLatestRun finds the most recent run of a workflow after trigger.
+func findLatestRun(repo, workflow string) (latestRun, error) {
 	// Wait a moment for the run to appear
 	time.Sleep(2 * time.Second)
 
-	out, err := verbose.Cmd(exec.Command("gh", "run", "list",
+	return latestWorkflowRun(repo, workflow, "")
+}
+
+// latestWorkflowRun returns the most recent run of a workflow, on branch
+// when it is not empty.
+func latestWorkflowRun(repo, workflow, branch string) (latestRun, error) {
+	args := []string{"run", "list",
 		"--repo", repo,
 		"--workflow", workflow,
 		"--limit", "1",
-		"--json", "databaseId",
-		"-q", ".[0].databaseId")).Output()
+		"--json", "databaseId,url,number,headBranch"}
+	if branch != "" {
+		args = append(args, "--branch", branch)
+	}
+	out, err := verbose.Cmd(exec.Command("gh", args...)).Output()
+	if err != nil {
+		return latestRun{}, err
+	}
+
+	return parseLatestRun(out)
+}
+
+// parseLatestRun extracts the first run of a `gh run list --json databaseId,url,number,headBranch` payload.
+func parseLatestRun(payload []byte) (latestRun, error) {
+	var runs []struct {
+		DatabaseID int64  `json:"databaseId"`
+		URL        string `json:"url"`
+		Number     int    `json:"number"`
+		HeadBranch string `json:"headBranch"`
+	}
+	if err := json.Unmarshal(payload, &runs); err != nil {
+		return latestRun{}, fmt.Errorf("failed to parse run list: %w", err)
+	}
+
+	if len(runs) == 0 || runs[0].DatabaseID == 0 {
+		return latestRun{}, fmt.Errorf("no run found")
+	}
+	return latestRun{
+		ID:     strconv.FormatInt(runs[0].DatabaseID, 10),
+		URL:    runs[0].URL,
+		Number: runs[0].Number,
+		Branch: runs[0].HeadBranch,
+	}, nil
+}
+
+// trackTriggeredRun looks up the run created by a trigger, adds it to the
+// tracker and prints its URL.
+func trackTriggeredRun(repo, workflow, branch, label string) (latestRun, error) {
+	_, span := telemetry.Start(rootCmd.Context(), "run-poll",
+		telemetry.WorkflowKey.String(workflow), telemetry.BranchKey.String(branch))
+	run, err := findLatestRun(repo, workflow)
+	telemetry.End(span, err)
 	if err != nil {
-		return "", err
+		return latestRun{}, err
+	}
+
+	trackRun(repo, workflow, branch, label, run)
+	return run, nil
+}
+
+// trackRun adds a triggered run to the tracker so it shows up in `devcli status`.
+func trackRun(repo, workflow, branch, label string, run latestRun) {
+	if runs, loadErr := tracker.Load(); loadErr == nil {
+		runs.Add(repo, workflow, branch, run.ID, label)
+		runs.SetURL(run.ID, run.URL)
+		runs.Save() //nolint:errcheck
+	}
+
+	ui.PrintStep("◉", runStartedMessage(run))
+	if run.URL != "" {
+		fmt.Println(ui.MutedStyle.Render("  " + run.URL))
+		if copyURLEnabled() {
+			copyRunURL(run.URL)
+		}
+	}
+}
+
+// copyURLEnabled reports whether run URLs go to the clipboard: --copy-url,
+// then deploy.copy_url.
+func copyURLEnabled() bool {
+	if flagCopyURL {
+		return true
+	}
+	cfg, err := config.Load()
+	return err == nil && cfg.Deploy.CopyURL
+}
+
+// copyRunURL copies a run URL to the clipboard. The URL is already printed,
+// so a missing clipboard tool is only a warning.
+func copyRunURL(url string) {
+	if err := clipboard.Copy(url); err != nil {
+		ui.PrintWarning(fmt.Sprintf("Could not copy the run URL: %s", err))
+		return
 	}
+	ui.PrintSuccess("Run URL copied to the clipboard")
+}
 
-	id := strings.TrimSpace(string(out))
-	if id == "" {
-		return "", fmt.Errorf("no run found")
+// runStartedMessage announces a tracked run by its number when known, with
+// the ID that `devcli status --watch` takes.
+func runStartedMessage(run latestRun) string {
+	if run.Number > 0 {
+		return fmt.Sprintf("Run #%d started (ID %s) — follow it with `devcli status --watch %s`", run.Number, run.ID, run.ID)
 	}
-	return id, nil
+	return fmt.Sprintf("Tracking run #%s — view with `devcli status`", run.ID)
 }
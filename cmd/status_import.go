@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/20uf/devcli/internal/tracker"
+	"github.com/20uf/devcli/internal/verbose"
+)
+
+// importRunLimit is the number of recent runs inspected by `status --repo`.
+const importRunLimit = 30
+
+// ghRun is a workflow run as returned by `gh run list --json`.
+type ghRun struct {
+	DatabaseID   int64     `json:"databaseId"`
+	WorkflowName string    `json:"workflowName"`
+	HeadBranch   string    `json:"headBranch"`
+	Status       string    `json:"status"`
+	Conclusion   string    `json:"conclusion"`
+	URL          string    `json:"url"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// parseActiveRuns decodes a gh run-list payload and keeps runs that are not completed.
+func parseActiveRuns(payload []byte) ([]ghRun, error) {
+	var runs []ghRun
+	if err := json.Unmarshal(payload, &runs); err != nil {
+		return nil, fmt.Errorf("failed to parse run list: %w", err)
+	}
+
+	var active []ghRun
+	for _, r := range runs {
+		if r.Status != "completed" {
+			active = append(active, r)
+		}
+	}
+	return active, nil
+}
+
+// trackRuns upserts runs into the tracker and returns how many were newly added.
+func trackRuns(store *tracker.Store, repo string, runs []ghRun) int {
+	added := 0
+	for _, r := range runs {
+		if store.Upsert(tracker.Run{
+			Repo:       repo,
+			Workflow:   r.WorkflowName,
+			Branch:     r.HeadBranch,
+			RunID:      strconv.FormatInt(r.DatabaseID, 10),
+			Label:      fmt.Sprintf("%s/%s @ %s", repo, r.WorkflowName, r.HeadBranch),
+			Status:     r.Status,
+			Conclusion: r.Conclusion,
+			URL:        r.URL,
+			StartedAt:  r.CreatedAt,
+		}) {
+			added++
+		}
+	}
+	return added
+}
+
+// importRepoRuns fetches recent runs of a repository and tracks the active ones.
+func importRepoRuns(store *tracker.Store, repo string) (int, error) {
+	out, err := verbose.Cmd(exec.Command("gh", "run", "list",
+		"--repo", repo,
+		"--limit", strconv.Itoa(importRunLimit),
+		"--json", "databaseId,workflowName,headBranch,status,conclusion,url,createdAt")).Output()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list runs for %s: %w", repo, err)
+	}
+
+	active, err := parseActiveRuns(out)
+	if err != nil {
+		return 0, err
+	}
+
+	return trackRuns(store, repo, active), nil
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/20uf/devcli/internal/tracker"
+)
+
+const runListPayload = `[
+  {"databaseId": 101, "workflowName": "Deploy", "headBranch": "main", "status": "in_progress", "conclusion": "", "createdAt": "2025-01-01T10:00:00Z"},
+  {"databaseId": 102, "workflowName": "CI", "headBranch": "feat/x", "status": "queued", "conclusion": "", "createdAt": "2025-01-01T10:01:00Z"},
+  {"databaseId": 100, "workflowName": "Deploy", "headBranch": "main", "status": "completed", "conclusion": "success", "createdAt": "2025-01-01T09:00:00Z"}
+]`
+
+// Test: Only non-completed runs are selected from a gh run-list payload
+func TestParseActiveRuns(t *testing.T) {
+	active, err := parseActiveRuns([]byte(runListPayload))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(active) != 2 {
+		t.Fatalf("Expected 2 active runs, got %d", len(active))
+	}
+	if active[0].DatabaseID != 101 || active[1].DatabaseID != 102 {
+		t.Errorf("Unexpected runs selected: %+v", active)
+	}
+
+	if _, err := parseActiveRuns([]byte(`{invalid`)); err == nil {
+		t.Errorf("Malformed payload should fail")
+	}
+
+	t.Log("✓ Active runs selected from run list")
+}
+
+// Test: Imported runs are upserted without duplicating tracked ones
+func TestTrackRuns_Upsert(t *testing.T) {
+	store := &tracker.Store{}
+	store.Add("owner/api", "deploy.yml", "main", "101", "owner/api/Deploy @ main")
+
+	active, _ := parseActiveRuns([]byte(runListPayload))
+	added := trackRuns(store, "owner/api", active)
+
+	if added != 1 {
+		t.Errorf("Expected 1 new run, got %d", added)
+	}
+	if len(store.Runs) != 2 {
+		t.Fatalf("Expected 2 tracked runs, got %d", len(store.Runs))
+	}
+	if store.Runs[0].Status != "in_progress" {
+		t.Errorf("Existing run status not refreshed: %s", store.Runs[0].Status)
+	}
+	if store.Runs[1].RunID != "102" || store.Runs[1].Branch != "feat/x" || store.Runs[1].StartedAt.IsZero() {
+		t.Errorf("Imported run not populated: %+v", store.Runs[1])
+	}
+
+	if trackRuns(store, "owner/api", active) != 0 {
+		t.Errorf("Second import should not add runs")
+	}
+
+	t.Log("✓ Runs upserted into tracker")
+}
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/20uf/devcli/internal/tracker"
+)
+
+// Test: Latest run ID and URL are read from a gh run-list payload
+func TestParseLatestRun(t *testing.T) {
+	run, err := parseLatestRun([]byte(`[{"databaseId": 123456789, "number": 42, "headBranch": "main", "url": "https://github.com/owner/api/actions/runs/123456789"}]`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if run.ID != "123456789" || run.Number != 42 || run.Branch != "main" || run.URL != "https://github.com/owner/api/actions/runs/123456789" {
+		t.Errorf("Unexpected run: %+v", run)
+	}
+
+	if _, err := parseLatestRun([]byte(`[]`)); err == nil {
+		t.Errorf("Empty run list should fail")
+	}
+
+	t.Log("✓ Latest run ID and URL parsed")
+}
+
+// Test: Started runs are announced by number with the ID to watch
+func TestRunStartedMessage(t *testing.T) {
+	if got := runStartedMessage(latestRun{ID: "123456789", Number: 42}); !strings.Contains(got, "#42") || !strings.Contains(got, "--watch 123456789") {
+		t.Errorf("runStartedMessage() = %q", got)
+	}
+	if got := runStartedMessage(latestRun{ID: "123456789"}); !strings.Contains(got, "#123456789") {
+		t.Errorf("runStartedMessage() without number = %q", got)
+	}
+
+	t.Log("✓ Run start announced")
+}
+
+// Test: A triggered run is persisted so it appears in `devcli status`
+func TestTrackRun(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	trackRun("owner/api", "deploy.yml", "main", "owner/api/Deploy @ main",
+		latestRun{ID: "42", URL: "https://github.com/owner/api/actions/runs/42"})
+
+	store, err := tracker.Load()
+	if err != nil {
+		t.Fatalf("Failed to load tracker: %v", err)
+	}
+	active := store.Active()
+	if len(active) != 1 {
+		t.Fatalf("Expected 1 tracked run, got %d", len(active))
+	}
+	if active[0].RunID != "42" || active[0].Repo != "owner/api" || active[0].URL == "" {
+		t.Errorf("Unexpected tracked run: %+v", active[0])
+	}
+
+	t.Log("✓ Triggered run tracked")
+}
+
+// Test: Only queued and in-progress runs offer the cancel action
+func TestIsCancellable(t *testing.T) {
+	tests := map[string]bool{
+		"queued":      true,
+		"in_progress": true,
+		"completed":   false,
+		"waiting":     false,
+	}
+
+	for status, want := range tests {
+		if got := isCancellable(tracker.Run{Status: status}); got != want {
+			t.Errorf("isCancellable(%s) = %v, want %v", status, got, want)
+		}
+	}
+
+	t.Log("✓ Cancel action offered for active runs")
+}
+
+// Test: Only failed runs offer to re-run their failed jobs
+func TestIsRerunnable(t *testing.T) {
+	tests := []struct {
+		status, conclusion string
+		want               bool
+	}{
+		{"completed", "failure", true},
+		{"completed", "success", false},
+		{"completed", "cancelled", false},
+		{"in_progress", "", false},
+	}
+
+	for _, tt := range tests {
+		if got := isRerunnable(tracker.Run{Status: tt.status, Conclusion: tt.conclusion}); got != tt.want {
+			t.Errorf("isRerunnable(%s, %s) = %v, want %v", tt.status, tt.conclusion, got, tt.want)
+		}
+	}
+
+	t.Log("✓ Re-run action offered for failed runs")
+}
+
+// Test: A failing refresh does not prevent the other runs from updating
+func TestRefreshRuns_IsolatesFailures(t *testing.T) {
+	store := &tracker.Store{}
+	store.Add("owner/api", "deploy.yml", "main", "1", "api")
+	store.Add("owner/web", "deploy.yml", "main", "2", "web")
+	store.Add("owner/worker", "deploy.yml", "main", "3", "worker")
+	store.Add("owner/done", "deploy.yml", "main", "4", "done")
+	store.Update("4", "completed", "success")
+
+	fetched := make(chan string, 4)
+	failed := refreshRuns(store, runFilter{}, func(runID, repo string) (remoteRunStatus, error) {
+		fetched <- runID
+		if runID == "2" {
+			return remoteRunStatus{}, errors.New("HTTP 502")
+		}
+		return remoteRunStatus{Status: "completed", Conclusion: "success", URL: "https://github.com/" + repo}, nil
+	})
+	close(fetched)
+
+	if failed != 1 {
+		t.Errorf("Expected 1 failed refresh, got %d", failed)
+	}
+	if len(fetched) != 3 {
+		t.Errorf("Completed runs should not be refreshed, fetched %d", len(fetched))
+	}
+
+	want := map[string]string{"1": "completed", "2": "queued", "3": "completed"}
+	for _, r := range store.Runs {
+		if status, ok := want[r.RunID]; ok && r.Status != status {
+			t.Errorf("Run %s status = %s, want %s", r.RunID, r.Status, status)
+		}
+	}
+	for _, r := range store.Runs {
+		if r.RunID == "3" && r.URL != "https://github.com/owner/worker" {
+			t.Errorf("Run 3 URL not updated: %q", r.URL)
+		}
+	}
+
+	t.Log("✓ Failed refreshes isolated and counted")
+}
+
+// Test: The repository of a watched run comes from the tracker
+func TestTrackedRunRepo(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	defer func() { flagStatusRepo = "" }()
+
+	trackRun("owner/api", "deploy.yml", "main", "owner/api/Deploy @ main", latestRun{ID: "42"})
+	store, err := tracker.Load()
+	if err != nil {
+		t.Fatalf("Failed to load tracker: %v", err)
+	}
+
+	if got := trackedRunRepo(store, "42"); got != "owner/api" {
+		t.Errorf("trackedRunRepo(42) = %q, want owner/api", got)
+	}
+	if got := trackedRunRepo(store, "7"); got != "" {
+		t.Errorf("trackedRunRepo(7) = %q, want none", got)
+	}
+
+	flagStatusRepo = ""
+	if err := runStatusWatch(store, "7"); err == nil {
+		t.Error("Watching an untracked run without --repo should fail")
+	}
+
+	t.Log("✓ Watched run repository resolved")
+}
+
+// Test: The dashboard is scoped to a repository or label, as glob patterns
+func TestRunFilter(t *testing.T) {
+	all := []tracker.Run{
+		{RunID: "1", Repo: "owner/api", Label: "release-2.5.0"},
+		{RunID: "2", Repo: "owner/web", Label: "owner/web/deploy @ main"},
+		{RunID: "3", Repo: "other/api", Label: "hotfix-payment"},
+	}
+
+	tests := []struct {
+		filter  runFilter
+		want    []string
+		pattern bool
+	}{
+		{runFilter{}, []string{"1", "2", "3"}, false},
+		{runFilter{repo: "owner/api"}, []string{"1"}, false},
+		{runFilter{repo: "owner/*"}, []string{"1", "2"}, true},
+		{runFilter{repo: "*/api"}, []string{"1", "3"}, true},
+		{runFilter{label: "release-*"}, []string{"1"}, false},
+		{runFilter{repo: "*/api", label: "hotfix-*"}, []string{"3"}, true},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, r := range tt.filter.runs(all) {
+			got = append(got, r.RunID)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%+v.runs() = %v, want %v", tt.filter, got, tt.want)
+		}
+		if tt.filter.isRepoPattern() != tt.pattern {
+			t.Errorf("%+v.isRepoPattern() = %v", tt.filter, !tt.pattern)
+		}
+	}
+	if err := (runFilter{repo: "owner/["}).validate(); err == nil {
+		t.Error("Expected an invalid repository pattern to be rejected")
+	}
+	if err := (runFilter{label: "release-["}).validate(); err == nil {
+		t.Error("Expected an invalid label pattern to be rejected")
+	}
+
+	t.Log("✓ Runs filtered by repository and label")
+}
+
+// Test: Only the runs matching the filter are refreshed
+func TestRefreshRuns_Filter(t *testing.T) {
+	store := &tracker.Store{}
+	store.Add("owner/api", "deploy.yml", "main", "1", "api")
+	store.Add("other/web", "deploy.yml", "main", "2", "web")
+
+	var fetched []string
+	refreshRuns(store, runFilter{repo: "owner/*"}, func(runID, repo string) (remoteRunStatus, error) {
+		fetched = append(fetched, runID)
+		return remoteRunStatus{Status: "in_progress"}, nil
+	})
+
+	if !reflect.DeepEqual(fetched, []string{"1"}) {
+		t.Errorf("Refreshed runs = %v, want [1]", fetched)
+	}
+
+	t.Log("✓ Refresh scoped to the filter")
+}
@@ -0,0 +1,13 @@
+//go:build !(linux || darwin || freebsd || openbsd)
+
+package cmd
+
+import (
+	"os"
+	"time"
+)
+
+// lastTTYInput cannot tell when the terminal was last read on this platform.
+func lastTTYInput(f *os.File) (time.Time, bool) {
+	return time.Time{}, false
+}
@@ -0,0 +1,21 @@
+//go:build linux || darwin || freebsd || openbsd
+
+package cmd
+
+import (
+	"os"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// lastTTYInput returns when input was last read from the terminal f: the
+// kernel updates the access time of a tty when it is read, with a
+// granularity of a few seconds on Linux. This is how w(1) tells idle users.
+func lastTTYInput(f *os.File) (time.Time, bool) {
+	var st unix.Stat_t
+	if err := unix.Fstat(int(f.Fd()), &st); err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(st.Atim.Unix()), true
+}
@@ -1,7 +1,11 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"runtime"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -12,20 +16,81 @@ var (
 	appDate    = "unknown"
 )
 
+var flagVersionJSON bool
+
 func SetVersionInfo(version, commit, date string) {
 	appVersion = version
 	appCommit = commit
 	appDate = date
 }
 
+// buildSummary returns the abbreviated commit and the build day, such as
+// "abc1234, 2024-05-01", leaving out the values not set at build time.
+func buildSummary(commit, date string) string {
+	var parts []string
+	if commit != "" && commit != "none" {
+		if len(commit) > 7 {
+			commit = commit[:7]
+		}
+		parts = append(parts, commit)
+	}
+	if date != "" && date != "unknown" {
+		if t, err := time.Parse(time.RFC3339, date); err == nil {
+			date = t.Format("2006-01-02")
+		}
+		parts = append(parts, date)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// versionLabel is the version followed by its build summary, so dev builds
+// can be told apart.
+func versionLabel() string {
+	if build := buildSummary(appCommit, appDate); build != "" {
+		return fmt.Sprintf("%s (%s)", appVersion, build)
+	}
+	return appVersion
+}
+
+// versionInfo is the machine-readable output of `devcli version --json`.
+type versionInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	Date      string `json:"date"`
+	GoVersion string `json:"goVersion"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+}
+
+func currentVersionInfo() versionInfo {
+	return versionInfo{
+		Version:   appVersion,
+		Commit:    appCommit,
+		Date:      appDate,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+	}
+}
+
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print version information",
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("devcli %s (commit: %s, built: %s)\n", appVersion, appCommit, appDate)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if flagVersionJSON {
+			out, err := json.MarshalIndent(currentVersionInfo(), "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(out))
+			return nil
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "devcli %s (commit: %s, built: %s)\n", appVersion, appCommit, appDate)
+		return nil
 	},
 }
 
 func init() {
+	versionCmd.Flags().BoolVar(&flagVersionJSON, "json", false, "Print version information as JSON")
 	rootCmd.AddCommand(versionCmd)
 }
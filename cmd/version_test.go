@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"runtime"
+	"testing"
+)
+
+// Test: version --json exposes build metadata and the runtime platform
+func TestCurrentVersionInfo_JSON(t *testing.T) {
+	SetVersionInfo("1.4.0", "abc123", "2024-05-01")
+	t.Cleanup(func() { SetVersionInfo("dev", "none", "unknown") })
+
+	var out bytes.Buffer
+	versionCmd.SetOut(&out)
+	t.Cleanup(func() { versionCmd.SetOut(nil) })
+	flagVersionJSON = true
+	t.Cleanup(func() { flagVersionJSON = false })
+	if err := versionCmd.RunE(versionCmd, nil); err != nil {
+		t.Fatalf("version --json error = %v", err)
+	}
+
+	var doc map[string]string
+	if err := json.Unmarshal(out.Bytes(), &doc); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, out.String())
+	}
+
+	want := map[string]string{
+		"version":   "1.4.0",
+		"commit":    "abc123",
+		"date":      "2024-05-01",
+		"goVersion": runtime.Version(),
+		"os":        runtime.GOOS,
+		"arch":      runtime.GOARCH,
+	}
+	if len(doc) != len(want) {
+		t.Errorf("Unexpected keys in %v", doc)
+	}
+	for key, value := range want {
+		if doc[key] != value {
+			t.Errorf("%s = %q, want %q", key, doc[key], value)
+		}
+	}
+
+	t.Log("✓ Version JSON carries version, commit, date and platform")
+}
+
+// Test: The banner and update notice show the short commit and build day when known
+func TestBuildSummary(t *testing.T) {
+	tests := []struct {
+		commit, date string
+		want         string
+	}{
+		{"none", "unknown", ""},
+		{"0123456789abcdef", "unknown", "0123456"},
+		{"abc123", "2024-05-01T10:20:30Z", "abc123, 2024-05-01"},
+		{"none", "2024-05-01", "2024-05-01"},
+	}
+
+	for _, tt := range tests {
+		if got := buildSummary(tt.commit, tt.date); got != tt.want {
+			t.Errorf("buildSummary(%q, %q) = %q, want %q", tt.commit, tt.date, got, tt.want)
+		}
+	}
+
+	t.Log("✓ Build summary formatted")
+}
@@ -0,0 +1,146 @@
+package alias
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Alias maps a short name to a stored devcli invocation.
+type Alias struct {
+	Name string   `json:"name"`
+	Args []string `json:"args"`
+}
+
+// Store manages aliases on disk.
+type Store struct {
+	Aliases []Alias `json:"aliases"`
+	path    string
+}
+
+// Load reads the aliases file from ~/.devcli/aliases.json.
+func Load() (*Store, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(home, ".devcli")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, "aliases.json")
+	store := &Store{path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return store, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("invalid aliases file %s: %w", path, err)
+	}
+
+	return store, nil
+}
+
+// Save writes the aliases to disk.
+func (s *Store) Save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0644)
+}
+
+// Set creates or replaces an alias.
+func (s *Store) Set(name string, args []string) {
+	for i := range s.Aliases {
+		if s.Aliases[i].Name == name {
+			s.Aliases[i].Args = args
+			return
+		}
+	}
+	s.Aliases = append(s.Aliases, Alias{Name: name, Args: args})
+	sort.Slice(s.Aliases, func(i, j int) bool {
+		return s.Aliases[i].Name < s.Aliases[j].Name
+	})
+}
+
+// Get returns the alias with the given name, or nil.
+func (s *Store) Get(name string) *Alias {
+	for i := range s.Aliases {
+		if s.Aliases[i].Name == name {
+			return &s.Aliases[i]
+		}
+	}
+	return nil
+}
+
+// Remove deletes an alias. Returns false if it didn't exist.
+func (s *Store) Remove(name string) bool {
+	for i := range s.Aliases {
+		if s.Aliases[i].Name == name {
+			s.Aliases = append(s.Aliases[:i], s.Aliases[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Names returns all alias names, sorted.
+func (s *Store) Names() []string {
+	names := make([]string, len(s.Aliases))
+	for i, a := range s.Aliases {
+		names[i] = a.Name
+	}
+	return names
+}
+
+// SplitArgs splits a command line into arguments, honoring single and
+// double quotes (e.g. `deploy --input "msg=hello world"`).
+func SplitArgs(line string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	var quote rune
+	inArg := false
+
+	for _, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in %q", line)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
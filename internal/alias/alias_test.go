@@ -0,0 +1,63 @@
+package alias
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Test: Command lines are split honoring quotes
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		want    []string
+		wantErr bool
+	}{
+		{"Plain", "deploy --repo owner/api --branch main", []string{"deploy", "--repo", "owner/api", "--branch", "main"}, false},
+		{"Double quotes", `deploy --input "msg=hello world"`, []string{"deploy", "--input", "msg=hello world"}, false},
+		{"Single quotes", `connect --shell '/bin/sh -l'`, []string{"connect", "--shell", "/bin/sh -l"}, false},
+		{"Extra spaces", "  status   ", []string{"status"}, false},
+		{"Unterminated", `deploy --input "oops`, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SplitArgs(tt.line)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unexpected error state: %v", err)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Log("✓ Command lines split with quotes")
+}
+
+// Test: Set replaces existing aliases and Remove deletes them
+func TestStore_SetRemove(t *testing.T) {
+	s := &Store{}
+	s.Set("prod", []string{"deploy", "--branch", "main"})
+	s.Set("dev", []string{"connect", "--profile", "dev"})
+	s.Set("prod", []string{"deploy", "--branch", "release"})
+
+	if len(s.Aliases) != 2 {
+		t.Fatalf("Got %d aliases, want 2", len(s.Aliases))
+	}
+	if !reflect.DeepEqual(s.Names(), []string{"dev", "prod"}) {
+		t.Errorf("Names should be sorted: %v", s.Names())
+	}
+	if got := s.Get("prod").Args[2]; got != "release" {
+		t.Errorf("Set should replace existing alias, got %s", got)
+	}
+
+	if !s.Remove("dev") || s.Remove("dev") {
+		t.Errorf("Remove should succeed once")
+	}
+	if s.Get("dev") != nil {
+		t.Errorf("Removed alias still present")
+	}
+
+	t.Log("✓ Aliases set, replaced and removed")
+}
@@ -33,17 +33,40 @@ var requiredDeps = []Dependency{
 	},
 }
 
-// CheckDependencies verifies that all required CLI tools are installed.
-// If missing, offers to install them automatically on supported platforms.
-func CheckDependencies() error {
-	var missing []Dependency
+// RequiredDependencies returns the CLI tools needed to connect to ECS.
+func RequiredDependencies() []Dependency {
+	return requiredDeps
+}
 
+// MissingDependencies returns the required CLI tools not found in PATH,
+// without offering to install them.
+func MissingDependencies() []Dependency {
+	var missing []Dependency
 	for _, dep := range requiredDeps {
 		if _, err := exec.LookPath(dep.Check); err != nil {
 			missing = append(missing, dep)
 		}
 	}
+	return missing
+}
 
+// InstallHint returns the install command for the current platform, or the
+// documentation URL where no command is known.
+func (d Dependency) InstallHint() string {
+	switch runtime.GOOS {
+	case "darwin":
+		return d.InstallMac
+	case "linux":
+		return d.InstallLinux
+	default:
+		return d.InstallURL
+	}
+}
+
+// CheckDependencies verifies that all required CLI tools are installed.
+// If missing, offers to install them automatically on supported platforms.
+func CheckDependencies() error {
+	missing := MissingDependencies()
 	if len(missing) == 0 {
 		return nil
 	}
@@ -0,0 +1,52 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ecsErrorHints maps the error codes of ECS Exec sessions to remediation,
+// evaluated in order with case-insensitive matching.
+var ecsErrorHints = []struct {
+	markers []string
+	hint    string
+}{
+	{
+		markers: []string{"TargetNotConnected"},
+		hint: "The container is not connected to SSM. Check the task IAM role has ssmmessages:* permissions\n" +
+			"  (ssmmessages:CreateControlChannel, CreateDataChannel, OpenControlChannel, OpenDataChannel), then restart the task",
+	},
+	{
+		markers: []string{"ExecuteCommandDisabled", "execute command was not enabled"},
+		hint:    ExecDisabledHint("<cluster>", "<service>"),
+	},
+	{
+		markers: []string{"ThrottlingException", "Rate exceeded"},
+		hint:    "AWS is throttling ECS requests. Wait a few seconds and retry, backing off if it happens again",
+	},
+}
+
+// ExecDisabledHint is the remediation of a service whose tasks do not accept
+// ECS Exec sessions.
+func ExecDisabledHint(cluster, service string) string {
+	return "ECS Exec is disabled on the service. Enable it, then let the tasks be replaced:\n" +
+		fmt.Sprintf("  aws ecs update-service --cluster %s --service %s --enable-execute-command --force-new-deployment", cluster, service)
+}
+
+// InterpretECSError returns a user-friendly explanation with the remediation
+// of a known ECS Exec error, or "" when the error is not recognized.
+func InterpretECSError(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, h := range ecsErrorHints {
+		for _, m := range h.markers {
+			if strings.Contains(msg, strings.ToLower(m)) {
+				return h.hint
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,37 @@
+package aws
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Test: Known ECS Exec errors map to their remediation
+func TestInterpretECSError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"Agent not connected", errors.New("An error occurred (TargetNotConnectedException) when calling the ExecuteCommand operation"), "ssmmessages:*"},
+		{"Exec disabled", errors.New("InvalidParameterException: The execute command failed because execute command was not enabled when the task was run"), "--enable-execute-command"},
+		{"Exec disabled code", errors.New("ExecuteCommandDisabled"), "--enable-execute-command"},
+		{"Throttled", errors.New("ThrottlingException: Rate exceeded"), "retry"},
+		{"Unknown", errors.New("exit status 1"), ""},
+		{"No error", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := InterpretECSError(tt.err)
+			if tt.want == "" && got != "" {
+				t.Errorf("InterpretECSError() = %q, want none", got)
+			}
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("InterpretECSError() = %q, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Log("✓ ECS Exec errors interpreted")
+}
@@ -0,0 +1,56 @@
+package aws
+
+import (
+	"context"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/20uf/devcli/internal/verbose"
+)
+
+// identityCheckTimeout bounds the credential check of each profile, so an
+// unreachable endpoint or a prompting credential_process does not stall.
+const identityCheckTimeout = 2 * time.Second
+
+// maxIdentityChecks bounds the aws processes running at once: each one is a
+// full CLI start, and ~/.aws/config may list dozens of profiles.
+const maxIdentityChecks = 8
+
+// ValidProfiles returns the profiles whose credentials currently work, in
+// the given order. They are checked in parallel with sts get-caller-identity,
+// at most maxIdentityChecks at a time.
+func ValidProfiles(ctx context.Context, profiles []string) []string {
+	return validProfiles(ctx, profiles, identityCheckTimeout, maxIdentityChecks, callerIdentity)
+}
+
+func validProfiles(ctx context.Context, profiles []string, timeout time.Duration, limit int, check func(ctx context.Context, profile string) error) []string {
+	ok := make([]bool, len(profiles))
+	slots := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i, profile := range profiles {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			checkCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			ok[i] = check(checkCtx, profile) == nil
+		}()
+	}
+	wg.Wait()
+
+	var valid []string
+	for i, profile := range profiles {
+		if ok[i] {
+			valid = append(valid, profile)
+		}
+	}
+	return valid
+}
+
+// callerIdentity fails when the credentials of profile are missing or expired.
+func callerIdentity(ctx context.Context, profile string) error {
+	return verbose.Cmd(exec.CommandContext(ctx, "aws", "sts", "get-caller-identity", "--profile", profile)).Run()
+}
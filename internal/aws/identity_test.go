@@ -0,0 +1,57 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Test: Only the profiles whose check succeeds in time are kept, in order
+func TestValidProfiles(t *testing.T) {
+	check := func(ctx context.Context, profile string) error {
+		switch profile {
+		case "expired":
+			return errors.New("ExpiredToken")
+		case "slow":
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return nil
+	}
+
+	got := validProfiles(context.Background(), []string{"prod", "expired", "slow", "dev"}, 50*time.Millisecond, maxIdentityChecks, check)
+	if want := []string{"prod", "dev"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("validProfiles() = %v, want %v", got, want)
+	}
+
+	// No more checks than the limit run at once
+	var running, peak atomic.Int32
+	counting := func(ctx context.Context, profile string) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		return nil
+	}
+	profiles := make([]string, 20)
+	for i := range profiles {
+		profiles[i] = fmt.Sprintf("profile-%d", i)
+	}
+	if got := validProfiles(context.Background(), profiles, time.Second, 3, counting); len(got) != len(profiles) {
+		t.Errorf("validProfiles() kept %d profiles, want %d", len(got), len(profiles))
+	}
+	if peak.Load() > 3 {
+		t.Errorf("%d checks ran at once, want at most 3", peak.Load())
+	}
+
+	t.Log("✓ Valid profiles detected")
+}
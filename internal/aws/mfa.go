@@ -0,0 +1,106 @@
+package aws
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/20uf/devcli/internal/verbose"
+)
+
+// MFAProfile is the MFA setup of a profile signing in with IAM keys.
+type MFAProfile struct {
+	SerialNumber string
+	// RoleARN and SourceProfile are set when the profile assumes a role.
+	RoleARN       string
+	SourceProfile string
+}
+
+// ProfileMFA returns the MFA setup of profile, nil when the profile has no
+// mfa_serial or signs in with SSO.
+func ProfileMFA(profile string) (*MFAProfile, error) {
+	if profile == "" {
+		return nil, nil
+	}
+	section, err := profileSection(profile)
+	if err != nil || section.HasKey("sso_start_url") || section.HasKey("sso_session") {
+		return nil, nil
+	}
+
+	serial := section.Key("mfa_serial").String()
+	if serial == "" {
+		return nil, nil
+	}
+	return &MFAProfile{
+		SerialNumber:  serial,
+		RoleARN:       section.Key("role_arn").String(),
+		SourceProfile: section.Key("source_profile").String(),
+	}, nil
+}
+
+// mfaCache keeps the MFA credentials of each profile for the rest of the
+// process, so a token is asked once per session.
+var mfaCache = struct {
+	sync.Mutex
+	creds map[string]*Credentials
+}{creds: make(map[string]*Credentials)}
+
+// MFACredentials returns temporary credentials for an MFA profile, asking
+// the token code with promptCode. Profiles assuming a role get the role's
+// credentials, others a session token. Credentials are cached until shortly
+// before they expire.
+func MFACredentials(profile string, mfa *MFAProfile, promptCode func(serial string) (string, error)) (*Credentials, error) {
+	mfaCache.Lock()
+	defer mfaCache.Unlock()
+
+	if creds, ok := mfaCache.creds[profile]; ok && time.Until(creds.Expiration) > time.Minute {
+		return creds, nil
+	}
+
+	code, err := promptCode(mfa.SerialNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := verbose.Cmd(exec.Command("aws", mfaArgs(profile, mfa, code)...)).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("MFA sign-in failed for profile %s: %s", profile, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("MFA sign-in failed for profile %s: %w", profile, err)
+	}
+
+	creds, err := parseAssumeRoleOutput(out)
+	if err != nil {
+		return nil, err
+	}
+	mfaCache.creds[profile] = creds
+	return creds, nil
+}
+
+// mfaArgs builds the aws CLI call exchanging an MFA code for credentials:
+// assume-role from the source profile when the profile assumes a role,
+// get-session-token with the profile's keys otherwise.
+func mfaArgs(profile string, mfa *MFAProfile, code string) []string {
+	if mfa.RoleARN != "" {
+		args := []string{"sts", "assume-role",
+			"--role-arn", mfa.RoleARN,
+			"--role-session-name", fmt.Sprintf("devcli-%d", time.Now().Unix()),
+			"--serial-number", mfa.SerialNumber,
+			"--token-code", code,
+			"--output", "json",
+		}
+		if mfa.SourceProfile != "" {
+			args = append(args, "--profile", mfa.SourceProfile)
+		}
+		return args
+	}
+	return []string{"sts", "get-session-token",
+		"--serial-number", mfa.SerialNumber,
+		"--token-code", code,
+		"--output", "json",
+		"--profile", profile,
+	}
+}
@@ -0,0 +1,80 @@
+package aws
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Test: MFA devices are read from IAM profiles only
+func TestProfileMFA(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	config := `[profile keys]
+mfa_serial = arn:aws:iam::123456789012:mfa/alice
+
+[profile ops]
+role_arn = arn:aws:iam::123456789012:role/ops
+source_profile = keys
+mfa_serial = arn:aws:iam::123456789012:mfa/alice
+
+[profile sso]
+sso_start_url = https://example.awsapps.com/start
+mfa_serial = arn:aws:iam::123456789012:mfa/alice
+
+[profile plain]
+region = eu-west-1
+`
+	if err := os.WriteFile(path, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("AWS_CONFIG_FILE", path)
+
+	tests := []struct {
+		profile string
+		want    *MFAProfile
+	}{
+		{"keys", &MFAProfile{SerialNumber: "arn:aws:iam::123456789012:mfa/alice"}},
+		{"ops", &MFAProfile{SerialNumber: "arn:aws:iam::123456789012:mfa/alice", RoleARN: "arn:aws:iam::123456789012:role/ops", SourceProfile: "keys"}},
+		{"sso", nil},
+		{"plain", nil},
+		{"missing", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.profile, func(t *testing.T) {
+			got, err := ProfileMFA(tt.profile)
+			if err != nil {
+				t.Fatalf("ProfileMFA() error = %v", err)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("ProfileMFA() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	t.Log("✓ MFA profiles detected")
+}
+
+// Test: Role profiles assume the role with the code, others get a session token
+func TestMFAArgs(t *testing.T) {
+	keys := mfaArgs("keys", &MFAProfile{SerialNumber: "arn:mfa"}, "123456")
+	if keys[1] != "get-session-token" || !containsArgs(keys, "--token-code", "123456") || !containsArgs(keys, "--profile", "keys") {
+		t.Errorf("mfaArgs() for keys = %v", keys)
+	}
+
+	role := mfaArgs("ops", &MFAProfile{SerialNumber: "arn:mfa", RoleARN: "arn:role", SourceProfile: "keys"}, "123456")
+	if role[1] != "assume-role" || !containsArgs(role, "--role-arn", "arn:role") || !containsArgs(role, "--profile", "keys") {
+		t.Errorf("mfaArgs() for a role = %v", role)
+	}
+
+	t.Log("✓ MFA sign-in commands built")
+}
+
+func containsArgs(args []string, flag, value string) bool {
+	for i := 0; i+1 < len(args); i++ {
+		if args[i] == flag && args[i+1] == value {
+			return true
+		}
+	}
+	return false
+}
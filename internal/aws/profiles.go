@@ -13,15 +13,146 @@ import (
 // ErrNoConfigFile is returned when ~/.aws/config does not exist.
 var ErrNoConfigFile = errors.New("AWS config file not found")
 
+// ConfigPath returns the AWS config file location, honoring AWS_CONFIG_FILE.
+func ConfigPath() (string, error) {
+	if configPath := os.Getenv("AWS_CONFIG_FILE"); configPath != "" {
+		return configPath, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".aws", "config"), nil
+}
+
 // ListProfiles returns all profile names from ~/.aws/config.
 func ListProfiles() ([]string, error) {
-	configPath := os.Getenv("AWS_CONFIG_FILE")
-	if configPath == "" {
-		home, err := os.UserHomeDir()
+	return listProfiles(func(*ini.Section) bool { return true })
+}
+
+// ListSSOProfiles returns the profiles of ~/.aws/config that sign in with
+// IAM Identity Center, either directly or through an sso-session.
+func ListSSOProfiles() ([]string, error) {
+	return listProfiles(isSSOSection)
+}
+
+// credentialKeys are the profile settings that provide credentials without
+// IAM Identity Center.
+var credentialKeys = []string{"aws_access_key_id", "credential_process", "role_arn"}
+
+// ListUsableProfiles returns the profiles of ~/.aws/config that can get
+// credentials: SSO profiles, and profiles with keys, a credential_process or
+// a role to assume, in the config or in the shared credentials file.
+func ListUsableProfiles() ([]string, error) {
+	withKeys := credentialsProfiles()
+	return listProfiles(func(section *ini.Section) bool {
+		if isSSOSection(section) || withKeys[strings.TrimPrefix(section.Name(), "profile ")] {
+			return true
+		}
+		for _, key := range credentialKeys {
+			if section.HasKey(key) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// CredentialsPath returns the shared credentials file location, honoring
+// AWS_SHARED_CREDENTIALS_FILE.
+func CredentialsPath() (string, error) {
+	if path := os.Getenv("AWS_SHARED_CREDENTIALS_FILE"); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".aws", "credentials"), nil
+}
+
+// credentialsProfiles returns the profiles holding access keys in the shared
+// credentials file. A missing or unreadable file holds none.
+func credentialsProfiles() map[string]bool {
+	profiles := make(map[string]bool)
+	path, err := CredentialsPath()
+	if err != nil {
+		return profiles
+	}
+	cfg, err := ini.Load(path)
+	if err != nil {
+		return profiles
+	}
+	for _, section := range cfg.Sections() {
+		if section.HasKey("aws_access_key_id") {
+			profiles[section.Name()] = true
+		}
+	}
+	return profiles
+}
+
+// credentialsHasProfile reports whether the shared credentials file defines
+// a profile. A missing or unreadable file defines none.
+func credentialsHasProfile(name string) bool {
+	path, err := CredentialsPath()
+	if err != nil {
+		return false
+	}
+	cfg, err := ini.Load(path)
+	if err != nil {
+		return false
+	}
+	return cfg.HasSection(name)
+}
+
+// ProfileRegion returns the region configured for profile, or "" when it has
+// none or the config cannot be read.
+func ProfileRegion(profile string) string {
+	section, err := profileSection(profile)
+	if err != nil {
+		return ""
+	}
+	return section.Key("region").String()
+}
+
+// ProfileExists reports whether a profile is defined in ~/.aws/config or in
+// the shared credentials file, including the default profile.
+func ProfileExists(name string) (bool, error) {
+	if credentialsHasProfile(name) {
+		return true, nil
+	}
+	if name == "default" {
+		configPath, err := ConfigPath()
 		if err != nil {
-			return nil, err
+			return false, err
+		}
+		if _, err := os.Stat(configPath); os.IsNotExist(err) {
+			return false, ErrNoConfigFile
 		}
-		configPath = filepath.Join(home, ".aws", "config")
+		cfg, err := ini.Load(configPath)
+		if err != nil {
+			return false, err
+		}
+		return cfg.HasSection("default"), nil
+	}
+
+	profiles, err := ListProfiles()
+	if err != nil {
+		return false, err
+	}
+	for _, p := range profiles {
+		if p == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// listProfiles returns the sorted names of the named profiles matching keep.
+func listProfiles(keep func(*ini.Section) bool) ([]string, error) {
+	configPath, err := ConfigPath()
+	if err != nil {
+		return nil, err
 	}
 
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
@@ -36,7 +167,10 @@ func ListProfiles() ([]string, error) {
 	var profiles []string
 	for _, section := range cfg.Sections() {
 		name := section.Name()
-		if name == "DEFAULT" || name == "default" {
+		if name == "DEFAULT" || name == "default" || strings.HasPrefix(name, "sso-session ") {
+			continue
+		}
+		if !keep(section) {
 			continue
 		}
 		// AWS config uses "profile xxx" for named profiles
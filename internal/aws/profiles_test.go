@@ -0,0 +1,181 @@
+package aws
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// Test: SSO profiles are those with an sso_start_url or an sso_session
+func TestListSSOProfiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	config := `[default]
+region = eu-west-1
+
+[profile legacy]
+sso_start_url = https://example.awsapps.com/start
+sso_account_id = 123456789012
+
+[profile dev]
+sso_session = corp
+sso_account_id = 123456789012
+
+[profile keys]
+region = eu-west-1
+
+[sso-session corp]
+sso_start_url = https://example.awsapps.com/start
+`
+	if err := os.WriteFile(path, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("AWS_CONFIG_FILE", path)
+
+	got, err := ListSSOProfiles()
+	if err != nil {
+		t.Fatalf("ListSSOProfiles() error = %v", err)
+	}
+	if want := []string{"dev", "legacy"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ListSSOProfiles() = %v, want %v", got, want)
+	}
+
+	all, _ := ListProfiles()
+	if want := []string{"dev", "keys", "legacy"}; !reflect.DeepEqual(all, want) {
+		t.Errorf("ListProfiles() = %v, want %v", all, want)
+	}
+
+	t.Log("✓ SSO profiles listed")
+}
+
+// Test: Usable profiles are SSO ones and those with credentials or a role
+func TestListUsableProfiles(t *testing.T) {
+	dir := t.TempDir()
+	config := `[profile sso]
+sso_session = corp
+
+[profile process]
+credential_process = /usr/local/bin/creds
+
+[profile role]
+role_arn = arn:aws:iam::123456789012:role/ops
+source_profile = keys
+
+[profile keys]
+region = eu-west-1
+
+[profile dead]
+region = eu-west-1
+`
+	credentials := `[keys]
+aws_access_key_id = AKIAEXAMPLE
+aws_secret_access_key = secret
+
+[orphan]
+aws_access_key_id = AKIAEXAMPLE
+`
+	if err := os.WriteFile(filepath.Join(dir, "config"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "credentials"), []byte(credentials), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(dir, "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+
+	got, err := ListUsableProfiles()
+	if err != nil {
+		t.Fatalf("ListUsableProfiles() error = %v", err)
+	}
+	if want := []string{"keys", "process", "role", "sso"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ListUsableProfiles() = %v, want %v", got, want)
+	}
+
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "missing"))
+	got, _ = ListUsableProfiles()
+	if want := []string{"process", "role", "sso"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ListUsableProfiles() without credentials = %v, want %v", got, want)
+	}
+
+	t.Log("✓ Usable profiles listed")
+}
+
+// Test: The region of a profile is read from its section
+func TestProfileRegion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	config := `[default]
+region = us-east-1
+
+[profile dev]
+region = eu-west-1
+
+[profile bare]
+sso_session = corp
+`
+	if err := os.WriteFile(path, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("AWS_CONFIG_FILE", path)
+
+	tests := []struct {
+		profile string
+		want    string
+	}{
+		{"dev", "eu-west-1"},
+		{"default", "us-east-1"},
+		{"bare", ""},
+		{"unknown", ""},
+	}
+	for _, tt := range tests {
+		if got := ProfileRegion(tt.profile); got != tt.want {
+			t.Errorf("ProfileRegion(%q) = %q, want %q", tt.profile, got, tt.want)
+		}
+	}
+
+	t.Log("✓ Profile region read")
+}
+
+// Test: Profiles are looked up by name in the config and credentials files, the default one included
+func TestProfileExists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	config := `[default]
+region = eu-west-1
+
+[profile dev]
+sso_session = corp
+`
+	if err := os.WriteFile(path, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("AWS_CONFIG_FILE", path)
+	credentials := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(credentials, []byte("[ci]\naws_access_key_id = AKIA\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", credentials)
+
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"dev", true},
+		{"default", true},
+		{"ci", true},
+		{"prod", false},
+	}
+	for _, tt := range tests {
+		if got, err := ProfileExists(tt.name); err != nil || got != tt.want {
+			t.Errorf("ProfileExists(%q) = %v, %v, want %v", tt.name, got, err, tt.want)
+		}
+	}
+
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "missing"))
+	if _, err := ProfileExists("dev"); err != ErrNoConfigFile {
+		t.Errorf("ProfileExists() without config error = %v, want ErrNoConfigFile", err)
+	}
+	if got, err := ProfileExists("ci"); err != nil || !got {
+		t.Errorf("ProfileExists(ci) without config = %v, %v, want true", got, err)
+	}
+
+	t.Log("✓ Profile existence checked")
+}
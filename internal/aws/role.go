@@ -0,0 +1,78 @@
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/20uf/devcli/internal/verbose"
+)
+
+// Credentials are temporary credentials obtained by assuming an IAM role.
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	Expiration      time.Time
+}
+
+// AssumeRole assumes roleARN with the credentials of profile and returns the
+// temporary credentials of the new session.
+func AssumeRole(profile, roleARN string) (*Credentials, error) {
+	args := []string{"sts", "assume-role",
+		"--role-arn", roleARN,
+		"--role-session-name", fmt.Sprintf("devcli-%d", time.Now().Unix()),
+		"--output", "json",
+	}
+	if profile != "" {
+		args = append(args, "--profile", profile)
+	}
+
+	out, err := verbose.Cmd(exec.Command("aws", args...)).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("failed to assume role %s: %s", roleARN, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("failed to assume role %s: %w", roleARN, err)
+	}
+
+	return parseAssumeRoleOutput(out)
+}
+
+// parseAssumeRoleOutput reads the credentials of an `aws sts assume-role` response.
+func parseAssumeRoleOutput(payload []byte) (*Credentials, error) {
+	var resp struct {
+		Credentials struct {
+			AccessKeyID     string    `json:"AccessKeyId"`
+			SecretAccessKey string    `json:"SecretAccessKey"`
+			SessionToken    string    `json:"SessionToken"`
+			Expiration      time.Time `json:"Expiration"`
+		} `json:"Credentials"`
+	}
+	if err := json.Unmarshal(payload, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse assume-role response: %w", err)
+	}
+
+	c := resp.Credentials
+	if c.AccessKeyID == "" || c.SecretAccessKey == "" || c.SessionToken == "" {
+		return nil, fmt.Errorf("assume-role response has no credentials")
+	}
+
+	return &Credentials{
+		AccessKeyID:     c.AccessKeyID,
+		SecretAccessKey: c.SecretAccessKey,
+		SessionToken:    c.SessionToken,
+		Expiration:      c.Expiration,
+	}, nil
+}
+
+// Env returns the credentials as AWS_* environment variables for child processes.
+func (c *Credentials) Env() []string {
+	return []string{
+		"AWS_ACCESS_KEY_ID=" + c.AccessKeyID,
+		"AWS_SECRET_ACCESS_KEY=" + c.SecretAccessKey,
+		"AWS_SESSION_TOKEN=" + c.SessionToken,
+	}
+}
@@ -0,0 +1,41 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Test: temporary credentials are read from an assume-role response
+func TestParseAssumeRoleOutput(t *testing.T) {
+	payload := []byte(`{
+		"Credentials": {
+			"AccessKeyId": "ASIAEXAMPLE",
+			"SecretAccessKey": "secret",
+			"SessionToken": "token",
+			"Expiration": "2024-05-01T11:00:00+00:00"
+		},
+		"AssumedRoleUser": {"Arn": "arn:aws:sts::1234:assumed-role/ops/devcli-1"}
+	}`)
+
+	creds, err := parseAssumeRoleOutput(payload)
+	if err != nil {
+		t.Fatalf("parseAssumeRoleOutput() error = %v", err)
+	}
+	if creds.AccessKeyID != "ASIAEXAMPLE" || creds.SecretAccessKey != "secret" || creds.SessionToken != "token" {
+		t.Errorf("Unexpected credentials: %+v", creds)
+	}
+	if creds.Expiration.IsZero() {
+		t.Errorf("Expiration should be parsed")
+	}
+
+	want := []string{"AWS_ACCESS_KEY_ID=ASIAEXAMPLE", "AWS_SECRET_ACCESS_KEY=secret", "AWS_SESSION_TOKEN=token"}
+	if got := creds.Env(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Env() = %v, want %v", got, want)
+	}
+
+	if _, err := parseAssumeRoleOutput([]byte(`{"Credentials": {}}`)); err == nil {
+		t.Errorf("Response without credentials should fail")
+	}
+
+	t.Log("✓ Assume-role credentials parsed")
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"strings"
 
 	"github.com/20uf/devcli/internal/verbose"
@@ -13,31 +12,38 @@ import (
 
 // IsSSO returns true if the given profile uses SSO authentication.
 func IsSSO(profile string) bool {
-	configPath := os.Getenv("AWS_CONFIG_FILE")
-	if configPath == "" {
-		home, err := os.UserHomeDir()
-		if err != nil {
-			return false
-		}
-		configPath = filepath.Join(home, ".aws", "config")
+	section, err := profileSection(profile)
+	if err != nil {
+		return false
+	}
+	return isSSOSection(section)
+}
+
+// isSSOSection reports whether a profile section signs in with IAM Identity
+// Center, either directly or through an sso-session.
+func isSSOSection(section *ini.Section) bool {
+	return section.HasKey("sso_start_url") || section.HasKey("sso_session")
+}
+
+// profileSection returns the section of profile in the AWS config file.
+func profileSection(profile string) (*ini.Section, error) {
+	configPath, err := ConfigPath()
+	if err != nil {
+		return nil, err
 	}
 
 	cfg, err := ini.Load(configPath)
 	if err != nil {
-		return false
+		return nil, err
 	}
 
 	sectionName := "profile " + profile
 	section, err := cfg.GetSection(sectionName)
 	if err != nil {
 		// Try without "profile " prefix (for [default])
-		section, err = cfg.GetSection(profile)
-		if err != nil {
-			return false
-		}
+		return cfg.GetSection(profile)
 	}
-
-	return section.HasKey("sso_start_url") || section.HasKey("sso_session")
+	return section, nil
 }
 
 // EnsureSSOLogin checks if the SSO session is valid. If not, triggers aws sso login.
@@ -90,6 +96,31 @@ func FormatSSOError(err error, profile string) string {
 	return msg
 }
 
+// ssoExpiryMarkers are the messages of the aws CLI and SDK when the SSO
+// token of a profile has expired, as opposed to other credential failures.
+var ssoExpiryMarkers = []string{
+	"token has expired",
+	"sso session",
+	"unauthorizedssotoken",
+	"sso token",
+	"expiredtoken",
+}
+
+// IsSSOExpired reports whether err comes from an expired SSO session, which
+// a new aws sso login fixes.
+func IsSSOExpired(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, marker := range ssoExpiryMarkers {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
+
 // ForceSSOLogin triggers SSO login unconditionally (skips the identity check).
 func ForceSSOLogin(profile string) error {
 	if profile == "" {
@@ -0,0 +1,32 @@
+package aws
+
+import (
+	"errors"
+	"testing"
+)
+
+// Test: Only expired SSO sessions are reported as such
+func TestIsSSOExpired(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"CLI token expired", errors.New("Error when retrieving token from sso: Token has expired and refresh failed"), true},
+		{"SDK unauthorized token", errors.New("operation error SSO: GetRoleCredentials, UnauthorizedSSOToken: Session token not found or invalid"), true},
+		{"Expired STS token", errors.New("An error occurred (ExpiredToken) when calling the ExecuteCommand operation"), true},
+		{"Access denied", errors.New("AccessDeniedException: not authorized to perform ecs:ExecuteCommand"), false},
+		{"Session exit", errors.New("exit status 1"), false},
+		{"No error", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsSSOExpired(tt.err); got != tt.want {
+				t.Errorf("IsSSOExpired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Log("✓ SSO expiry detected")
+}
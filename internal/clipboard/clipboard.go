@@ -0,0 +1,60 @@
+package clipboard
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/20uf/devcli/internal/verbose"
+)
+
+// ErrUnavailable is returned when no clipboard tool is installed.
+var ErrUnavailable = errors.New("no clipboard tool found.\n  Supported: pbcopy, wl-copy, xclip, xsel, clip.exe")
+
+// Copy puts text on the system clipboard.
+func Copy(text string) error {
+	line, err := command(runtime.GOOS, os.Getenv, exec.LookPath)
+	if err != nil {
+		return err
+	}
+
+	cmd := verbose.Cmd(exec.Command(line[0], line[1:]...))
+	cmd.Stdin = strings.NewReader(text)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to copy to the clipboard: %w", err)
+	}
+	return nil
+}
+
+// command resolves the clipboard tool of the platform. On Linux, wl-copy is
+// preferred under Wayland, then the X11 tools, then clip.exe under WSL.
+func command(goos string, getenv func(string) string, lookPath func(string) (string, error)) ([]string, error) {
+	installed := func(name string) bool {
+		_, err := lookPath(name)
+		return err == nil
+	}
+
+	switch goos {
+	case "darwin":
+		return []string{"pbcopy"}, nil
+	case "windows":
+		return []string{"clip.exe"}, nil
+	case "linux":
+		if getenv("WAYLAND_DISPLAY") != "" && installed("wl-copy") {
+			return []string{"wl-copy"}, nil
+		}
+		if installed("xclip") {
+			return []string{"xclip", "-selection", "clipboard"}, nil
+		}
+		if installed("xsel") {
+			return []string{"xsel", "--clipboard", "--input"}, nil
+		}
+		if installed("clip.exe") {
+			return []string{"clip.exe"}, nil
+		}
+	}
+	return nil, ErrUnavailable
+}
@@ -0,0 +1,53 @@
+package clipboard
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// Test: the clipboard tool is resolved from the platform, environment and PATH
+func TestCommand(t *testing.T) {
+	tests := []struct {
+		name      string
+		goos      string
+		env       map[string]string
+		available []string
+		want      []string
+	}{
+		{"macOS", "darwin", nil, nil, []string{"pbcopy"}},
+		{"Windows", "windows", nil, nil, []string{"clip.exe"}},
+		{"Linux Wayland", "linux", map[string]string{"WAYLAND_DISPLAY": "wayland-0"}, []string{"wl-copy", "xclip"}, []string{"wl-copy"}},
+		{"Linux X11 with xclip", "linux", nil, []string{"wl-copy", "xclip"}, []string{"xclip", "-selection", "clipboard"}},
+		{"Linux X11 with xsel", "linux", nil, []string{"xsel"}, []string{"xsel", "--clipboard", "--input"}},
+		{"WSL", "linux", nil, []string{"clip.exe"}, []string{"clip.exe"}},
+		{"Linux without tool", "linux", nil, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+			lookPath := func(name string) (string, error) {
+				for _, a := range tt.available {
+					if a == name {
+						return "/usr/bin/" + name, nil
+					}
+				}
+				return "", errors.New("not found")
+			}
+
+			got, err := command(tt.goos, getenv, lookPath)
+			if tt.want == nil {
+				if !errors.Is(err, ErrUnavailable) {
+					t.Errorf("command() error = %v, want ErrUnavailable", err)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("command() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+
+	t.Log("✓ Clipboard tool detected")
+}
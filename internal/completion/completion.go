@@ -0,0 +1,82 @@
+package completion
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// TTL is how long listed values complete without asking GitHub again. Each
+// tab press is a new process, so the cache lives on disk.
+const TTL = 5 * time.Minute
+
+// Entry is a list of completion values and when it was fetched.
+type Entry struct {
+	Values    []string  `json:"values"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// Store caches completion values on disk, keyed by what was listed
+// (e.g. "repos" or "workflows:owner/api").
+type Store struct {
+	Entries map[string]Entry `json:"entries"`
+	path    string
+}
+
+// Load reads the completion cache from ~/.devcli/completion.json.
+func Load() (*Store, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(home, ".devcli")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, "completion.json")
+	store := &Store{path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return store, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, store); err != nil {
+		return store, nil
+	}
+
+	return store, nil
+}
+
+// Save writes the completion cache to disk.
+func (s *Store) Save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0644)
+}
+
+// Get returns the values cached under key if they were fetched less than
+// TTL before now.
+func (s *Store) Get(key string, now time.Time) ([]string, bool) {
+	entry, ok := s.Entries[key]
+	if !ok || now.Sub(entry.FetchedAt) >= TTL {
+		return nil, false
+	}
+	return entry.Values, true
+}
+
+// Set caches the values listed for key.
+func (s *Store) Set(key string, values []string, now time.Time) {
+	if s.Entries == nil {
+		s.Entries = make(map[string]Entry)
+	}
+	s.Entries[key] = Entry{Values: values, FetchedAt: now}
+}
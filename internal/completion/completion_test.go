@@ -0,0 +1,51 @@
+package completion
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Test: cached values are served until the TTL expires, across loads
+func TestStore_TTL(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	store, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	store.Set("workflows:owner/api", []string{"deploy.yml", "release.yml"}, now)
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	reloaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		key    string
+		at     time.Time
+		wantOK bool
+	}{
+		{"Fresh", "workflows:owner/api", now.Add(time.Minute), true},
+		{"Expired", "workflows:owner/api", now.Add(TTL), false},
+		{"Unknown key", "repos", now, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, ok := reloaded.Get(tt.key, tt.at)
+			if ok != tt.wantOK {
+				t.Fatalf("Get() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && !reflect.DeepEqual(values, []string{"deploy.yml", "release.yml"}) {
+				t.Errorf("Get() = %v", values)
+			}
+		})
+	}
+
+	t.Log("✓ Completion cache expires after its TTL")
+}
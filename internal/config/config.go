@@ -0,0 +1,252 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds user preferences read from ~/.devcli/config.yaml.
+// Every key is optional: commands fall back to their built-in defaults.
+type Config struct {
+	// GitHubHost targets a GitHub Enterprise instance (e.g. "github.example.com").
+	GitHubHost string  `yaml:"github_host,omitempty"`
+	Deploy     Deploy  `yaml:"deploy,omitempty"`
+	Connect    Connect `yaml:"connect,omitempty"`
+	// Environments maps environment names (dev, staging, prod...) to AWS
+	// profiles, for connect --env. The first listed is the default profile.
+	Environments Environments `yaml:"environments,omitempty"`
+
+	path string
+}
+
+// Deploy holds preferences for the deploy command.
+type Deploy struct {
+	RepoLimit   int `yaml:"repo_limit,omitempty"`
+	BranchLimit int `yaml:"branch_limit,omitempty"`
+	// SensitiveInputKeys extends the names of inputs whose values are masked
+	// in verbose output and history (token, secret, password, key...).
+	SensitiveInputKeys []string `yaml:"sensitive_input_keys,omitempty"`
+	// CopyURL copies the web URL of each triggered run to the clipboard.
+	CopyURL bool `yaml:"copy_url,omitempty"`
+}
+
+// Connect holds preferences for the connect command.
+type Connect struct {
+	// Profiles holds per AWS profile settings, keyed by profile name.
+	Profiles map[string]Profile `yaml:"profiles,omitempty"`
+	// Templates holds reusable exec commands keyed by name. Placeholders such
+	// as {service} or {container} are expanded against the connection target.
+	Templates map[string]string `yaml:"templates,omitempty"`
+	// Shells maps cluster/service glob patterns to the shell opened when
+	// --shell is not given. The first matching pattern wins.
+	Shells ShellRules `yaml:"shells,omitempty"`
+	// ShellPreference lists the shells preferred by --shell-detect, in order.
+	ShellPreference []string `yaml:"shell_preference,omitempty"`
+	// DefaultShell replaces the built-in default shell for every service
+	// without a more specific setting.
+	DefaultShell string `yaml:"default_shell,omitempty"`
+	// PreferredContainers lists the container names auto-selected in a task
+	// with several containers (default php, app, web, api, worker).
+	PreferredContainers []string `yaml:"preferred_containers,omitempty"`
+}
+
+// ShellRule is the shell used for the services matching a cluster/service
+// glob pattern, e.g. "prod/laravel-*".
+type ShellRule struct {
+	Pattern string
+	Shell   string
+}
+
+// ShellRules is an ordered list of shell rules, written in YAML as a mapping
+// from pattern to shell. The order of the file is kept so that the first
+// match is the first rule listed.
+type ShellRules []ShellRule
+
+// UnmarshalYAML reads the mapping in file order.
+func (r *ShellRules) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: shells must map cluster/service patterns to shells", node.Line)
+	}
+	rules := make(ShellRules, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		pattern, shell := node.Content[i].Value, node.Content[i+1].Value
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("line %d: invalid shell pattern %q: %w", node.Content[i].Line, pattern, err)
+		}
+		rules = append(rules, ShellRule{Pattern: pattern, Shell: shell})
+	}
+	*r = rules
+	return nil
+}
+
+// MarshalYAML writes the rules back as a mapping, in order.
+func (r ShellRules) MarshalYAML() (any, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, rule := range r {
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: rule.Pattern},
+			&yaml.Node{Kind: yaml.ScalarNode, Value: rule.Shell},
+		)
+	}
+	return node, nil
+}
+
+// Environment maps an environment name to the AWS profile reaching it.
+type Environment struct {
+	Name    string
+	Profile string
+}
+
+// Environments is an ordered list of environments, written in YAML as a
+// mapping from name to profile. The order of the file is kept as the
+// priority of the default profile.
+type Environments []Environment
+
+// UnmarshalYAML reads the mapping in file order.
+func (e *Environments) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: environments must map environment names to AWS profiles", node.Line)
+	}
+	envs := make(Environments, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		envs = append(envs, Environment{Name: node.Content[i].Value, Profile: node.Content[i+1].Value})
+	}
+	*e = envs
+	return nil
+}
+
+// MarshalYAML writes the environments back as a mapping, in order.
+func (e Environments) MarshalYAML() (any, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, env := range e {
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: env.Name},
+			&yaml.Node{Kind: yaml.ScalarNode, Value: env.Profile},
+		)
+	}
+	return node, nil
+}
+
+// Profile returns the AWS profile of the named environment.
+func (e Environments) Profile(name string) (string, bool) {
+	for _, env := range e {
+		if env.Name == name {
+			return env.Profile, true
+		}
+	}
+	return "", false
+}
+
+// Names returns the environment names in file order.
+func (e Environments) Names() []string {
+	names := make([]string, len(e))
+	for i, env := range e {
+		names[i] = env.Name
+	}
+	return names
+}
+
+// Profile holds settings applied when connecting with an AWS profile.
+type Profile struct {
+	// RoleARN is an IAM role assumed before calling ECS.
+	RoleARN string `yaml:"role_arn,omitempty"`
+	// Shell is the default shell of the services reached with the profile.
+	Shell string `yaml:"shell,omitempty"`
+}
+
+// Load reads the config file from ~/.devcli/config.yaml.
+// A missing file yields an empty config.
+func Load() (*Config, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(home, ".devcli", "config.yaml")
+	cfg := &Config{path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Save writes the config back to ~/.devcli/config.yaml.
+func (c *Config) Save() error {
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, data, 0644)
+}
+
+// RoleARN returns the IAM role configured for an AWS profile, if any.
+func (c *Config) RoleARN(profile string) string {
+	return c.Connect.Profiles[profile].RoleARN
+}
+
+// SetRoleARN records the IAM role to assume for an AWS profile.
+func (c *Config) SetRoleARN(profile, roleARN string) {
+	if c.Connect.Profiles == nil {
+		c.Connect.Profiles = make(map[string]Profile)
+	}
+	p := c.Connect.Profiles[profile]
+	p.RoleARN = roleARN
+	c.Connect.Profiles[profile] = p
+}
+
+// ServiceShell returns the shell of the first connect.shells pattern matching
+// cluster/service.
+func (c *Config) ServiceShell(cluster, service string) (string, bool) {
+	target := cluster + "/" + service
+	for _, rule := range c.Connect.Shells {
+		if ok, _ := path.Match(rule.Pattern, target); ok {
+			return rule.Shell, true
+		}
+	}
+	return "", false
+}
+
+// DefaultShell returns the default shell for a profile: its
+// connect.profiles.<profile>.shell, then connect.default_shell.
+func (c *Config) DefaultShell(profile string) (string, bool) {
+	if shell := c.Connect.Profiles[profile].Shell; shell != "" {
+		return shell, true
+	}
+	if c.Connect.DefaultShell != "" {
+		return c.Connect.DefaultShell, true
+	}
+	return "", false
+}
+
+// CommandTemplate returns the exec command template registered under name.
+func (c *Config) CommandTemplate(name string) (string, bool) {
+	tmpl, ok := c.Connect.Templates[name]
+	return tmpl, ok
+}
+
+// ResolveGitHubHost returns the GitHub host to target. The GH_HOST environment
+// variable, also honored by gh, takes precedence over the github_host key.
+func (c *Config) ResolveGitHubHost() string {
+	if host := os.Getenv("GH_HOST"); host != "" {
+		return host
+	}
+	return c.GitHubHost
+}
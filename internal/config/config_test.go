@@ -0,0 +1,161 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Test: a role ARN saved for a profile is read back from config.yaml
+func TestSaveRoleARN(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	cfg.SetRoleARN("prod", "arn:aws:iam::1234:role/ops")
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(home, ".devcli", "config.yaml")); err != nil {
+		t.Fatalf("config.yaml not written: %v", err)
+	}
+
+	reloaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := reloaded.RoleARN("prod"); got != "arn:aws:iam::1234:role/ops" {
+		t.Errorf("RoleARN(prod) = %q", got)
+	}
+	if got := reloaded.RoleARN("dev"); got != "" {
+		t.Errorf("RoleARN(dev) = %q, want empty", got)
+	}
+
+	t.Log("✓ Role ARN persisted per profile")
+}
+
+// Test: service shells match cluster/service patterns in file order
+func TestServiceShell(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, ".devcli"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	data := "connect:\n  shells:\n    \"prod/laravel-*\": su -s /bin/sh www-data\n    \"*/node-*\": /bin/sh\n    \"*/*\": /bin/bash\n"
+	if err := os.WriteFile(filepath.Join(home, ".devcli", "config.yaml"), []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	tests := []struct {
+		cluster, service string
+		want             string
+	}{
+		{"prod", "laravel-api", "su -s /bin/sh www-data"},
+		{"staging", "node-front", "/bin/sh"},
+		{"staging", "laravel-api", "/bin/bash"},
+	}
+	for _, tt := range tests {
+		if got, ok := cfg.ServiceShell(tt.cluster, tt.service); !ok || got != tt.want {
+			t.Errorf("ServiceShell(%s, %s) = %q, %v, want %q", tt.cluster, tt.service, got, ok, tt.want)
+		}
+	}
+
+	// Order survives a save
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reloaded.Connect.Shells; len(got) != 3 || got[0].Pattern != "prod/laravel-*" || got[2].Shell != "/bin/bash" {
+		t.Errorf("reloaded rules = %v", got)
+	}
+
+	t.Log("✓ First matching service shell wins")
+}
+
+// Test: a malformed pattern is reported when loading the config
+func TestServiceShell_InvalidPattern(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, ".devcli"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	data := "connect:\n  shells:\n    \"prod/[\": /bin/sh\n"
+	if err := os.WriteFile(filepath.Join(home, ".devcli", "config.yaml"), []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(); err == nil {
+		t.Error("Load() should reject an invalid pattern")
+	}
+
+	t.Log("✓ Invalid pattern rejected")
+}
+
+// Test: the profile shell wins over connect.default_shell
+func TestDefaultShell(t *testing.T) {
+	cfg := &Config{Connect: Connect{
+		DefaultShell: "/bin/sh",
+		Profiles:     map[string]Profile{"dev": {Shell: "/bin/bash"}},
+	}}
+
+	if got, ok := cfg.DefaultShell("dev"); !ok || got != "/bin/bash" {
+		t.Errorf("DefaultShell(dev) = %q, %v, want /bin/bash", got, ok)
+	}
+	if got, ok := cfg.DefaultShell("prod"); !ok || got != "/bin/sh" {
+		t.Errorf("DefaultShell(prod) = %q, %v, want /bin/sh", got, ok)
+	}
+	if _, ok := (&Config{}).DefaultShell("prod"); ok {
+		t.Error("DefaultShell() without config should not be set")
+	}
+
+	t.Log("✓ Default shell resolved per profile")
+}
+
+// Test: environments resolve to their profile and keep the file order
+func TestEnvironments(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, ".devcli"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	data := "environments:\n  prod: acme-prod\n  dev: acme-dev\n"
+	if err := os.WriteFile(filepath.Join(home, ".devcli", "config.yaml"), []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got, ok := cfg.Environments.Profile("dev"); !ok || got != "acme-dev" {
+		t.Errorf("Profile(dev) = %q, %v, want acme-dev", got, ok)
+	}
+	if _, ok := cfg.Environments.Profile("staging"); ok {
+		t.Error("Profile(staging) found, want none")
+	}
+
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reloaded.Environments.Names(); len(got) != 2 || got[0] != "prod" || got[1] != "dev" {
+		t.Errorf("reloaded environments = %v, want [prod dev]", got)
+	}
+
+	t.Log("✓ Environments mapped to profiles in order")
+}
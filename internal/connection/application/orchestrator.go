@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/20uf/devcli/internal/connection/domain"
+	"github.com/20uf/devcli/internal/telemetry"
 )
 
 // ConnectOrchestrator is the main use case for initiating a connection to an ECS container.
@@ -33,7 +34,9 @@ func (o *ConnectOrchestrator) SelectCluster(ctx context.Context, req SelectClust
 	}
 
 	// List available clusters
-	clusters, err := o.repos.Clusters.ListClusters(ctx)
+	listCtx, span := telemetry.Start(ctx, "cluster-list")
+	clusters, err := o.repos.Clusters.ListClusters(listCtx)
+	telemetry.End(span, err)
 	if err != nil {
 		return domain.Cluster{}, err
 	}
@@ -58,7 +61,9 @@ func (o *ConnectOrchestrator) SelectService(ctx context.Context, req SelectServi
 		return domain.NewService(*req.ServiceName)
 	}
 
-	services, err := o.repos.Services.ListServices(ctx, req.Cluster)
+	listCtx, span := telemetry.Start(ctx, "service-list", telemetry.ClusterKey.String(req.Cluster.Name()))
+	services, err := o.repos.Services.ListServices(listCtx, req.Cluster)
+	telemetry.End(span, err)
 	if err != nil {
 		return domain.Service{}, err
 	}
@@ -94,7 +99,7 @@ type SelectContainerRequest struct {
 // SelectContainer selects a container within a task.
 // Strategy:
 // 1. If ContainerName is provided, use it directly
-// 2. If task has a preferred container (php, app, web, api), use it
+// 2. If task has a preferred container (php, app, web, api, worker by default), use it
 // 3. If task has only one container, use it
 // 4. Otherwise, delegate to UI layer (return all containers)
 func (o *ConnectOrchestrator) SelectContainer(ctx context.Context, req SelectContainerRequest) (domain.Container, error) {
@@ -155,12 +160,16 @@ type ConnectRequest struct {
 
 // Connect is the main orchestration flow: cluster → service → task → container.
 // This is a complete use case that guides through the entire selection process.
-func (o *ConnectOrchestrator) Connect(ctx context.Context, req ConnectRequest) (domain.Connection, error) {
+func (o *ConnectOrchestrator) Connect(ctx context.Context, req ConnectRequest) (conn domain.Connection, err error) {
+	ctx, span := telemetry.Start(ctx, "connect")
+	defer func() { telemetry.End(span, err) }()
+
 	// Step 1: Select cluster
 	cluster, err := o.SelectCluster(ctx, SelectClusterRequest{ClusterName: req.ClusterName})
 	if err != nil {
 		return domain.Connection{}, fmt.Errorf("cluster selection failed: %w", err)
 	}
+	span.SetAttributes(telemetry.ClusterKey.String(cluster.Name()))
 
 	// Step 2: Select service
 	service, err := o.SelectService(ctx, SelectServiceRequest{
@@ -170,6 +179,7 @@ func (o *ConnectOrchestrator) Connect(ctx context.Context, req ConnectRequest) (
 	if err != nil {
 		return domain.Connection{}, fmt.Errorf("service selection failed: %w", err)
 	}
+	span.SetAttributes(telemetry.ServiceKey.String(service.Name()))
 
 	// Step 3: Select task
 	task, err := o.SelectTask(ctx, SelectTaskRequest{
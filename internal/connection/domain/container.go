@@ -1,17 +1,36 @@
 package domain
 
+// DefaultPreferredContainers are the names of the containers preferred
+// when connect.preferred_containers is not configured.
+var DefaultPreferredContainers = []string{"php", "app", "web", "api", "worker"}
+
 // Container represents a Docker container in an ECS task (value object).
 // Containers are identified by their name within a task.
 type Container struct {
-	name string
+	name      string
+	preferred bool
 }
 
-// NewContainer creates a new Container value object.
+// NewContainer creates a new Container value object, preferred when its name
+// is one of DefaultPreferredContainers.
 func NewContainer(name string) (Container, error) {
+	return NewContainerWithPreferred(name, DefaultPreferredContainers)
+}
+
+// NewContainerWithPreferred creates a Container that is preferred when its
+// name is one of preferredNames.
+func NewContainerWithPreferred(name string, preferredNames []string) (Container, error) {
 	if name == "" {
 		return Container{}, ErrInvalidContainer
 	}
-	return Container{name: name}, nil
+	container := Container{name: name}
+	for _, preferred := range preferredNames {
+		if name == preferred {
+			container.preferred = true
+			break
+		}
+	}
+	return container, nil
 }
 
 // Name returns the container name.
@@ -34,19 +53,17 @@ func (c Container) MarshalText() ([]byte, error) {
 	return []byte(c.name), nil
 }
 
-// UnmarshalText implements encoding.TextUnmarshaler.
+// UnmarshalText implements encoding.TextUnmarshaler. The preference is not
+// part of the text form: a decoded container is not preferred until it is
+// rebuilt with NewContainerWithPreferred and the configured names.
 func (c *Container) UnmarshalText(text []byte) error {
 	var err error
-	*c, err = NewContainer(string(text))
+	*c, err = NewContainerWithPreferred(string(text), nil)
 	return err
 }
 
-// IsPreferred returns true if this container matches common development container names.
+// IsPreferred returns true if this container matches the preferred
+// development container names it was created with.
 func (c Container) IsPreferred() bool {
-	switch c.name {
-	case "php", "app", "web", "api":
-		return true
-	default:
-		return false
-	}
+	return c.preferred
 }
@@ -3,7 +3,9 @@ package domain
 // Service represents an ECS service (value object).
 // A service is identified by its name within a cluster.
 type Service struct {
-	name string
+	name           string
+	taskDefinition string
+	image          string
 }
 
 // NewService creates a new Service value object.
@@ -19,6 +21,24 @@ func (s Service) Name() string {
 	return s.name
 }
 
+// WithDeployment returns a copy of the service describing the task definition
+// (family:revision) and primary container image it currently runs.
+func (s Service) WithDeployment(taskDefinition, image string) Service {
+	s.taskDefinition = taskDefinition
+	s.image = image
+	return s
+}
+
+// TaskDefinition returns the current task definition as family:revision.
+func (s Service) TaskDefinition() string {
+	return s.taskDefinition
+}
+
+// Image returns the image of the primary container.
+func (s Service) Image() string {
+	return s.image
+}
+
 // String returns the service name.
 func (s Service) String() string {
 	return s.name
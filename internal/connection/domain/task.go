@@ -71,7 +71,7 @@ func (t Task) FindContainerByName(name string) (Container, error) {
 }
 
 // SelectContainer selects the best container from the task.
-// Prefers containers with preferred names (php, app, web, api).
+// Prefers containers with preferred names (see DefaultPreferredContainers).
 // Returns error if no containers are available.
 func (t Task) SelectContainer() (Container, error) {
 	if len(t.containers) == 0 {
@@ -9,11 +9,18 @@ import (
 
 // ECSMapper translates between AWS ECS API objects and domain entities.
 // This is the anti-corruption layer that shields the domain from AWS SDK changes.
-type ECSMapper struct{}
+type ECSMapper struct {
+	preferredContainers []string
+}
 
-// NewECSMapper creates a new mapper instance.
-func NewECSMapper() *ECSMapper {
-	return &ECSMapper{}
+// NewECSMapper creates a new mapper instance. Mapped containers are preferred
+// when named after one of preferredContainers, domain.DefaultPreferredContainers
+// when none are given.
+func NewECSMapper(preferredContainers ...string) *ECSMapper {
+	if len(preferredContainers) == 0 {
+		preferredContainers = domain.DefaultPreferredContainers
+	}
+	return &ECSMapper{preferredContainers: preferredContainers}
 }
 
 // MapClusterARNToCluster extracts a cluster name from an ARN and returns a domain Cluster.
@@ -30,6 +37,15 @@ func (m *ECSMapper) MapServiceARNToService(arn string) (domain.Service, error) {
 	return domain.NewService(name)
 }
 
+// MapTaskDefinitionARN returns the family:revision of a task definition ARN.
+// ARN format: arn:aws:ecs:region:account-id:task-definition/family:revision
+func (m *ECSMapper) MapTaskDefinitionARN(arn string) string {
+	if arn == "" {
+		return ""
+	}
+	return extractNameFromARN(arn)
+}
+
 // MapECSTaskToTask converts an AWS ECS Task to a domain Task entity.
 // Extracts the task ID from the ARN and maps all containers.
 func (m *ECSMapper) MapECSTaskToTask(ecsTask *types.Task) (domain.Task, error) {
@@ -41,7 +57,7 @@ func (m *ECSMapper) MapECSTaskToTask(ecsTask *types.Task) (domain.Task, error) {
 	if ecsTask.Containers != nil {
 		for _, c := range ecsTask.Containers {
 			if c.Name != nil && *c.Name != "" {
-				container, err := domain.NewContainer(*c.Name)
+				container, err := domain.NewContainerWithPreferred(*c.Name, m.preferredContainers)
 				if err != nil {
 					return domain.Task{}, err
 				}
@@ -0,0 +1,48 @@
+package infra
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+// Test: Mapped containers are preferred after the configured names
+func TestECSMapper_PreferredContainers(t *testing.T) {
+	ecsTask := &types.Task{
+		TaskArn: aws.String("arn:aws:ecs:eu-west-1:123456789012:task/prod/abc123"),
+		Containers: []types.Container{
+			{Name: aws.String("nginx")},
+			{Name: aws.String("worker")},
+			{Name: aws.String("sidecar")},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		preferred []string
+		want      string
+	}{
+		{"Default names", nil, "worker"},
+		{"Configured names", []string{"sidecar"}, "sidecar"},
+		{"No preferred container", []string{"php"}, "nginx"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task, err := NewECSMapper(tt.preferred...).MapECSTaskToTask(ecsTask)
+			if err != nil {
+				t.Fatalf("MapECSTaskToTask() error = %v", err)
+			}
+			container, err := task.SelectContainer()
+			if err != nil {
+				t.Fatalf("SelectContainer() error = %v", err)
+			}
+			if container.Name() != tt.want {
+				t.Errorf("SelectContainer() = %s, want %s", container.Name(), tt.want)
+			}
+		})
+	}
+
+	t.Log("✓ Preferred containers configurable")
+}
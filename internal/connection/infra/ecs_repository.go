@@ -6,6 +6,7 @@ import (
 	"sort"
 
 	"github.com/20uf/devcli/internal/connection/domain"
+	ecsclient "github.com/20uf/devcli/internal/ecs"
 	"github.com/20uf/devcli/internal/verbose"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ecs"
@@ -123,20 +124,45 @@ func (r *ECSServiceRepository) ListServices(ctx context.Context, cluster domain.
 		return nil, domain.ErrNoServiceFound
 	}
 
+	// Deployment details are informative only: keep the names on failure
+	if err := r.describeDeployments(ctx, cluster, services); err != nil {
+		verbose.Log("ecs:DescribeServices failed: %v", err)
+	}
+
 	return services, nil
 }
 
+// describeDeployments attaches the current task definition and primary image to services.
+func (r *ECSServiceRepository) describeDeployments(ctx context.Context, cluster domain.Cluster, services []domain.Service) error {
+	names := make([]string, 0, len(services))
+	for _, s := range services {
+		names = append(names, s.Name())
+	}
+
+	deployments, err := ecsclient.DescribeDeployments(ctx, r.client, cluster.Name(), names)
+	if err != nil {
+		return fmt.Errorf("failed to describe services: %w", err)
+	}
+	for i, service := range services {
+		if d, ok := deployments[service.Name()]; ok {
+			services[i] = service.WithDeployment(r.mapper.MapTaskDefinitionARN(d.TaskDefinitionARN), d.Image)
+		}
+	}
+	return nil
+}
+
 // ECSTaskRepository implements domain.TaskRepository using AWS ECS SDK.
 type ECSTaskRepository struct {
 	client *ecs.Client
 	mapper *ECSMapper
 }
 
-// NewECSTaskRepository creates a new ECS task repository.
-func NewECSTaskRepository(client *ecs.Client) *ECSTaskRepository {
+// NewECSTaskRepository creates a new ECS task repository. preferredContainers
+// replaces the default preferred container names of the mapped tasks.
+func NewECSTaskRepository(client *ecs.Client, preferredContainers ...string) *ECSTaskRepository {
 	return &ECSTaskRepository{
 		client: client,
-		mapper: NewECSMapper(),
+		mapper: NewECSMapper(preferredContainers...),
 	}
 }
 
@@ -0,0 +1,262 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/20uf/devcli/internal/connection/domain"
+	"github.com/20uf/devcli/internal/verbose"
+)
+
+// Backends of devcli connect.
+const (
+	ProviderECS = "ecs"
+	ProviderK8s = "k8s"
+)
+
+// Kubernetes maps onto the connection domain as follows: a namespace is a
+// cluster, a workload (the app label, or the owner of its pods) is a
+// service, and one of its running pods is a task.
+
+// k8sPod is a pod as returned by `kubectl get pods -o json`.
+type k8sPod struct {
+	Metadata struct {
+		Name            string            `json:"name"`
+		Labels          map[string]string `json:"labels"`
+		OwnerReferences []struct {
+			Kind string `json:"kind"`
+			Name string `json:"name"`
+		} `json:"ownerReferences"`
+	} `json:"metadata"`
+	Spec struct {
+		Containers []struct {
+			Name  string `json:"name"`
+			Image string `json:"image"`
+		} `json:"containers"`
+	} `json:"spec"`
+	Status struct {
+		Phase string `json:"phase"`
+	} `json:"status"`
+}
+
+// workload returns the name of the service a pod belongs to.
+func (p k8sPod) workload() string {
+	for _, label := range []string{"app.kubernetes.io/name", "app"} {
+		if name := p.Metadata.Labels[label]; name != "" {
+			return name
+		}
+	}
+	for _, owner := range p.Metadata.OwnerReferences {
+		if owner.Kind == "ReplicaSet" {
+			// Deployment pods are owned by <deployment>-<pod-template-hash>
+			if i := strings.LastIndex(owner.Name, "-"); i > 0 {
+				return owner.Name[:i]
+			}
+		}
+		return owner.Name
+	}
+	return p.Metadata.Name
+}
+
+// kubectl runs a kubectl command against the given context ("" for the
+// current one) and returns its output.
+func kubectl(ctx context.Context, kubeContext string, args ...string) ([]byte, error) {
+	if kubeContext != "" {
+		args = append([]string{"--context", kubeContext}, args...)
+	}
+	out, err := verbose.Cmd(exec.CommandContext(ctx, "kubectl", args...)).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("kubectl: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+// ParseK8sPods reads the running pods of a `kubectl get pods -o json` list.
+func ParseK8sPods(data []byte) ([]k8sPod, error) {
+	var list struct {
+		Items []k8sPod `json:"items"`
+	}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse pods: %w", err)
+	}
+
+	var running []k8sPod
+	for _, pod := range list.Items {
+		if pod.Status.Phase == "Running" {
+			running = append(running, pod)
+		}
+	}
+	return running, nil
+}
+
+// K8sClusterRepository implements domain.ClusterRepository with namespaces.
+type K8sClusterRepository struct {
+	kubeContext string
+}
+
+// NewK8sClusterRepository creates a new Kubernetes namespace repository.
+func NewK8sClusterRepository(kubeContext string) *K8sClusterRepository {
+	return &K8sClusterRepository{kubeContext: kubeContext}
+}
+
+// ListClusters returns the namespaces, sorted by name.
+func (r *K8sClusterRepository) ListClusters(ctx context.Context) ([]domain.Cluster, error) {
+	out, err := kubectl(ctx, r.kubeContext, "get", "namespaces", "-o", "jsonpath={.items[*].metadata.name}")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	names := strings.Fields(string(out))
+	sort.Strings(names)
+
+	var clusters []domain.Cluster
+	for _, name := range names {
+		cluster, err := domain.NewCluster(name)
+		if err != nil {
+			continue
+		}
+		clusters = append(clusters, cluster)
+	}
+	if len(clusters) == 0 {
+		return nil, domain.ErrNoClusterFound
+	}
+	return clusters, nil
+}
+
+// K8sServiceRepository implements domain.ServiceRepository with the
+// workloads of a namespace.
+type K8sServiceRepository struct {
+	kubeContext string
+}
+
+// NewK8sServiceRepository creates a new Kubernetes workload repository.
+func NewK8sServiceRepository(kubeContext string) *K8sServiceRepository {
+	return &K8sServiceRepository{kubeContext: kubeContext}
+}
+
+// ListServices returns the workloads with running pods in a namespace,
+// sorted by name, with the image of their first container.
+func (r *K8sServiceRepository) ListServices(ctx context.Context, cluster domain.Cluster) ([]domain.Service, error) {
+	pods, err := listPods(ctx, r.kubeContext, cluster.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	images := make(map[string]string)
+	for _, pod := range pods {
+		if _, ok := images[pod.workload()]; ok {
+			continue
+		}
+		image := ""
+		if len(pod.Spec.Containers) > 0 {
+			image = pod.Spec.Containers[0].Image
+		}
+		images[pod.workload()] = image
+	}
+
+	names := make([]string, 0, len(images))
+	for name := range images {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var services []domain.Service
+	for _, name := range names {
+		service, err := domain.NewService(name)
+		if err != nil {
+			continue
+		}
+		services = append(services, service.WithDeployment("", images[name]))
+	}
+	if len(services) == 0 {
+		return nil, domain.ErrNoServiceFound
+	}
+	return services, nil
+}
+
+// K8sTaskRepository implements domain.TaskRepository with pods.
+type K8sTaskRepository struct {
+	kubeContext string
+}
+
+// NewK8sTaskRepository creates a new Kubernetes pod repository.
+func NewK8sTaskRepository(kubeContext string) *K8sTaskRepository {
+	return &K8sTaskRepository{kubeContext: kubeContext}
+}
+
+// GetRunningTask returns the first running pod of a workload.
+func (r *K8sTaskRepository) GetRunningTask(ctx context.Context, cluster domain.Cluster, service domain.Service) (domain.Task, error) {
+	pods, err := listPods(ctx, r.kubeContext, cluster.Name())
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	for _, pod := range pods {
+		if pod.workload() != service.Name() {
+			continue
+		}
+		var containers []domain.Container
+		for _, c := range pod.Spec.Containers {
+			if container, err := domain.NewContainer(c.Name); err == nil {
+				containers = append(containers, container)
+			}
+		}
+		return domain.NewTask(pod.Metadata.Name, containers, domain.TaskStatusRunning), nil
+	}
+	return domain.Task{}, domain.ErrNoTaskFound
+}
+
+// listPods returns the running pods of a namespace, sorted by name.
+func listPods(ctx context.Context, kubeContext, namespace string) ([]k8sPod, error) {
+	out, err := kubectl(ctx, kubeContext, "get", "pods", "-n", namespace, "-o", "json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	pods, err := ParseK8sPods(out)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Metadata.Name < pods[j].Metadata.Name })
+	return pods, nil
+}
+
+// ListK8sContexts returns the contexts of the kubeconfig.
+func ListK8sContexts(ctx context.Context) ([]string, error) {
+	out, err := kubectl(ctx, "", "config", "get-contexts", "-o", "name")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list kubectl contexts: %w", err)
+	}
+	return strings.Fields(string(out)), nil
+}
+
+// K8sExecArgs builds the `kubectl exec` arguments running command in a
+// container of a pod. tty attaches the terminal for an interactive shell.
+func K8sExecArgs(kubeContext, namespace, pod, container string, tty bool, command ...string) []string {
+	var args []string
+	if kubeContext != "" {
+		args = append(args, "--context", kubeContext)
+	}
+	args = append(args, "exec")
+	if tty {
+		args = append(args, "-it")
+	}
+	args = append(args, pod, "-n", namespace, "-c", container, "--")
+	return append(args, command...)
+}
+
+// CreateK8sRepositories wires the connection repositories backed by kubectl.
+func CreateK8sRepositories(kubeContext string) *domain.AllRepositories {
+	return &domain.AllRepositories{
+		Clusters:    NewK8sClusterRepository(kubeContext),
+		Services:    NewK8sServiceRepository(kubeContext),
+		Tasks:       NewK8sTaskRepository(kubeContext),
+		Connections: &NoOpConnectionRepository{},
+	}
+}
@@ -0,0 +1,81 @@
+package infra
+
+import (
+	"reflect"
+	"testing"
+)
+
+const podsJSON = `{"items": [
+  {"metadata": {"name": "api-7d9c8-x2k4p", "labels": {"app.kubernetes.io/name": "api"}},
+   "spec": {"containers": [{"name": "php", "image": "api:1.2"}, {"name": "nginx", "image": "nginx:1"}]},
+   "status": {"phase": "Running"}},
+  {"metadata": {"name": "worker-5f6b7-abcde", "ownerReferences": [{"kind": "ReplicaSet", "name": "worker-5f6b7"}]},
+   "spec": {"containers": [{"name": "worker", "image": "worker:3"}]},
+   "status": {"phase": "Running"}},
+  {"metadata": {"name": "db-0", "ownerReferences": [{"kind": "StatefulSet", "name": "db"}]},
+   "spec": {"containers": [{"name": "postgres", "image": "postgres:16"}]},
+   "status": {"phase": "Running"}},
+  {"metadata": {"name": "migrate-9qz", "labels": {"app": "migrate"}},
+   "spec": {"containers": [{"name": "migrate", "image": "api:1.2"}]},
+   "status": {"phase": "Succeeded"}},
+  {"metadata": {"name": "debug"},
+   "spec": {"containers": [{"name": "shell", "image": "busybox"}]},
+   "status": {"phase": "Running"}}
+]}`
+
+// Test: Running pods are grouped by workload
+func TestParseK8sPods(t *testing.T) {
+	pods, err := ParseK8sPods([]byte(podsJSON))
+	if err != nil {
+		t.Fatalf("ParseK8sPods() error = %v", err)
+	}
+
+	var workloads []string
+	for _, pod := range pods {
+		workloads = append(workloads, pod.workload())
+	}
+	want := []string{"api", "worker", "db", "debug"}
+	if !reflect.DeepEqual(workloads, want) {
+		t.Errorf("workloads = %v, want %v", workloads, want)
+	}
+	if got := len(pods[0].Spec.Containers); got != 2 {
+		t.Errorf("api containers = %d, want 2", got)
+	}
+
+	if _, err := ParseK8sPods([]byte("not json")); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+
+	t.Log("✓ Pods grouped by label, owner or name")
+}
+
+// Test: kubectl exec arguments
+func TestK8sExecArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		kubeContext string
+		tty         bool
+		command     []string
+		want        []string
+	}{
+		{
+			"Interactive shell", "", true, []string{"/bin/sh"},
+			[]string{"exec", "-it", "api-1", "-n", "prod", "-c", "php", "--", "/bin/sh"},
+		},
+		{
+			"One-shot command in a context", "kind-dev", false, []string{"sh", "-c", "php -v"},
+			[]string{"--context", "kind-dev", "exec", "api-1", "-n", "prod", "-c", "php", "--", "sh", "-c", "php -v"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := K8sExecArgs(tt.kubeContext, "prod", "api-1", "php", tt.tty, tt.command...)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("K8sExecArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Log("✓ kubectl exec arguments built")
+}
@@ -85,6 +85,14 @@ func (m *mockRunRepo) GetRunLogs(ctx context.Context, runID string) (string, err
 	return "Sample logs", nil
 }
 
+func (m *mockRunRepo) WatchRun(ctx context.Context, runID string) (domain.RunConclusion, error) {
+	return domain.RunConclusionSuccess, nil
+}
+
+func (m *mockRunRepo) CancelInProgress(ctx context.Context, workflow domain.Workflow, branch string) (int, error) {
+	return 0, nil
+}
+
 // Test: StatusOrchestrator initialization
 func TestStatusOrchestrator_Init(t *testing.T) {
 	tracker := newMockTracker()
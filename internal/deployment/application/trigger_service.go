@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/20uf/devcli/internal/deployment/domain"
+	"github.com/20uf/devcli/internal/telemetry"
 )
 
 // TriggerDeploymentOrchestrator is the main use case for triggering a deployment.
@@ -86,6 +87,9 @@ type PrepareDeploymentRequest struct {
 	Branch   string
 	Inputs   []domain.Input
 	RepoURL  string
+	// AllowTagLikeBranch skips the release tag check of the branch, for a
+	// branch picked from the repository's list or explicitly allowed.
+	AllowTagLikeBranch bool
 }
 
 // PrepareDeployment creates and validates a deployment.
@@ -101,6 +105,12 @@ func (o *TriggerDeploymentOrchestrator) PrepareDeployment(ctx context.Context, r
 		return domain.Deployment{}, err
 	}
 
+	if !req.AllowTagLikeBranch {
+		if err := deployment.Validate(); err != nil {
+			return domain.Deployment{}, err
+		}
+	}
+
 	for _, input := range req.Inputs {
 		if err := deployment.AddInput(input); err != nil {
 			return domain.Deployment{}, fmt.Errorf("failed to add input %s: %w", input.Key(), err)
@@ -122,7 +132,11 @@ type ExecuteDeploymentRequest struct {
 // ExecuteDeployment triggers the workflow run.
 func (o *TriggerDeploymentOrchestrator) ExecuteDeployment(ctx context.Context, req ExecuteDeploymentRequest) (domain.Deployment, error) {
 	// Trigger the run
-	run, err := o.repos.Runs.CreateRun(ctx, req.Deployment)
+	createCtx, span := telemetry.Start(ctx, "workflow-trigger",
+		telemetry.WorkflowKey.String(req.Deployment.Workflow().Name()),
+		telemetry.BranchKey.String(req.Deployment.Branch()))
+	run, err := o.repos.Runs.CreateRun(createCtx, req.Deployment)
+	telemetry.End(span, err)
 	if err != nil {
 		return domain.Deployment{}, fmt.Errorf("failed to create run: %w", err)
 	}
@@ -139,26 +153,72 @@ func (o *TriggerDeploymentOrchestrator) ExecuteDeployment(ctx context.Context, r
 	return deployment, nil
 }
 
+// CancelPreviousRunsRequest represents a request to cancel in-flight runs.
+type CancelPreviousRunsRequest struct {
+	Workflow domain.Workflow
+	Branch   string
+}
+
+// CancelPreviousRuns cancels the in-progress runs of a workflow so only one
+// deployment runs at a time. Returns the number of cancelled runs.
+func (o *TriggerDeploymentOrchestrator) CancelPreviousRuns(ctx context.Context, req CancelPreviousRunsRequest) (int, error) {
+	cancelled, err := o.repos.Runs.CancelInProgress(ctx, req.Workflow, req.Branch)
+	if err != nil {
+		return cancelled, fmt.Errorf("failed to cancel previous runs: %w", err)
+	}
+
+	return cancelled, nil
+}
+
+// WatchDeploymentRequest represents a request to follow a triggered deployment.
+type WatchDeploymentRequest struct {
+	Deployment domain.Deployment
+}
+
+// WatchDeployment follows the run of a deployment until it completes and returns its conclusion.
+func (o *TriggerDeploymentOrchestrator) WatchDeployment(ctx context.Context, req WatchDeploymentRequest) (domain.RunConclusion, error) {
+	if !req.Deployment.HasRun() {
+		return "", domain.ErrNoRunFound
+	}
+
+	pollCtx, span := telemetry.Start(ctx, "run-poll",
+		telemetry.WorkflowKey.String(req.Deployment.Workflow().Name()),
+		telemetry.BranchKey.String(req.Deployment.Branch()))
+	conclusion, err := o.repos.Runs.WatchRun(pollCtx, req.Deployment.Run().ID())
+	telemetry.End(span, err)
+	if err != nil {
+		return "", fmt.Errorf("failed to watch run: %w", err)
+	}
+
+	return conclusion, nil
+}
+
 // TriggerRequest represents a complete deployment trigger request.
 type TriggerRequest struct {
-	WorkflowName *string
-	BranchName   *string
-	Inputs       map[string]string // User-provided input values
-	RepoURL      string
+	WorkflowName       *string
+	BranchName         *string
+	Inputs             map[string]string // User-provided input values
+	RepoURL            string
+	AllowTagLikeBranch bool // see PrepareDeploymentRequest
 }
 
 // Trigger orchestrates the complete deployment flow.
 // UseCase: select workflow → select branch → validate inputs → create deployment → execute.
-func (o *TriggerDeploymentOrchestrator) Trigger(ctx context.Context, req TriggerRequest) (domain.Deployment, error) {
+func (o *TriggerDeploymentOrchestrator) Trigger(ctx context.Context, req TriggerRequest) (deployment domain.Deployment, err error) {
+	ctx, span := telemetry.Start(ctx, "deploy")
+	defer func() { telemetry.End(span, err) }()
+
 	workflow, err := o.SelectWorkflow(ctx, SelectWorkflowRequest{WorkflowName: req.WorkflowName})
 	if err != nil {
 		return domain.Deployment{}, fmt.Errorf("workflow selection failed: %w", err)
 	}
+	span.SetAttributes(telemetry.WorkflowKey.String(workflow.Name()))
 
 	branch, err := o.SelectBranch(ctx, SelectBranchRequest{BranchName: req.BranchName})
 	if err != nil {
 		return domain.Deployment{}, fmt.Errorf("branch selection failed: %w", err)
 	}
+	span.SetAttributes(telemetry.BranchKey.String(branch))
 
 	inputs, err := o.GetWorkflowInputs(ctx, GetWorkflowInputsRequest{Workflow: workflow})
 	if err != nil {
@@ -173,11 +233,12 @@ func (o *TriggerDeploymentOrchestrator) Trigger(ctx context.Context, req Trigger
 		}
 	}
 
-	deployment, err := o.PrepareDeployment(ctx, PrepareDeploymentRequest{
-		Workflow: workflow,
-		Branch:   branch,
-		Inputs:   inputs,
-		RepoURL:  req.RepoURL,
+	deployment, err = o.PrepareDeployment(ctx, PrepareDeploymentRequest{
+		Workflow:           workflow,
+		Branch:             branch,
+		Inputs:             inputs,
+		RepoURL:            req.RepoURL,
+		AllowTagLikeBranch: req.AllowTagLikeBranch,
 	})
 	if err != nil {
 		return domain.Deployment{}, fmt.Errorf("deployment preparation failed: %w", err)
@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/20uf/devcli/internal/deployment/domain"
@@ -35,9 +36,15 @@ func (m *MockWorkflowRepository) GetWorkflowInputs(ctx context.Context, workflow
 	return []domain.Input{}, nil
 }
 
+func (m *MockWorkflowRepository) IsDispatchable(ctx context.Context, workflow domain.Workflow) (bool, error) {
+	return true, nil
+}
+
 type MockRunRepository struct {
-	runs map[string]domain.Run
-	err  error
+	runs       map[string]domain.Run
+	err        error
+	inProgress int
+	cancelled  []string
 }
 
 func (m *MockRunRepository) CreateRun(ctx context.Context, deployment domain.Deployment) (*domain.Run, error) {
@@ -65,6 +72,17 @@ func (m *MockRunRepository) GetRunLogs(ctx context.Context, runID string) (strin
 	return "logs...", nil
 }
 
+func (m *MockRunRepository) WatchRun(ctx context.Context, runID string) (domain.RunConclusion, error) {
+	return domain.RunConclusionSuccess, nil
+}
+
+func (m *MockRunRepository) CancelInProgress(ctx context.Context, workflow domain.Workflow, branch string) (int, error) {
+	m.cancelled = append(m.cancelled, workflow.Name())
+	count := m.inProgress
+	m.inProgress = 0
+	return count, nil
+}
+
 type MockBranchRepository struct {
 	branches      []string
 	defaultBranch string
@@ -244,6 +262,61 @@ func TestTriggerDeploymentOrchestrator_PrepareDeployment_InputValidation(t *test
 	}
 }
 
+// Test: A deployment of a tag is refused before anything is triggered
+func TestTriggerDeploymentOrchestrator_PrepareDeployment_TagBranch(t *testing.T) {
+	workflow, _ := domain.NewWorkflow("deploy.yml")
+	orchestrator := NewTriggerDeploymentOrchestrator(&domain.AllRepositories{
+		Deployments: &MockDeploymentRepository{},
+	})
+
+	_, err := orchestrator.PrepareDeployment(context.Background(), PrepareDeploymentRequest{
+		Workflow: workflow,
+		Branch:   "v1.2.3",
+		RepoURL:  "https://github.com/example",
+	})
+	if !errors.Is(err, domain.ErrBranchLooksLikeTag) {
+		t.Errorf("expected ErrBranchLooksLikeTag, got %v", err)
+	}
+
+	_, err = orchestrator.PrepareDeployment(context.Background(), PrepareDeploymentRequest{
+		Workflow:           workflow,
+		Branch:             "v2.3",
+		RepoURL:            "https://github.com/example",
+		AllowTagLikeBranch: true,
+	})
+	if err != nil {
+		t.Errorf("expected an allowed release branch, got %v", err)
+	}
+}
+
+func TestTriggerDeploymentOrchestrator_CancelPreviousRuns(t *testing.T) {
+	// Arrange
+	workflow, _ := domain.NewWorkflow("deploy.yml")
+	runs := &MockRunRepository{
+		runs:       make(map[string]domain.Run),
+		inProgress: 2,
+	}
+	orchestrator := NewTriggerDeploymentOrchestrator(&domain.AllRepositories{Runs: runs})
+
+	// Act
+	cancelled, err := orchestrator.CancelPreviousRuns(context.Background(), CancelPreviousRunsRequest{
+		Workflow: workflow,
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if cancelled != 2 {
+		t.Errorf("expected 2 cancelled runs, got %d", cancelled)
+	}
+
+	if len(runs.cancelled) != 1 || runs.cancelled[0] != "deploy.yml" {
+		t.Errorf("expected cancellation for deploy.yml, got %v", runs.cancelled)
+	}
+}
+
 // Acceptance Test: User triggers a deployment with typed inputs
 func TestAcceptance_TriggerDeploymentWithInputs(t *testing.T) {
 	// Scenario: Developer triggers a deployment to production with custom configuration
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Condition makes an input relevant only for some values of other inputs
+// (value object). Supported clauses, combined with "&&":
+//   - "key": the input is truthy (true, yes or 1)
+//   - "!key": the input is not truthy
+//   - "key == value" and "key != value"
+type Condition struct {
+	expr    string
+	clauses []conditionClause
+}
+
+type conditionClause struct {
+	key   string
+	op    string // "==", "!=", "truthy" or "falsy"
+	value string
+}
+
+// ParseCondition parses a condition expression. An empty expression yields
+// a zero Condition that is always satisfied.
+func ParseCondition(expr string) (Condition, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return Condition{}, nil
+	}
+
+	cond := Condition{expr: expr}
+	for _, part := range strings.Split(expr, "&&") {
+		clause, err := parseClause(strings.TrimSpace(part))
+		if err != nil {
+			return Condition{}, fmt.Errorf("%w %q: %s", ErrInvalidCondition, expr, err)
+		}
+		cond.clauses = append(cond.clauses, clause)
+	}
+	return cond, nil
+}
+
+func parseClause(part string) (conditionClause, error) {
+	for _, op := range []string{"==", "!="} {
+		if key, value, ok := strings.Cut(part, op); ok {
+			key = strings.TrimSpace(key)
+			if key == "" {
+				return conditionClause{}, fmt.Errorf("missing input name before %s", op)
+			}
+			return conditionClause{key: key, op: op, value: unquote(strings.TrimSpace(value))}, nil
+		}
+	}
+
+	if strings.HasPrefix(part, "!") {
+		key := strings.TrimSpace(part[1:])
+		if key == "" {
+			return conditionClause{}, fmt.Errorf("missing input name after !")
+		}
+		return conditionClause{key: key, op: "falsy"}, nil
+	}
+
+	if part == "" || strings.ContainsAny(part, " \t") {
+		return conditionClause{}, fmt.Errorf("expected an input name or a comparison")
+	}
+	return conditionClause{key: part, op: "truthy"}, nil
+}
+
+// unquote strips matching single or double quotes around a value.
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// IsZero returns true when the condition has no clause.
+func (c Condition) IsZero() bool {
+	return len(c.clauses) == 0
+}
+
+// Keys returns the inputs the condition depends on.
+func (c Condition) Keys() []string {
+	keys := make([]string, 0, len(c.clauses))
+	for _, clause := range c.clauses {
+		keys = append(keys, clause.key)
+	}
+	return keys
+}
+
+// Eval reports whether the condition holds for the given input values.
+// Missing inputs are treated as empty.
+func (c Condition) Eval(values map[string]string) bool {
+	for _, clause := range c.clauses {
+		value := values[clause.key]
+		var ok bool
+		switch clause.op {
+		case "==":
+			ok = value == clause.value
+		case "!=":
+			ok = value != clause.value
+		case "truthy":
+			ok = isTruthy(value)
+		case "falsy":
+			ok = !isTruthy(value)
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the condition expression.
+func (c Condition) String() string {
+	return c.expr
+}
+
+func isTruthy(value string) bool {
+	switch strings.ToLower(value) {
+	case "true", "yes", "1":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+// Test: Conditions evaluate against the values collected so far
+func TestCondition_Eval(t *testing.T) {
+	tests := []struct {
+		expr   string
+		values map[string]string
+		want   bool
+	}{
+		{"", nil, true},
+		{"send_notification", map[string]string{"send_notification": "true"}, true},
+		{"send_notification", map[string]string{"send_notification": "false"}, false},
+		{"send_notification", nil, false},
+		{"!dry_run", map[string]string{"dry_run": "false"}, true},
+		{"send_notification == true", map[string]string{"send_notification": "true"}, true},
+		{"environment == 'prod'", map[string]string{"environment": "prod"}, true},
+		{`environment != "prod"`, map[string]string{"environment": "prod"}, false},
+		{"environment == prod && notify", map[string]string{"environment": "prod", "notify": "yes"}, true},
+		{"environment == prod && notify", map[string]string{"environment": "prod", "notify": "no"}, false},
+	}
+
+	for _, tt := range tests {
+		cond, err := ParseCondition(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseCondition(%q) error = %v", tt.expr, err)
+		}
+		if got := cond.Eval(tt.values); got != tt.want {
+			t.Errorf("%q.Eval(%v) = %v, want %v", tt.expr, tt.values, got, tt.want)
+		}
+	}
+
+	t.Log("✓ Conditions evaluated")
+}
+
+// Test: Malformed conditions are rejected
+func TestParseCondition_Invalid(t *testing.T) {
+	for _, expr := range []string{"== prod", "!", "environment is prod", "a && "} {
+		if _, err := ParseCondition(expr); !errors.Is(err, ErrInvalidCondition) {
+			t.Errorf("ParseCondition(%q) error = %v, want ErrInvalidCondition", expr, err)
+		}
+	}
+
+	t.Log("✓ Invalid conditions rejected")
+}
+
+// Test: An input with a condition is only relevant when it holds
+func TestInput_IsRelevant(t *testing.T) {
+	cond, _ := ParseCondition("send_notification == true")
+	input, _ := NewInput("notify_channel", InputTypeString, "", false)
+	input = input.WithCondition(cond)
+
+	if input.IsRelevant(map[string]string{"send_notification": "false"}) {
+		t.Errorf("Input should be skipped when the condition fails")
+	}
+	if !input.IsRelevant(map[string]string{"send_notification": "true"}) {
+		t.Errorf("Input should apply when the condition holds")
+	}
+	if got := input.Condition().Keys(); len(got) != 1 || got[0] != "send_notification" {
+		t.Errorf("Condition().Keys() = %v", got)
+	}
+
+	t.Log("✓ Input relevance follows its condition")
+}
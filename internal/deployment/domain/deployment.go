@@ -2,9 +2,14 @@ package domain
 
 import (
 	"errors"
+	"fmt"
+	"regexp"
 	"time"
 )
 
+// tagPattern matches semver-like release tags such as v1.2 or v1.2.3-rc.1.
+var tagPattern = regexp.MustCompile(`^v\d+\.\d+(\.\d+)?([-+][0-9A-Za-z.-]+)?$`)
+
 // Deployment represents an intended deployment execution (aggregate root).
 // It encapsulates all information needed to trigger and track a workflow run.
 // This is the entry point for the deployment domain logic.
@@ -102,27 +107,40 @@ func (d *Deployment) AddInput(input Input) error {
 }
 
 // GetInput retrieves an input by key.
-func (d Deployment) GetInput(key string) *Input {
-	for _, input := range d.inputs {
-		if input.Key() == key {
-			return &input
+// The returned pointer refers to the deployment's own input, so changes made
+// through it (e.g. SetValue) are reflected in Inputs().
+func (d *Deployment) GetInput(key string) *Input {
+	for i := range d.inputs {
+		if d.inputs[i].Key() == key {
+			return &d.inputs[i]
 		}
 	}
 	return nil
 }
 
 // SetInputValue updates an input's value by key.
+// The value is left unchanged if validation fails.
 func (d *Deployment) SetInputValue(key string, value string) error {
-	for i, input := range d.inputs {
-		if input.Key() == key {
-			if err := input.SetValue(value); err != nil {
-				return err
-			}
-			d.inputs[i] = input
-			return nil
-		}
+	input := d.GetInput(key)
+	if input == nil {
+		return ErrInvalidInput
+	}
+	return input.SetValue(value)
+}
+
+// Validate checks the deployment rules that do not depend on the inputs:
+// the branch must not be a release tag.
+func (d Deployment) Validate() error {
+	return ValidateBranch(d.branch)
+}
+
+// ValidateBranch rejects a branch that looks like a release tag, which is
+// usually deployed to the main workflow by mistake.
+func ValidateBranch(branch string) error {
+	if tagPattern.MatchString(branch) {
+		return fmt.Errorf("%w: %s\n  Use 'main' or a branch name, not a tag", ErrBranchLooksLikeTag, branch)
 	}
-	return ErrInvalidInput
+	return nil
 }
 
 // ValidateInputs checks that all required inputs are provided.
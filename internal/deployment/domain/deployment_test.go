@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func newTestDeployment(t *testing.T) Deployment {
+	t.Helper()
+
+	workflow, _ := NewWorkflow("deploy.yml")
+	d, err := NewDeployment("dep-1", workflow, "main", "owner/repo")
+	if err != nil {
+		t.Fatalf("Failed to create deployment: %v", err)
+	}
+
+	env, _ := NewChoiceInput("environment", "dev", []string{"dev", "staging", "prod"}, true)
+	tag, _ := NewInput("tag", InputTypeString, "latest", false)
+	if err := d.AddInput(env); err != nil {
+		t.Fatalf("Failed to add input: %v", err)
+	}
+	if err := d.AddInput(tag); err != nil {
+		t.Fatalf("Failed to add input: %v", err)
+	}
+	return d
+}
+
+// Test: Value set through GetInput pointer is reflected in Inputs()
+func TestDeployment_GetInputPointer(t *testing.T) {
+	d := newTestDeployment(t)
+
+	input := d.GetInput("environment")
+	if input == nil {
+		t.Fatalf("Input not found")
+	}
+	if err := input.SetValue("prod"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := d.Inputs()[0].Value(); got != "prod" {
+		t.Errorf("Inputs() not updated: got %s, want prod", got)
+	}
+	if got := d.BuildInputsMap()["environment"]; got != "prod" {
+		t.Errorf("BuildInputsMap() not updated: got %s, want prod", got)
+	}
+
+	if d.GetInput("missing") != nil {
+		t.Errorf("Unknown key should return nil")
+	}
+
+	t.Log("✓ GetInput returns a pointer into the deployment")
+}
+
+// Test: SetInputValue persists valid values and keeps the previous one on error
+func TestDeployment_SetInputValue(t *testing.T) {
+	d := newTestDeployment(t)
+
+	if err := d.SetInputValue("tag", "v1.2.3"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := d.GetInput("tag").Value(); got != "v1.2.3" {
+		t.Errorf("Got %s, want v1.2.3", got)
+	}
+
+	if err := d.SetInputValue("environment", "moon"); err == nil {
+		t.Errorf("Invalid choice should be rejected")
+	}
+	if got := d.GetInput("environment").Value(); got != "dev" {
+		t.Errorf("Rejected value should not persist: got %s, want dev", got)
+	}
+
+	if err := d.SetInputValue("missing", "x"); err != ErrInvalidInput {
+		t.Errorf("Expected ErrInvalidInput for unknown key, got %v", err)
+	}
+
+	t.Log("✓ SetInputValue persists valid values only")
+}
+
+// Test: Branches that look like release tags are rejected
+func TestDeployment_Validate(t *testing.T) {
+	workflow, _ := NewWorkflow("deploy.yml")
+
+	tests := []struct {
+		branch string
+		tag    bool
+	}{
+		{"main", false},
+		{"release/v1.2", false},
+		{"v2-redesign", false},
+		{"v1.2", true},
+		{"v1.2.3", true},
+		{"v1.2.3-rc.1", true},
+	}
+	for _, tt := range tests {
+		d, err := NewDeployment("dep-1", workflow, tt.branch, "owner/repo")
+		if err != nil {
+			t.Fatalf("Failed to create deployment: %v", err)
+		}
+		err = d.Validate()
+		if got := errors.Is(err, ErrBranchLooksLikeTag); got != tt.tag {
+			t.Errorf("Validate() on %q = %v, want tag error %v", tt.branch, err, tt.tag)
+		}
+	}
+
+	t.Log("✓ Tag-like branches rejected")
+}
@@ -13,4 +13,6 @@ var (
 	ErrInputValidationFailed  = errors.New("input validation failed")
 	ErrMissingRequiredInput   = errors.New("missing required input")
 	ErrRunNotTracking         = errors.New("run is not being tracked")
+	ErrInvalidCondition       = errors.New("invalid input condition")
+	ErrBranchLooksLikeTag     = errors.New("branch looks like a tag")
 )
@@ -1,6 +1,9 @@
 package domain
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+)
 
 // InputType represents the type of a workflow input.
 type InputType string
@@ -9,6 +12,7 @@ const (
 	InputTypeString   InputType = "string"
 	InputTypeBoolean  InputType = "boolean"
 	InputTypeChoice   InputType = "choice"
+	InputTypeNumber   InputType = "number"
 	InputTypeUnknown  InputType = "unknown"
 )
 
@@ -17,12 +21,14 @@ const (
 // - string: any text value
 // - boolean: true/false
 // - choice: one of a predefined list
+// - number: an integer or decimal value
 type Input struct {
 	key       string
 	inputType InputType
 	value     string        // The actual value provided by user
 	required  bool
 	options   []string      // For choice type: allowed values
+	condition Condition     // Only relevant when the condition holds
 }
 
 // NewInput creates a new typed Input value object.
@@ -88,6 +94,23 @@ func (i Input) Options() []string {
 	return i.options
 }
 
+// WithCondition returns a copy of the input only relevant when cond holds.
+func (i Input) WithCondition(cond Condition) Input {
+	i.condition = cond
+	return i
+}
+
+// Condition returns the condition under which the input is relevant.
+func (i Input) Condition() Condition {
+	return i.condition
+}
+
+// IsRelevant reports whether the input applies given the values of the
+// other inputs. Inputs without condition are always relevant.
+func (i Input) IsRelevant(values map[string]string) bool {
+	return i.condition.Eval(values)
+}
+
 // Validate checks if the input value is valid for its type.
 func (i Input) Validate() error {
 	if i.required && i.value == "" {
@@ -101,6 +124,8 @@ func (i Input) Validate() error {
 		if i.value != "" && !i.isValidChoice() {
 			return ErrInputValidationFailed
 		}
+	case InputTypeNumber:
+		return i.validateNumber()
 	case InputTypeString:
 		// Any string is valid
 	case InputTypeUnknown:
@@ -123,6 +148,17 @@ func (i Input) validateBoolean() error {
 	}
 }
 
+// validateNumber checks if value parses as an integer or a decimal number.
+func (i Input) validateNumber() error {
+	if i.value == "" {
+		return nil // Empty is OK for optional numbers
+	}
+	if _, err := strconv.ParseFloat(i.value, 64); err != nil {
+		return ErrInputTypeMismatch
+	}
+	return nil
+}
+
 // isValidChoice checks if value is in the options list.
 func (i Input) isValidChoice() bool {
 	for _, opt := range i.options {
@@ -134,9 +170,15 @@ func (i Input) isValidChoice() bool {
 }
 
 // SetValue updates the input value with validation.
+// An invalid value is rejected and the previous value is kept.
 func (i *Input) SetValue(value string) error {
+	candidate := *i
+	candidate.value = value
+	if err := candidate.Validate(); err != nil {
+		return err
+	}
 	i.value = value
-	return i.Validate()
+	return nil
 }
 
 // String returns a human-readable representation.
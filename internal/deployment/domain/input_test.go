@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+// Test: Number inputs accept integers and decimals, reject other text
+func TestInput_ValidateNumber(t *testing.T) {
+	tests := []struct {
+		value   string
+		wantErr bool
+	}{
+		{"", false},
+		{"3", false},
+		{"-12", false},
+		{"0.5", false},
+		{"1e3", false},
+		{"three", true},
+		{"3 replicas", true},
+		{"1,5", true},
+	}
+
+	for _, tt := range tests {
+		input, _ := NewInput("replicas", InputTypeNumber, tt.value, false)
+		err := input.Validate()
+		if tt.wantErr && !errors.Is(err, ErrInputTypeMismatch) {
+			t.Errorf("Validate(%q) error = %v, want ErrInputTypeMismatch", tt.value, err)
+		}
+		if !tt.wantErr && err != nil {
+			t.Errorf("Validate(%q) unexpected error = %v", tt.value, err)
+		}
+	}
+
+	t.Log("✓ Number inputs validated")
+}
+
+// Test: SetValue keeps the previous number when the new value is not numeric
+func TestInput_SetValueNumber(t *testing.T) {
+	input, _ := NewInput("replicas", InputTypeNumber, "2", true)
+
+	if err := input.SetValue("many"); !errors.Is(err, ErrInputTypeMismatch) {
+		t.Fatalf("SetValue(many) error = %v, want ErrInputTypeMismatch", err)
+	}
+	if input.Value() != "2" {
+		t.Errorf("Value() = %q, want previous value 2", input.Value())
+	}
+	if err := input.SetValue("4"); err != nil || input.Value() != "4" {
+		t.Errorf("SetValue(4) = %v, value %q", err, input.Value())
+	}
+
+	t.Log("✓ Invalid numbers rejected without losing the value")
+}
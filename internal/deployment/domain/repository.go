@@ -12,6 +12,9 @@ type WorkflowRepository interface {
 
 	// GetWorkflowInputs retrieves the typed inputs required by a workflow.
 	GetWorkflowInputs(ctx context.Context, workflow Workflow) ([]Input, error)
+
+	// IsDispatchable reports whether a workflow declares a workflow_dispatch trigger.
+	IsDispatchable(ctx context.Context, workflow Workflow) (bool, error)
 }
 
 // RunRepository defines the interface for accessing and managing runs.
@@ -30,6 +33,13 @@ type RunRepository interface {
 
 	// GetRunLogs retrieves the logs for a run.
 	GetRunLogs(ctx context.Context, runID string) (string, error)
+
+	// WatchRun streams the progress of a run until it completes and returns its conclusion.
+	WatchRun(ctx context.Context, runID string) (RunConclusion, error)
+
+	// CancelInProgress cancels the in-progress runs of a workflow and returns how many were cancelled.
+	// Providers without separate workflows only cancel the runs of branch.
+	CancelInProgress(ctx context.Context, workflow Workflow, branch string) (int, error)
 }
 
 // BranchRepository defines the interface for accessing branch information.
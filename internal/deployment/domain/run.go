@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // RunStatus represents the lifecycle state of a deployment run.
 type RunStatus string
@@ -24,6 +27,19 @@ const (
 	RunConclusionUnknown   RunConclusion = "unknown"
 )
 
+// ExitCode maps a conclusion to a process exit code: 0 when the run did not
+// fail, 2 when it was cancelled and 1 for any other outcome.
+func (c RunConclusion) ExitCode() int {
+	switch c {
+	case RunConclusionSuccess, RunConclusionNeutral, RunConclusionSkipped:
+		return 0
+	case RunConclusionCancelled:
+		return 2
+	default:
+		return 1
+	}
+}
+
 // Run represents a GitHub Actions workflow run (entity).
 // A run has an identity (ID) and mutable state (status, conclusion, timestamps).
 type Run struct {
@@ -164,5 +180,5 @@ func (r Run) IsStale(maxAge time.Duration) bool {
 
 // String returns a human-readable representation.
 func (r Run) String() string {
-	return "#" + string(rune(r.number))
+	return fmt.Sprintf("#%d", r.number)
 }
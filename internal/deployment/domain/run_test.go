@@ -0,0 +1,34 @@
+package domain
+
+import "testing"
+
+// Test: Run string shows the decimal run number
+func TestRun_String(t *testing.T) {
+	run := NewRun("x", 42, RunStatusQueued, "main", "https://github.com/owner/repo/actions/runs/1")
+
+	if got := run.String(); got != "#42" {
+		t.Errorf("Got %q, want #42", got)
+	}
+
+	t.Log("✓ Run number rendered as decimal")
+}
+
+// Test: Conclusions map to process exit codes
+func TestRunConclusion_ExitCode(t *testing.T) {
+	tests := map[RunConclusion]int{
+		RunConclusionSuccess:   0,
+		RunConclusionSkipped:   0,
+		RunConclusionNeutral:   0,
+		RunConclusionFailure:   1,
+		RunConclusionUnknown:   1,
+		RunConclusionCancelled: 2,
+	}
+
+	for conclusion, want := range tests {
+		if got := conclusion.ExitCode(); got != want {
+			t.Errorf("%s.ExitCode() = %d, want %d", conclusion, got, want)
+		}
+	}
+
+	t.Log("✓ Conclusions mapped to exit codes")
+}
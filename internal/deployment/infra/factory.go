@@ -18,6 +18,23 @@ func CreateRepositories(repoURL string) *domain.AllRepositories {
 	}
 }
 
+// Providers hosting the deployment pipelines.
+const (
+	ProviderGitHub = "github"
+	ProviderGitLab = "gitlab"
+)
+
+// CreateGitLabRepositories creates the deployment repositories of a GitLab
+// project ("group/project"), using the GitLab API via glab CLI.
+func CreateGitLabRepositories(project string) *domain.AllRepositories {
+	return &domain.AllRepositories{
+		Workflows:   NewGitLabWorkflowRepository(project),
+		Runs:        NewGitLabRunRepository(project),
+		Branches:    NewGitLabBranchRepository(project),
+		Deployments: NewFileDeploymentRepository(getDeploymentStorePath()),
+	}
+}
+
 // CreateMockRepositories creates mock implementations for testing.
 // Uses in-memory implementations that don't require GitHub access.
 func CreateMockRepositories() *domain.AllRepositories {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 
 	"github.com/20uf/devcli/internal/deployment/domain"
 )
@@ -24,13 +25,35 @@ func NewFileDeploymentRepository(storePath string) *FileDeploymentRepository {
 
 // deploymentRecord is the serializable format for Deployment.
 type deploymentRecord struct {
-	ID        string            `json:"id"`
-	Workflow  string            `json:"workflow"`
-	Branch    string            `json:"branch"`
-	Inputs    map[string]string `json:"inputs"`
-	Timestamp string            `json:"timestamp"`
-	RunID     string            `json:"run_id,omitempty"`
-	Status    string            `json:"status,omitempty"`
+	ID        string                 `json:"id"`
+	Workflow  string                 `json:"workflow"`
+	Branch    string                 `json:"branch"`
+	Inputs    map[string]inputRecord `json:"inputs"`
+	Timestamp string                 `json:"timestamp"`
+	RunID     string                 `json:"run_id,omitempty"`
+	Status    string                 `json:"status,omitempty"`
+}
+
+// inputRecord is the serializable format for a typed Input.
+type inputRecord struct {
+	Type     domain.InputType `json:"type"`
+	Value    string           `json:"value"`
+	Required bool             `json:"required,omitempty"`
+	Options  []string         `json:"options,omitempty"`
+	If       string           `json:"if,omitempty"`
+}
+
+// UnmarshalJSON also accepts the legacy format where inputs were stored as
+// plain strings, restoring them as string inputs.
+func (r *inputRecord) UnmarshalJSON(data []byte) error {
+	var legacy string
+	if err := json.Unmarshal(data, &legacy); err == nil {
+		*r = inputRecord{Type: domain.InputTypeString, Value: legacy}
+		return nil
+	}
+
+	type plain inputRecord
+	return json.Unmarshal(data, (*plain)(r))
 }
 
 // Save persists a deployment record.
@@ -84,16 +107,9 @@ func (r *FileDeploymentRepository) FindByID(ctx context.Context, id string) (*do
 		return nil, fmt.Errorf("failed to unmarshal deployment: %w", err)
 	}
 
-	// Reconstruct deployment from record
-	workflow, _ := domain.NewWorkflow(record.Workflow)
-	deployment, _ := domain.NewDeployment(record.ID, workflow, record.Branch, "")
-
-	// Restore inputs
-	for key, value := range record.Inputs {
-		// This is simplified - in practice would need input type info
-		input, _ := domain.NewInput(key, domain.InputTypeString, value, false)
-		// Would need to set value in the input
-		_ = input
+	deployment, err := recordToDeployment(record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore deployment %s: %w", id, err)
 	}
 
 	return &deployment, nil
@@ -130,19 +146,77 @@ func (r *FileDeploymentRepository) FindRecent(ctx context.Context, limit int) ([
 			continue
 		}
 
-		workflow, _ := domain.NewWorkflow(record.Workflow)
-		deployment, _ := domain.NewDeployment(record.ID, workflow, record.Branch, "")
+		deployment, err := recordToDeployment(record)
+		if err != nil {
+			continue
+		}
 		deployments = append(deployments, deployment)
 	}
 
 	return deployments, nil
 }
 
-// Helper: Convert inputs slice to map
-func (r *FileDeploymentRepository) inputsToMap(inputs []domain.Input) map[string]string {
-	result := make(map[string]string)
+// Helper: Convert inputs slice to typed records
+func (r *FileDeploymentRepository) inputsToMap(inputs []domain.Input) map[string]inputRecord {
+	result := make(map[string]inputRecord)
 	for _, input := range inputs {
-		result[input.Key()] = input.Value()
+		result[input.Key()] = inputRecord{
+			Type:     input.Type(),
+			Value:    input.Value(),
+			Required: input.IsRequired(),
+			Options:  input.Options(),
+			If:       input.Condition().String(),
+		}
 	}
 	return result
 }
+
+// recordToDeployment rebuilds a deployment with its typed inputs.
+// Inputs are restored in key order so reloads are deterministic.
+func recordToDeployment(record deploymentRecord) (domain.Deployment, error) {
+	workflow, err := domain.NewWorkflow(record.Workflow)
+	if err != nil {
+		return domain.Deployment{}, err
+	}
+
+	deployment, err := domain.NewDeployment(record.ID, workflow, record.Branch, "")
+	if err != nil {
+		return domain.Deployment{}, err
+	}
+
+	keys := make([]string, 0, len(record.Inputs))
+	for key := range record.Inputs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		rec := record.Inputs[key]
+
+		var input domain.Input
+		if rec.Type == domain.InputTypeChoice {
+			input, err = domain.NewChoiceInput(key, rec.Value, rec.Options, rec.Required)
+		} else {
+			inputType := rec.Type
+			if inputType == "" {
+				inputType = domain.InputTypeString
+			}
+			input, err = domain.NewInput(key, inputType, rec.Value, rec.Required)
+		}
+		if err != nil {
+			return domain.Deployment{}, fmt.Errorf("input %s: %w", key, err)
+		}
+
+		cond, err := domain.ParseCondition(rec.If)
+		if err != nil {
+			return domain.Deployment{}, fmt.Errorf("input %s: %w", key, err)
+		}
+		input = input.WithCondition(cond)
+
+		if err := deployment.AddInput(input); err != nil {
+			return domain.Deployment{}, fmt.Errorf("input %s: %w", key, err)
+		}
+	}
+
+	return deployment, nil
+}
@@ -0,0 +1,77 @@
+package infra
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/20uf/devcli/internal/deployment/domain"
+)
+
+// TestFileDeploymentRepository_RoundTrip tests that typed inputs survive save and load.
+func TestFileDeploymentRepository_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	repo := NewFileDeploymentRepository(t.TempDir())
+
+	workflow, _ := domain.NewWorkflow("deploy.yml")
+	deployment, _ := domain.NewDeployment("dep-1", workflow, "main", "owner/repo")
+
+	env, _ := domain.NewChoiceInput("environment", "staging", []string{"dev", "staging", "prod"}, true)
+	skip, _ := domain.NewInput("skip_tests", domain.InputTypeBoolean, "true", false)
+	if err := deployment.AddInput(env); err != nil {
+		t.Fatalf("Failed to add input: %v", err)
+	}
+	if err := deployment.AddInput(skip); err != nil {
+		t.Fatalf("Failed to add input: %v", err)
+	}
+
+	if err := repo.Save(ctx, deployment); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := repo.FindByID(ctx, "dep-1")
+	if err != nil || loaded == nil {
+		t.Fatalf("FindByID failed: %v", err)
+	}
+
+	if len(loaded.Inputs()) != 2 {
+		t.Fatalf("Expected 2 inputs, got %d", len(loaded.Inputs()))
+	}
+
+	gotEnv := loaded.GetInput("environment")
+	if gotEnv == nil || gotEnv.Type() != domain.InputTypeChoice || gotEnv.Value() != "staging" || !gotEnv.IsRequired() {
+		t.Errorf("Choice input not restored: %+v", gotEnv)
+	}
+	if gotEnv != nil && len(gotEnv.Options()) != 3 {
+		t.Errorf("Choice options not restored: %v", gotEnv.Options())
+	}
+
+	gotSkip := loaded.GetInput("skip_tests")
+	if gotSkip == nil || gotSkip.Type() != domain.InputTypeBoolean || gotSkip.Value() != "true" {
+		t.Errorf("Boolean input not restored: %+v", gotSkip)
+	}
+
+	t.Log("✓ Typed inputs round-trip through the file repository")
+}
+
+// TestFileDeploymentRepository_LegacyInputs tests loading records saved with plain string inputs.
+func TestFileDeploymentRepository_LegacyInputs(t *testing.T) {
+	dir := t.TempDir()
+	legacy := `{"id":"old","workflow":"deploy.yml","branch":"main","inputs":{"version":"1.2.3"}}`
+	if err := os.WriteFile(filepath.Join(dir, "old.json"), []byte(legacy), 0644); err != nil {
+		t.Fatalf("Failed to write record: %v", err)
+	}
+
+	loaded, err := NewFileDeploymentRepository(dir).FindByID(context.Background(), "old")
+	if err != nil || loaded == nil {
+		t.Fatalf("FindByID failed: %v", err)
+	}
+
+	input := loaded.GetInput("version")
+	if input == nil || input.Type() != domain.InputTypeString || input.Value() != "1.2.3" {
+		t.Errorf("Legacy input not restored: %+v", input)
+	}
+
+	t.Log("✓ Legacy string inputs restored")
+}
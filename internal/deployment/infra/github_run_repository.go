@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
 	"strings"
 	"time"
@@ -122,6 +123,84 @@ func (r *GitHubRunRepository) GetRunLogs(ctx context.Context, runID string) (str
 	return string(out), nil
 }
 
+// WatchRun streams the run with `gh run watch` until it completes, then
+// returns its conclusion.
+func (r *GitHubRunRepository) WatchRun(ctx context.Context, runID string) (domain.RunConclusion, error) {
+	cmd := verbose.Cmd(exec.CommandContext(ctx, "gh", "run", "watch", runID,
+		"--repo", r.repoURL,
+		"--exit-status"))
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	// A failed run exits non-zero; the conclusion below tells the outcome.
+	if err := cmd.Run(); err != nil && ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+
+	out, err := verbose.Cmd(exec.CommandContext(ctx, "gh", "run", "view", runID,
+		"--repo", r.repoURL,
+		"--json", "conclusion",
+		"-q", ".conclusion")).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch run conclusion: %w", err)
+	}
+
+	conclusion := stringToRunConclusion(strings.TrimSpace(string(out)))
+	if conclusion == "" {
+		return domain.RunConclusionUnknown, nil
+	}
+	return conclusion, nil
+}
+
+// CancelInProgress cancels every in-progress run of a workflow, whatever its
+// branch: the workflow already scopes the runs to the deployment.
+// Returns the number of runs that were cancelled.
+func (r *GitHubRunRepository) CancelInProgress(ctx context.Context, workflow domain.Workflow, branch string) (int, error) {
+	cmd := verbose.Cmd(exec.CommandContext(ctx, "gh", "run", "list",
+		"--repo", r.repoURL,
+		"--workflow", workflow.Name(),
+		"--status", "in_progress",
+		"--json", "databaseId"))
+
+	out, err := cmd.Output()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list in-progress runs: %w", err)
+	}
+
+	runIDs, err := parseRunIDs(out)
+	if err != nil {
+		return 0, err
+	}
+
+	cancelled := 0
+	for _, runID := range runIDs {
+		cancel := verbose.Cmd(exec.CommandContext(ctx, "gh", "run", "cancel", runID, "--repo", r.repoURL))
+		if err := cancel.Run(); err != nil {
+			return cancelled, fmt.Errorf("failed to cancel run %s: %w", runID, err)
+		}
+		cancelled++
+	}
+
+	return cancelled, nil
+}
+
+// parseRunIDs extracts run IDs from a `gh run list --json databaseId` payload.
+func parseRunIDs(payload []byte) ([]string, error) {
+	var runs []struct {
+		DatabaseID int64 `json:"databaseId"`
+	}
+	if err := json.Unmarshal(payload, &runs); err != nil {
+		return nil, fmt.Errorf("failed to parse run list: %w", err)
+	}
+
+	ids := make([]string, 0, len(runs))
+	for _, run := range runs {
+		ids = append(ids, fmt.Sprintf("%d", run.DatabaseID))
+	}
+	return ids, nil
+}
+
 // getLatestRunID fetches the most recent run ID for a workflow.
 func (r *GitHubRunRepository) getLatestRunID(ctx context.Context, workflowName string) (string, error) {
 	cmd := verbose.Cmd(exec.CommandContext(ctx, "gh", "run", "list",
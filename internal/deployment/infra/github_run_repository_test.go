@@ -0,0 +1,46 @@
+package infra
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestParseRunIDs tests extracting run IDs from a gh run list payload.
+func TestParseRunIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "Two in-progress runs",
+			payload: `[{"databaseId":1234567890},{"databaseId":42}]`,
+			want:    []string{"1234567890", "42"},
+		},
+		{
+			name:    "No runs",
+			payload: `[]`,
+			want:    []string{},
+		},
+		{
+			name:    "Malformed JSON",
+			payload: `{invalid`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRunIDs([]byte(tt.payload))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRunIDs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseRunIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Log("✓ Run IDs parsed from gh run list output")
+}
@@ -2,13 +2,13 @@ package infra
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os/exec"
 	"strings"
 
 	"github.com/20uf/devcli/internal/deployment/domain"
 	"github.com/20uf/devcli/internal/verbose"
+	"gopkg.in/yaml.v3"
 )
 
 // GitHubWorkflowRepository implements WorkflowRepository using GitHub API via gh CLI.
@@ -73,79 +73,77 @@ func (r *GitHubWorkflowRepository) GetWorkflow(ctx context.Context, name string)
 }
 
 // GetWorkflowInputs retrieves typed inputs required by a workflow.
-// Parses workflow YAML via GitHub API to extract workflow_dispatch inputs.
+// Inputs are read from the committed workflow YAML, like the legacy deploy
+// flow, and hold their default value. They are returned in name order, each
+// after the inputs its "# devcli:if" condition depends on.
 func (r *GitHubWorkflowRepository) GetWorkflowInputs(ctx context.Context, workflow domain.Workflow) ([]domain.Input, error) {
-	// GitHub API: GET /repos/{owner}/{repo}/actions/workflows/{workflow_id}
-	// We use gh API to fetch the workflow and parse its inputs
-
-	cmd := verbose.Cmd(exec.CommandContext(ctx, "gh", "api",
-		fmt.Sprintf("repos/%s/actions/workflows/%s", r.repoURL, workflow.Name()),
-		"--jq", ".on.workflow_dispatch.inputs"))
+	cmd := verbose.Cmd(exec.CommandContext(ctx, "gh", "workflow", "view", workflow.Name(),
+		"--repo", r.repoURL,
+		"--yaml"))
 
 	out, err := cmd.Output()
 	if err != nil {
-		// Workflow doesn't exist or has no workflow_dispatch inputs
-		return []domain.Input{}, nil
+		return nil, fmt.Errorf("failed to fetch workflow %s: %w", workflow.Name(), err)
 	}
 
-	var inputsData map[string]interface{}
-	if err := json.Unmarshal(out, &inputsData); err != nil {
-		return []domain.Input{}, nil
+	specs, err := ParseWorkflowInputs(out)
+	if err != nil {
+		return nil, err
 	}
 
-	var inputs []domain.Input
-
-	for key, val := range inputsData {
-		inputMap, ok := val.(map[string]interface{})
-		if !ok {
+	inputs := make([]domain.Input, 0, len(specs))
+	for _, key := range OrderedInputKeys(specs) {
+		input, err := specs[key].ToInput(key)
+		if err != nil {
+			verbose.Log("skipping workflow input: %s", err)
 			continue
 		}
+		inputs = append(inputs, input)
+	}
 
-		var inputType domain.InputType
-		if t, ok := inputMap["type"].(string); ok {
-			switch t {
-			case "choice":
-				inputType = domain.InputTypeChoice
-			case "boolean":
-				inputType = domain.InputTypeBoolean
-			default:
-				inputType = domain.InputTypeString
-			}
-		} else {
-			inputType = domain.InputTypeString
-		}
+	return inputs, nil
+}
 
-		required := true
-		if r, ok := inputMap["required"].(bool); ok {
-			required = r
-		}
+// IsDispatchable fetches the workflow YAML and checks for a workflow_dispatch trigger.
+func (r *GitHubWorkflowRepository) IsDispatchable(ctx context.Context, workflow domain.Workflow) (bool, error) {
+	cmd := verbose.Cmd(exec.CommandContext(ctx, "gh", "workflow", "view", workflow.Name(),
+		"--repo", r.repoURL,
+		"--yaml"))
 
-		defaultVal := ""
-		if d, ok := inputMap["default"].(string); ok {
-			defaultVal = d
-		}
+	out, err := cmd.Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch workflow %s: %w", workflow.Name(), err)
+	}
 
-		if inputType == domain.InputTypeChoice {
-			var options []string
-			if opts, ok := inputMap["options"].([]interface{}); ok {
-				for _, opt := range opts {
-					if optStr, ok := opt.(string); ok {
-						options = append(options, optStr)
-					}
-				}
-			}
+	return hasDispatchTrigger(out)
+}
 
-			input, err := domain.NewChoiceInput(key, defaultVal, options, required)
-			if err == nil {
-				inputs = append(inputs, input)
+// hasDispatchTrigger parses a workflow YAML and reports whether its "on" section
+// includes workflow_dispatch. GitHub accepts a string, a list or a map there.
+func hasDispatchTrigger(data []byte) (bool, error) {
+	var wf struct {
+		On yaml.Node `yaml:"on"`
+	}
+	if err := yaml.Unmarshal(data, &wf); err != nil {
+		return false, fmt.Errorf("failed to parse workflow YAML: %w", err)
+	}
+
+	switch wf.On.Kind {
+	case yaml.ScalarNode:
+		return wf.On.Value == "workflow_dispatch", nil
+	case yaml.SequenceNode:
+		for _, n := range wf.On.Content {
+			if n.Value == "workflow_dispatch" {
+				return true, nil
 			}
-		} else {
-			input, err := domain.NewInput(key, inputType, defaultVal, required)
-			if err == nil {
-				inputs = append(inputs, input)
+		}
+	case yaml.MappingNode:
+		for i := 0; i < len(wf.On.Content); i += 2 {
+			if wf.On.Content[i].Value == "workflow_dispatch" {
+				return true, nil
 			}
 		}
 	}
 
-	return inputs, nil
+	return false, nil
 }
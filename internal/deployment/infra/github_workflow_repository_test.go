@@ -3,6 +3,7 @@ package infra
 import (
 	"context"
 	"os/exec"
+	"strings"
 	"testing"
 
 	"github.com/20uf/devcli/internal/deployment/domain"
@@ -94,6 +95,41 @@ func TestGitHubWorkflowRepository_GetWorkflowInputs(t *testing.T) {
 	}
 }
 
+// TestHasDispatchTrigger tests detection of the workflow_dispatch trigger.
+func TestHasDispatchTrigger(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want bool
+	}{
+		{"Scalar trigger", "on: workflow_dispatch\n", true},
+		{"List trigger", "on: [push, workflow_dispatch]\n", true},
+		{"Map trigger with inputs", "on:\n  workflow_dispatch:\n    inputs:\n      env:\n        type: string\n", true},
+		{"Map trigger without inputs", "on:\n  push:\n  workflow_dispatch:\n", true},
+		{"Push only", "on:\n  push:\n    branches: [main]\n", false},
+		{"List without dispatch", "on: [push, pull_request]\n", false},
+		{"No trigger", "name: CI\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := hasDispatchTrigger([]byte(tt.yaml))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := hasDispatchTrigger([]byte("on: [push")); err == nil {
+		t.Errorf("Malformed YAML should fail")
+	}
+
+	t.Log("✓ workflow_dispatch trigger detected in all YAML forms")
+}
+
 // TestGitHubWorkflowRepository_Integration tests with real gh CLI (if available).
 func TestGitHubWorkflowRepository_Integration(t *testing.T) {
 	if testing.Short() {
@@ -140,3 +176,107 @@ func TestGitHubWorkflowRepository_CommandConstruction(t *testing.T) {
 		})
 	}
 }
+
+// Test: committed workflow YAML inputs become typed inputs holding their default
+func TestParseWorkflowInputs(t *testing.T) {
+	specs, err := ParseWorkflowInputs([]byte(`
+on:
+  workflow_dispatch:
+    inputs:
+      environment:
+        type: choice
+        options: [dev, prod]
+        default: prod
+        required: true
+      skip_tests:
+        type: boolean
+        default: false
+      note:
+        description: Free text
+      replicas:
+        type: number
+        default: 2
+`))
+	if err != nil {
+		t.Fatalf("ParseWorkflowInputs() error = %v", err)
+	}
+
+	tests := []struct {
+		key      string
+		typ      domain.InputType
+		value    string
+		required bool
+	}{
+		{"environment", domain.InputTypeChoice, "prod", true},
+		{"skip_tests", domain.InputTypeBoolean, "false", false},
+		{"note", domain.InputTypeString, "", false},
+		{"replicas", domain.InputTypeNumber, "2", false},
+	}
+
+	for _, tt := range tests {
+		input, err := specs[tt.key].ToInput(tt.key)
+		if err != nil {
+			t.Fatalf("ToInput(%s) error = %v", tt.key, err)
+		}
+		if input.Type() != tt.typ || input.Value() != tt.value || input.IsRequired() != tt.required {
+			t.Errorf("%s = %s required=%v, want type %s value %q required=%v",
+				tt.key, input, input.IsRequired(), tt.typ, tt.value, tt.required)
+		}
+	}
+
+	if got := OrderedInputKeys(specs); len(got) != 4 || got[0] != "environment" || got[3] != "skip_tests" {
+		t.Errorf("OrderedInputKeys() = %v", got)
+	}
+
+	t.Log("✓ Workflow inputs parsed with their defaults")
+}
+
+// Test: "# devcli:if" comments attach conditions to workflow inputs
+func TestInputConditions(t *testing.T) {
+	workflow := `
+name: Deploy
+on:
+  workflow_dispatch:
+    inputs:
+      send_notification:
+        type: boolean
+      # devcli:if send_notification == true
+      notify_channel:
+        type: string
+      rollback_to: # devcli:if strategy == rollback
+        type: string
+      strategy:
+        # devcli:if !dry_run
+        type: choice
+        options: [rolling, rollback]
+`
+	got := InputConditions([]byte(workflow))
+
+	want := map[string]string{
+		"notify_channel": "send_notification == true",
+		"rollback_to":    "strategy == rollback",
+		"strategy":       "!dry_run",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d conditions, got %v", len(want), got)
+	}
+	for name, expr := range want {
+		if got[name] != expr {
+			t.Errorf("condition[%s] = %q, want %q", name, got[name], expr)
+		}
+	}
+
+	specs, err := ParseWorkflowInputs([]byte(workflow))
+	if err != nil {
+		t.Fatalf("ParseWorkflowInputs() error = %v", err)
+	}
+	if cond := specs["notify_channel"].Condition; cond.Eval(map[string]string{"send_notification": "false"}) {
+		t.Errorf("notify_channel should be skipped without notification, condition %q", cond)
+	}
+	order := strings.Join(OrderedInputKeys(specs), ",")
+	if want := "send_notification,notify_channel,strategy,rollback_to"; order != want {
+		t.Errorf("OrderedInputKeys() = %s, want %s", order, want)
+	}
+
+	t.Log("✓ Conditions read from workflow comments")
+}
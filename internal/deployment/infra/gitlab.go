@@ -0,0 +1,119 @@
+package infra
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/20uf/devcli/internal/deployment/domain"
+	"github.com/20uf/devcli/internal/verbose"
+)
+
+// GitLabPipelineFile is the single "workflow" of a GitLab project: its CI/CD
+// pipeline definition.
+const GitLabPipelineFile = ".gitlab-ci.yml"
+
+// gitlabPipeline is a pipeline as returned by the GitLab API.
+type gitlabPipeline struct {
+	ID     int64  `json:"id"`
+	IID    int    `json:"iid"`
+	Status string `json:"status"`
+	Ref    string `json:"ref"`
+	Source string `json:"source"`
+	WebURL string `json:"web_url"`
+}
+
+// gitlabProjectPath encodes a "group/project" path for use as the :id of a
+// GitLab API endpoint.
+func gitlabProjectPath(project string) string {
+	return url.PathEscape(project)
+}
+
+// gitlabHostname returns the host of CI_SERVER_URL, letting glab fall back
+// to its configured host when the variable is unset.
+func gitlabHostname() string {
+	server := os.Getenv("CI_SERVER_URL")
+	if server == "" {
+		return ""
+	}
+	if u, err := url.Parse(server); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return strings.TrimSuffix(server, "/")
+}
+
+// glabAPIArgs builds the arguments of a `glab api` call.
+func glabAPIArgs(method, endpoint string, withBody bool) []string {
+	args := []string{"api", endpoint}
+	if method != "" && method != "GET" {
+		args = append(args, "--method", method)
+	}
+	if withBody {
+		args = append(args, "--input", "-", "--header", "Content-Type: application/json")
+	}
+	if host := gitlabHostname(); host != "" {
+		args = append(args, "--hostname", host)
+	}
+	return args
+}
+
+// glabAPI calls the GitLab API through the glab CLI, which handles auth.
+// A non-nil body is sent as JSON.
+func glabAPI(ctx context.Context, method, endpoint string, body any) ([]byte, error) {
+	cmd := verbose.Cmd(exec.CommandContext(ctx, "glab", glabAPIArgs(method, endpoint, body != nil)...))
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		cmd.Stdin = bytes.NewReader(payload)
+	}
+
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("%s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+// gitlabStatus maps a GitLab pipeline status to a domain status and, once
+// the pipeline is finished, its conclusion.
+func gitlabStatus(status string) (domain.RunStatus, domain.RunConclusion) {
+	switch status {
+	case "created", "waiting_for_resource", "preparing", "pending", "scheduled":
+		return domain.RunStatusQueued, ""
+	case "running":
+		return domain.RunStatusInProgress, ""
+	case "success":
+		return domain.RunStatusCompleted, domain.RunConclusionSuccess
+	case "failed":
+		return domain.RunStatusCompleted, domain.RunConclusionFailure
+	case "canceled", "canceling":
+		return domain.RunStatusCompleted, domain.RunConclusionCancelled
+	case "skipped":
+		return domain.RunStatusCompleted, domain.RunConclusionSkipped
+	case "manual":
+		// Waiting on a manual job: nothing more runs on its own.
+		return domain.RunStatusCompleted, domain.RunConclusionNeutral
+	default:
+		return domain.RunStatusUnknown, ""
+	}
+}
+
+// toRun converts a GitLab pipeline to a domain Run.
+func (p gitlabPipeline) toRun() domain.Run {
+	status, conclusion := gitlabStatus(p.Status)
+	run := domain.NewRun(fmt.Sprintf("%d", p.ID), p.IID, status, p.Ref, p.WebURL)
+	if conclusion != "" {
+		run.UpdateConclusion(conclusion)
+	}
+	return run
+}
@@ -0,0 +1,66 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// GitLabBranchRepository implements BranchRepository using the GitLab API via glab CLI.
+type GitLabBranchRepository struct {
+	project string
+}
+
+// NewGitLabBranchRepository creates a new GitLab branch repository.
+func NewGitLabBranchRepository(project string) *GitLabBranchRepository {
+	return &GitLabBranchRepository{
+		project: project,
+	}
+}
+
+// ListBranches returns the branches of the project.
+func (r *GitLabBranchRepository) ListBranches(ctx context.Context) ([]string, error) {
+	out, err := glabAPI(ctx, "GET", fmt.Sprintf("projects/%s/repository/branches?per_page=100", gitlabProjectPath(r.project)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	var list []struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(out, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse branches: %w", err)
+	}
+
+	branches := make([]string, 0, len(list))
+	for _, b := range list {
+		branches = append(branches, b.Name)
+	}
+
+	if len(branches) == 0 {
+		return nil, fmt.Errorf("no branches found in project")
+	}
+
+	return branches, nil
+}
+
+// GetDefaultBranch returns the default branch of the project.
+func (r *GitLabBranchRepository) GetDefaultBranch(ctx context.Context) (string, error) {
+	out, err := glabAPI(ctx, "GET", fmt.Sprintf("projects/%s", gitlabProjectPath(r.project)), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to get default branch: %w", err)
+	}
+
+	var project struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := json.Unmarshal(out, &project); err != nil {
+		return "", fmt.Errorf("failed to parse project: %w", err)
+	}
+
+	if project.DefaultBranch == "" {
+		return "", fmt.Errorf("no default branch found")
+	}
+
+	return project.DefaultBranch, nil
+}
@@ -0,0 +1,194 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/20uf/devcli/internal/deployment/domain"
+)
+
+// gitlabPollInterval is the delay between two pipeline status checks while watching.
+const gitlabPollInterval = 5 * time.Second
+
+// GitLabRunRepository implements RunRepository using GitLab pipelines via glab CLI.
+type GitLabRunRepository struct {
+	project string
+}
+
+// NewGitLabRunRepository creates a new GitLab run repository.
+func NewGitLabRunRepository(project string) *GitLabRunRepository {
+	return &GitLabRunRepository{
+		project: project,
+	}
+}
+
+// gitlabVariable is a pipeline variable in a create pipeline request.
+type gitlabVariable struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// createPipelineRequest is the body of POST /projects/:id/pipeline.
+type createPipelineRequest struct {
+	Ref       string           `json:"ref"`
+	Variables []gitlabVariable `json:"variables,omitempty"`
+}
+
+// newCreatePipelineRequest builds the request running the pipeline of a
+// deployment, its inputs passed as variables in name order.
+func newCreatePipelineRequest(deployment domain.Deployment) createPipelineRequest {
+	req := createPipelineRequest{Ref: deployment.Branch()}
+	for _, input := range deployment.Inputs() {
+		req.Variables = append(req.Variables, gitlabVariable{Key: input.Key(), Value: input.Value()})
+	}
+	sort.Slice(req.Variables, func(i, j int) bool { return req.Variables[i].Key < req.Variables[j].Key })
+	return req
+}
+
+// CreateRun runs a new pipeline on the deployment branch and returns it.
+// GitLab returns the created pipeline, so no lookup of the latest run is needed.
+func (r *GitLabRunRepository) CreateRun(ctx context.Context, deployment domain.Deployment) (*domain.Run, error) {
+	out, err := glabAPI(ctx, "POST", fmt.Sprintf("projects/%s/pipeline", gitlabProjectPath(r.project)),
+		newCreatePipelineRequest(deployment))
+	if err != nil {
+		return nil, fmt.Errorf("failed to trigger pipeline: %w", err)
+	}
+
+	return parsePipeline(out)
+}
+
+// GetRun retrieves a specific pipeline by ID.
+func (r *GitLabRunRepository) GetRun(ctx context.Context, runID string) (*domain.Run, error) {
+	out, err := glabAPI(ctx, "GET", fmt.Sprintf("projects/%s/pipelines/%s", gitlabProjectPath(r.project), runID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pipeline: %w", err)
+	}
+
+	return parsePipeline(out)
+}
+
+// UpdateRunStatus updates the status of a run.
+func (r *GitLabRunRepository) UpdateRunStatus(ctx context.Context, runID string, status domain.RunStatus) error {
+	// Status is read-only from the GitLab API - we only fetch it
+	return nil
+}
+
+// UpdateRunConclusion updates the conclusion of a run.
+func (r *GitLabRunRepository) UpdateRunConclusion(ctx context.Context, runID string, conclusion domain.RunConclusion) error {
+	// Conclusion is read-only from the GitLab API - we only fetch it
+	return nil
+}
+
+// GetRunLogs concatenates the traces of the jobs of a pipeline.
+func (r *GitLabRunRepository) GetRunLogs(ctx context.Context, runID string) (string, error) {
+	project := gitlabProjectPath(r.project)
+	out, err := glabAPI(ctx, "GET", fmt.Sprintf("projects/%s/pipelines/%s/jobs?per_page=100", project, runID), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	var jobs []struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(out, &jobs); err != nil {
+		return "", fmt.Errorf("failed to parse jobs: %w", err)
+	}
+
+	var logs strings.Builder
+	for _, job := range jobs {
+		trace, err := glabAPI(ctx, "GET", fmt.Sprintf("projects/%s/jobs/%d/trace", project, job.ID), nil)
+		if err != nil {
+			return "", fmt.Errorf("failed to fetch logs of job %s: %w", job.Name, err)
+		}
+		fmt.Fprintf(&logs, "=== %s ===\n%s\n", job.Name, trace)
+	}
+	return logs.String(), nil
+}
+
+// WatchRun polls the pipeline until it finishes, printing status changes,
+// then returns its conclusion.
+func (r *GitLabRunRepository) WatchRun(ctx context.Context, runID string) (domain.RunConclusion, error) {
+	var last domain.RunStatus
+	for {
+		run, err := r.GetRun(ctx, runID)
+		if err != nil {
+			return "", err
+		}
+		if run.Status() != last {
+			fmt.Printf("Pipeline #%s %s\n", runID, run.Status())
+			last = run.Status()
+		}
+		if run.IsCompleted() {
+			return run.Conclusion(), nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(gitlabPollInterval):
+		}
+	}
+}
+
+// CancelInProgress cancels the pending and running pipelines of branch that
+// were started by hand or through the API, leaving push and merge request
+// pipelines alone. Returns the number of pipelines that were cancelled.
+func (r *GitLabRunRepository) CancelInProgress(ctx context.Context, workflow domain.Workflow, branch string) (int, error) {
+	project := gitlabProjectPath(r.project)
+
+	cancelled := 0
+	for _, status := range []string{"running", "pending"} {
+		out, err := glabAPI(ctx, "GET", inProgressPipelinesPath(project, status, branch), nil)
+		if err != nil {
+			return cancelled, fmt.Errorf("failed to list %s pipelines: %w", status, err)
+		}
+
+		var pipelines []gitlabPipeline
+		if err := json.Unmarshal(out, &pipelines); err != nil {
+			return cancelled, fmt.Errorf("failed to parse pipeline list: %w", err)
+		}
+
+		for _, p := range pipelines {
+			if !isDeployPipeline(p) {
+				continue
+			}
+			if _, err := glabAPI(ctx, "POST", fmt.Sprintf("projects/%s/pipelines/%d/cancel", project, p.ID), nil); err != nil {
+				return cancelled, fmt.Errorf("failed to cancel pipeline %d: %w", p.ID, err)
+			}
+			cancelled++
+		}
+	}
+
+	return cancelled, nil
+}
+
+// inProgressPipelinesPath lists the pipelines of ref in the given status.
+func inProgressPipelinesPath(project, status, ref string) string {
+	return fmt.Sprintf("projects/%s/pipelines?status=%s&ref=%s&per_page=100", project, status, url.QueryEscape(ref))
+}
+
+// isDeployPipeline reports whether a pipeline was run the way devcli runs
+// them, through the API, or by hand from the web interface.
+func isDeployPipeline(p gitlabPipeline) bool {
+	return p.Source == "api" || p.Source == "web"
+}
+
+// parsePipeline reads a pipeline of the GitLab API into a domain Run.
+func parsePipeline(payload []byte) (*domain.Run, error) {
+	var p gitlabPipeline
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse pipeline: %w", err)
+	}
+	if p.ID == 0 {
+		return nil, fmt.Errorf("pipeline response has no ID")
+	}
+
+	run := p.toRun()
+	return &run, nil
+}
@@ -0,0 +1,144 @@
+package infra
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/20uf/devcli/internal/deployment/domain"
+)
+
+// Test: GitLab pipeline statuses map to domain statuses and conclusions
+func TestGitLabStatus(t *testing.T) {
+	tests := []struct {
+		status         string
+		wantStatus     domain.RunStatus
+		wantConclusion domain.RunConclusion
+	}{
+		{"pending", domain.RunStatusQueued, ""},
+		{"running", domain.RunStatusInProgress, ""},
+		{"success", domain.RunStatusCompleted, domain.RunConclusionSuccess},
+		{"failed", domain.RunStatusCompleted, domain.RunConclusionFailure},
+		{"canceled", domain.RunStatusCompleted, domain.RunConclusionCancelled},
+		{"manual", domain.RunStatusCompleted, domain.RunConclusionNeutral},
+		{"bogus", domain.RunStatusUnknown, ""},
+	}
+
+	for _, tt := range tests {
+		status, conclusion := gitlabStatus(tt.status)
+		if status != tt.wantStatus || conclusion != tt.wantConclusion {
+			t.Errorf("gitlabStatus(%q) = %s/%s, want %s/%s", tt.status, status, conclusion, tt.wantStatus, tt.wantConclusion)
+		}
+	}
+
+	t.Log("✓ Pipeline statuses mapped")
+}
+
+// Test: A created pipeline response becomes a domain Run
+func TestParsePipeline(t *testing.T) {
+	run, err := parsePipeline([]byte(`{"id":1234,"iid":56,"status":"failed","ref":"main","web_url":"https://gitlab.com/g/p/-/pipelines/1234"}`))
+	if err != nil {
+		t.Fatalf("parsePipeline() error = %v", err)
+	}
+	if run.ID() != "1234" || run.Number() != 56 || run.Branch() != "main" || !run.IsFailed() {
+		t.Errorf("Unexpected run: %s", run)
+	}
+
+	if _, err := parsePipeline([]byte(`{"message":"403 Forbidden"}`)); err == nil {
+		t.Error("Response without ID should fail")
+	}
+
+	t.Log("✓ Pipelines parsed into runs")
+}
+
+// Test: Only described global variables are prompted, options become choices
+func TestParseGitLabVariables(t *testing.T) {
+	specs, err := ParseGitLabVariables([]byte(`
+variables:
+  DOCKER_DRIVER: overlay2
+  ENVIRONMENT:
+    value: staging
+    options: [staging, production]
+    description: Target environment
+  VERSION:
+    description: Version to deploy
+  INTERNAL:
+    value: "1"
+deploy:
+  script: ./deploy.sh
+`))
+	if err != nil {
+		t.Fatalf("ParseGitLabVariables() error = %v", err)
+	}
+
+	if len(specs) != 2 {
+		t.Fatalf("Expected 2 prompted variables, got %v", specs)
+	}
+	env, _ := specs["ENVIRONMENT"].ToInput("ENVIRONMENT")
+	if env.Type() != domain.InputTypeChoice || env.Value() != "staging" || len(env.Options()) != 2 {
+		t.Errorf("ENVIRONMENT = %s", env)
+	}
+	if specs["VERSION"].InputType() != domain.InputTypeString {
+		t.Errorf("VERSION should be a string input")
+	}
+
+	t.Log("✓ Pipeline variables parsed as inputs")
+}
+
+// Test: Inputs are sent as pipeline variables on the deployment branch
+func TestNewCreatePipelineRequest(t *testing.T) {
+	workflow, _ := domain.NewWorkflow(GitLabPipelineFile)
+	deployment, _ := domain.NewDeployment("d1", workflow, "main", "group/api")
+	version, _ := domain.NewInput("VERSION", domain.InputTypeString, "1.2.0", false)
+	env, _ := domain.NewInput("ENVIRONMENT", domain.InputTypeString, "prod", false)
+	_ = deployment.AddInput(version)
+	_ = deployment.AddInput(env)
+
+	body, err := json.Marshal(newCreatePipelineRequest(deployment))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"ref":"main","variables":[{"key":"ENVIRONMENT","value":"prod"},{"key":"VERSION","value":"1.2.0"}]}`
+	if string(body) != want {
+		t.Errorf("request = %s, want %s", body, want)
+	}
+
+	t.Log("✓ Pipeline request built from the deployment")
+}
+
+// Test: glab targets CI_SERVER_URL and encodes nested project paths
+func TestGlabAPIArgs(t *testing.T) {
+	t.Setenv("CI_SERVER_URL", "https://gitlab.example.com")
+
+	endpoint := "projects/" + gitlabProjectPath("group/sub/api") + "/pipeline"
+	got := strings.Join(glabAPIArgs("POST", endpoint, true), " ")
+	want := "api projects/group%2Fsub%2Fapi/pipeline --method POST --input - --header Content-Type: application/json --hostname gitlab.example.com"
+	if got != want {
+		t.Errorf("glabAPIArgs() = %q, want %q", got, want)
+	}
+
+	t.Setenv("CI_SERVER_URL", "")
+	if got := strings.Join(glabAPIArgs("GET", "projects/1", false), " "); got != "api projects/1" {
+		t.Errorf("glabAPIArgs() = %q, want plain GET", got)
+	}
+
+	t.Log("✓ glab api calls built")
+}
+
+// Test: --cancel-previous only targets deploy pipelines of the branch
+func TestInProgressPipelines(t *testing.T) {
+	got := inProgressPipelinesPath("group%2Fapi", "running", "feature/x")
+	want := "projects/group%2Fapi/pipelines?status=running&ref=feature%2Fx&per_page=100"
+	if got != want {
+		t.Errorf("inProgressPipelinesPath() = %q, want %q", got, want)
+	}
+
+	for source, want := range map[string]bool{"api": true, "web": true, "push": false, "merge_request_event": false} {
+		if got := isDeployPipeline(gitlabPipeline{Source: source}); got != want {
+			t.Errorf("isDeployPipeline(%s) = %v, want %v", source, got, want)
+		}
+	}
+
+	t.Log("✓ Cancellation scoped to the branch")
+}
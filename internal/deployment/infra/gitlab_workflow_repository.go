@@ -0,0 +1,111 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/20uf/devcli/internal/deployment/domain"
+	"github.com/20uf/devcli/internal/verbose"
+	"gopkg.in/yaml.v3"
+)
+
+// GitLabWorkflowRepository implements WorkflowRepository for GitLab CI/CD.
+// A project has a single pipeline, defined in .gitlab-ci.yml; its inputs are
+// the prefilled variables of the "Run pipeline" form.
+type GitLabWorkflowRepository struct {
+	project string
+}
+
+// NewGitLabWorkflowRepository creates a new GitLab workflow repository.
+func NewGitLabWorkflowRepository(project string) *GitLabWorkflowRepository {
+	return &GitLabWorkflowRepository{
+		project: project,
+	}
+}
+
+// ListWorkflows returns the pipeline of the project.
+func (r *GitLabWorkflowRepository) ListWorkflows(ctx context.Context) ([]domain.Workflow, error) {
+	workflow, err := domain.NewWorkflow(GitLabPipelineFile)
+	if err != nil {
+		return nil, err
+	}
+	return []domain.Workflow{workflow}, nil
+}
+
+// GetWorkflow retrieves a specific workflow by name.
+func (r *GitLabWorkflowRepository) GetWorkflow(ctx context.Context, name string) (*domain.Workflow, error) {
+	workflow, err := domain.NewWorkflow(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workflow name: %w", err)
+	}
+
+	return &workflow, nil
+}
+
+// GetWorkflowInputs reads the prefilled variables of the committed pipeline
+// file. They are returned in name order and hold their default value.
+func (r *GitLabWorkflowRepository) GetWorkflowInputs(ctx context.Context, workflow domain.Workflow) ([]domain.Input, error) {
+	out, err := glabAPI(ctx, "GET", fmt.Sprintf("projects/%s/repository/files/%s/raw",
+		gitlabProjectPath(r.project), gitlabProjectPath(workflow.Name())), nil)
+	if err != nil {
+		// No pipeline file, hence no variables to ask for
+		return []domain.Input{}, nil
+	}
+
+	specs, err := ParseGitLabVariables(out)
+	if err != nil {
+		return []domain.Input{}, nil
+	}
+
+	inputs := make([]domain.Input, 0, len(specs))
+	for _, key := range OrderedInputKeys(specs) {
+		input, err := specs[key].ToInput(key)
+		if err != nil {
+			verbose.Log("skipping pipeline variable: %s", err)
+			continue
+		}
+		inputs = append(inputs, input)
+	}
+
+	return inputs, nil
+}
+
+// IsDispatchable reports true: any GitLab pipeline can be run on demand.
+func (r *GitLabWorkflowRepository) IsDispatchable(ctx context.Context, workflow domain.Workflow) (bool, error) {
+	return true, nil
+}
+
+// ParseGitLabVariables extracts the prefilled variables of a .gitlab-ci.yml:
+// the global variables with a description, shown when running a pipeline
+// manually. Variables with options become choices.
+func ParseGitLabVariables(data []byte) (map[string]WorkflowInputSpec, error) {
+	var ci struct {
+		Variables map[string]yaml.Node `yaml:"variables"`
+	}
+	if err := yaml.Unmarshal(data, &ci); err != nil {
+		return nil, fmt.Errorf("failed to parse pipeline YAML: %w", err)
+	}
+
+	specs := make(map[string]WorkflowInputSpec)
+	for name, node := range ci.Variables {
+		if node.Kind != yaml.MappingNode {
+			continue // plain variables are not prompted by GitLab either
+		}
+		var v struct {
+			Value       string   `yaml:"value"`
+			Description string   `yaml:"description"`
+			Options     []string `yaml:"options"`
+		}
+		if err := node.Decode(&v); err != nil || v.Description == "" {
+			continue
+		}
+
+		spec := WorkflowInputSpec{Description: v.Description, Default: v.Value, Type: "string"}
+		if len(v.Options) > 0 {
+			spec.Type = "choice"
+			spec.Options = v.Options
+		}
+		specs[name] = spec
+	}
+	return specs, nil
+}
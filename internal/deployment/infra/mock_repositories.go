@@ -33,6 +33,10 @@ func (m *MockWorkflowRepository) GetWorkflowInputs(ctx context.Context, workflow
 	return []domain.Input{}, nil
 }
 
+func (m *MockWorkflowRepository) IsDispatchable(ctx context.Context, workflow domain.Workflow) (bool, error) {
+	return true, nil
+}
+
 // MockRunRepository is a mock implementation for testing.
 type MockRunRepository struct{}
 
@@ -62,6 +66,14 @@ func (m *MockRunRepository) GetRunLogs(ctx context.Context, runID string) (strin
 	return "logs...", nil
 }
 
+func (m *MockRunRepository) WatchRun(ctx context.Context, runID string) (domain.RunConclusion, error) {
+	return domain.RunConclusionSuccess, nil
+}
+
+func (m *MockRunRepository) CancelInProgress(ctx context.Context, workflow domain.Workflow, branch string) (int, error) {
+	return 0, nil
+}
+
 // MockBranchRepository is a mock implementation for testing.
 type MockBranchRepository struct{}
 
@@ -0,0 +1,196 @@
+package infra
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/20uf/devcli/internal/deployment/domain"
+	"github.com/20uf/devcli/internal/verbose"
+	"gopkg.in/yaml.v3"
+)
+
+// conditionMarker introduces an input condition in a workflow YAML comment:
+//
+//	# devcli:if send_notification == true
+//	notify_channel:
+const conditionMarker = "devcli:if"
+
+// WorkflowInputSpec is a workflow_dispatch input as committed in the workflow YAML.
+// It is the single source of input defaults for every deploy flow.
+type WorkflowInputSpec struct {
+	Description string   `yaml:"description"`
+	Required    bool     `yaml:"required"`
+	Default     string   `yaml:"default"`
+	Type        string   `yaml:"type"`
+	Options     []string `yaml:"options"`
+
+	// Condition is read from "# devcli:if" comments by ParseWorkflowInputs;
+	// the deploy command also applies those of .devcli.yaml.
+	Condition domain.Condition `yaml:"-"`
+}
+
+// ParseWorkflowInputs extracts the workflow_dispatch inputs of a workflow YAML
+// with the conditions of their "# devcli:if" comments. Scalar defaults
+// (booleans, numbers) are kept as their literal text.
+func ParseWorkflowInputs(data []byte) (map[string]WorkflowInputSpec, error) {
+	var wf struct {
+		On struct {
+			WorkflowDispatch struct {
+				Inputs map[string]WorkflowInputSpec `yaml:"inputs"`
+			} `yaml:"workflow_dispatch"`
+		} `yaml:"on"`
+	}
+	if err := yaml.Unmarshal(data, &wf); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow YAML: %w", err)
+	}
+
+	inputs := wf.On.WorkflowDispatch.Inputs
+	for name, expr := range InputConditions(data) {
+		input, ok := inputs[name]
+		if !ok {
+			continue
+		}
+		cond, err := domain.ParseCondition(expr)
+		if err != nil {
+			verbose.Log("ignoring condition of input %s: %s", name, err)
+			continue
+		}
+		input.Condition = cond
+		inputs[name] = input
+	}
+	return inputs, nil
+}
+
+// InputConditions extracts the "# devcli:if" comments attached to the
+// workflow_dispatch inputs of a workflow YAML, as expressions by input name.
+func InputConditions(data []byte) map[string]string {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 {
+		return nil
+	}
+
+	inputs := mappingValue(mappingValue(mappingValue(doc.Content[0], "on"), "workflow_dispatch"), "inputs")
+	if inputs == nil {
+		return nil
+	}
+
+	conditions := make(map[string]string)
+	for i := 0; i+1 < len(inputs.Content); i += 2 {
+		key, value := inputs.Content[i], inputs.Content[i+1]
+		comments := []string{key.HeadComment, key.LineComment, value.HeadComment}
+		if value.Kind == yaml.MappingNode && len(value.Content) > 0 {
+			comments = append(comments, value.Content[0].HeadComment)
+		}
+		for _, comment := range comments {
+			if expr, ok := parseConditionComment(comment); ok {
+				conditions[key.Value] = expr
+				break
+			}
+		}
+	}
+	return conditions
+}
+
+// parseConditionComment returns the expression of a "# devcli:if <expr>" line.
+func parseConditionComment(comment string) (string, bool) {
+	for _, line := range strings.Split(comment, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "#"))
+		if expr, ok := strings.CutPrefix(line, conditionMarker); ok {
+			return strings.TrimSpace(expr), true
+		}
+	}
+	return "", false
+}
+
+// mappingValue returns the value of key in a YAML mapping node, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// InputType maps the YAML type to a domain input type. Choices without
+// options and unknown types are handled as strings.
+func (s WorkflowInputSpec) InputType() domain.InputType {
+	switch s.Type {
+	case "choice":
+		if len(s.Options) > 0 {
+			return domain.InputTypeChoice
+		}
+	case "boolean":
+		return domain.InputTypeBoolean
+	case "number":
+		return domain.InputTypeNumber
+	}
+	return domain.InputTypeString
+}
+
+// ToInput converts the spec to a domain Input holding its default value.
+func (s WorkflowInputSpec) ToInput(key string) (domain.Input, error) {
+	var input domain.Input
+	var err error
+	if s.InputType() == domain.InputTypeChoice {
+		input, err = domain.NewChoiceInput(key, s.Default, s.Options, s.Required)
+	} else {
+		input, err = domain.NewInput(key, s.InputType(), s.Default, s.Required)
+	}
+	if err != nil {
+		return domain.Input{}, fmt.Errorf("input %s: %w", key, err)
+	}
+	return input.WithCondition(s.Condition), nil
+}
+
+// WorkflowInputDefaults returns the default value of every input that has one.
+func WorkflowInputDefaults(specs map[string]WorkflowInputSpec) map[string]string {
+	defaults := make(map[string]string)
+	for key, spec := range specs {
+		if spec.Default != "" {
+			defaults[key] = spec.Default
+		}
+	}
+	return defaults
+}
+
+// OrderedInputKeys returns input names alphabetically, except that an input
+// always comes after the inputs its condition depends on.
+func OrderedInputKeys(specs map[string]WorkflowInputSpec) []string {
+	names := make([]string, 0, len(specs))
+	for name := range specs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var ordered []string
+	placed := make(map[string]bool, len(names))
+	visiting := make(map[string]bool)
+
+	var place func(name string)
+	place = func(name string) {
+		if placed[name] || visiting[name] {
+			return // cycles fall back to alphabetical order
+		}
+		visiting[name] = true
+		deps := specs[name].Condition.Keys()
+		sort.Strings(deps)
+		for _, dep := range deps {
+			if _, ok := specs[dep]; ok {
+				place(dep)
+			}
+		}
+		visiting[name] = false
+		placed[name] = true
+		ordered = append(ordered, name)
+	}
+
+	for _, name := range names {
+		place(name)
+	}
+	return ordered
+}
@@ -1,26 +1,66 @@
 package ecs
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"sort"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
+	awsutil "github.com/20uf/devcli/internal/aws"
+	"github.com/20uf/devcli/internal/retry"
 	"github.com/20uf/devcli/internal/verbose"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
 )
 
 type Client struct {
 	ecs     *ecs.Client
 	profile string
 	region  string
+	env     []string // assumed role credentials for child aws commands
 }
 
 func NewClient(profile, region string) (*Client, error) {
+	return NewClientWithCredentials(profile, region, nil)
+}
+
+// NewClientWithCredentials creates a client that calls ECS with the given
+// assumed role credentials instead of those of the profile. The credentials
+// are also injected into every aws command started by the client.
+func NewClientWithCredentials(profile, region string, creds *awsutil.Credentials) (*Client, error) {
+	cfg, err := loadConfig(profile, region, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &Client{
+		ecs:     ecs.NewFromConfig(cfg),
+		profile: profile,
+		region:  region,
+	}
+	if creds != nil {
+		client.env = creds.Env()
+		// Child commands no longer receive --profile, keep its region.
+		if client.region == "" {
+			client.region = cfg.Region
+		}
+	}
+	return client, nil
+}
+
+// loadConfig loads the AWS config of profile, overriding the region and the
+// credentials when given.
+func loadConfig(profile, region string, creds *awsutil.Credentials) (aws.Config, error) {
 	var opts []func(*config.LoadOptions) error
 
 	if profile != "" {
@@ -29,17 +69,16 @@ func NewClient(profile, region string) (*Client, error) {
 	if region != "" {
 		opts = append(opts, config.WithRegion(region))
 	}
+	if creds != nil {
+		opts = append(opts, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(creds.AccessKeyID, creds.SecretAccessKey, creds.SessionToken)))
+	}
 
 	cfg, err := config.LoadDefaultConfig(context.Background(), opts...)
 	if err != nil {
-		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+		return aws.Config{}, fmt.Errorf("unable to load AWS config: %w", err)
 	}
-
-	return &Client{
-		ecs:     ecs.NewFromConfig(cfg),
-		profile: profile,
-		region:  region,
-	}, nil
+	return cfg, nil
 }
 
 func (c *Client) ListClusters(ctx context.Context) ([]string, error) {
@@ -64,7 +103,32 @@ func (c *Client) ListClusters(ctx context.Context) ([]string, error) {
 	return names, nil
 }
 
-func (c *Client) ListServices(ctx context.Context, cluster string) ([]string, error) {
+// ServiceInfo describes a service and the version it currently runs.
+type ServiceInfo struct {
+	Name           string
+	TaskDefinition string // family:revision, empty when unknown
+	Image          string // image of the primary container
+}
+
+// Detail renders the deployed version, e.g. "api:42 · api:1.4.2".
+func (si ServiceInfo) Detail() string {
+	var parts []string
+	if si.TaskDefinition != "" {
+		parts = append(parts, si.TaskDefinition)
+	}
+	if si.Image != "" {
+		parts = append(parts, shortImage(si.Image))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// describeServicesBatch is the maximum number of services DescribeServices accepts per call.
+const describeServicesBatch = 10
+
+// ListServices returns the services of a cluster, sorted by name, with their
+// current task definition and image. When services cannot be described, only
+// their names are returned.
+func (c *Client) ListServices(ctx context.Context, cluster string) ([]ServiceInfo, error) {
 	verbose.Log("ecs:ListServices cluster=%s", cluster)
 	var serviceArns []string
 	paginator := ecs.NewListServicesPaginator(c.ecs, &ecs.ListServicesInput{
@@ -79,22 +143,165 @@ func (c *Client) ListServices(ctx context.Context, cluster string) ([]string, er
 		serviceArns = append(serviceArns, page.ServiceArns...)
 	}
 
-	names := make([]string, 0, len(serviceArns))
+	services := make([]ServiceInfo, 0, len(serviceArns))
 	for _, arn := range serviceArns {
-		names = append(names, extractName(arn))
+		services = append(services, ServiceInfo{Name: extractName(arn)})
 	}
-	sort.Strings(names)
+	sort.Slice(services, func(i, j int) bool { return services[i].Name < services[j].Name })
 
-	return names, nil
+	if err := c.describeServices(ctx, cluster, services); err != nil {
+		verbose.Log("ecs:DescribeServices failed: %v", err)
+	}
+
+	return services, nil
+}
+
+// describeServices fills the task definition and image of services in place.
+func (c *Client) describeServices(ctx context.Context, cluster string, services []ServiceInfo) error {
+	names := make([]string, 0, len(services))
+	for _, s := range services {
+		names = append(names, s.Name)
+	}
+
+	deployments, err := DescribeDeployments(ctx, c.ecs, cluster, names)
+	if err != nil {
+		return err
+	}
+	for i := range services {
+		if d, ok := deployments[services[i].Name]; ok {
+			services[i].TaskDefinition = extractName(d.TaskDefinitionARN)
+			services[i].Image = d.Image
+		}
+	}
+	return nil
+}
+
+// Deployment is the task definition a service runs and the image of its
+// primary container.
+type Deployment struct {
+	TaskDefinitionARN string
+	Image             string
+}
+
+// DescribeDeployments returns the current deployment of the named services
+// of a cluster, keyed by service name. Services often share a task
+// definition, so each one is described once.
+func DescribeDeployments(ctx context.Context, client *ecs.Client, cluster string, names []string) (map[string]Deployment, error) {
+	taskDefs := make(map[string]string, len(names))
+	for start := 0; start < len(names); start += describeServicesBatch {
+		end := min(start+describeServicesBatch, len(names))
+
+		verbose.Log("ecs:DescribeServices cluster=%s services=%d", cluster, end-start)
+		resp, err := client.DescribeServices(ctx, &ecs.DescribeServicesInput{
+			Cluster:  aws.String(cluster),
+			Services: names[start:end],
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, svc := range resp.Services {
+			taskDefs[aws.ToString(svc.ServiceName)] = aws.ToString(svc.TaskDefinition)
+		}
+	}
+
+	deployments := make(map[string]Deployment, len(taskDefs))
+	images := make(map[string]string)
+	for name, arn := range taskDefs {
+		if arn == "" {
+			continue
+		}
+
+		image, ok := images[arn]
+		if !ok {
+			verbose.Log("ecs:DescribeTaskDefinition %s", extractName(arn))
+			resp, err := client.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{
+				TaskDefinition: aws.String(arn),
+			})
+			if err != nil {
+				return nil, err
+			}
+			image = primaryImage(resp.TaskDefinition.ContainerDefinitions)
+			images[arn] = image
+		}
+		deployments[name] = Deployment{TaskDefinitionARN: arn, Image: image}
+	}
+	return deployments, nil
+}
+
+// primaryImage returns the image of the first essential container.
+func primaryImage(containers []types.ContainerDefinition) string {
+	for _, c := range containers {
+		if c.Essential == nil || *c.Essential {
+			return aws.ToString(c.Image)
+		}
+	}
+	if len(containers) > 0 {
+		return aws.ToString(containers[0].Image)
+	}
+	return ""
+}
+
+// shortImage drops the registry and repository path of an image reference,
+// e.g. "1234.dkr.ecr.eu-west-1.amazonaws.com/team/api:1.4.2" becomes "api:1.4.2".
+func shortImage(image string) string {
+	if i := strings.LastIndex(image, "/"); i >= 0 {
+		return image[i+1:]
+	}
+	return image
+}
+
+// ExecDisabledError reports a task that does not accept ECS Exec sessions,
+// with the command enabling it on its service.
+type ExecDisabledError struct {
+	Cluster string
+	Service string
+	Task    string
+}
+
+func (e *ExecDisabledError) Error() string {
+	return fmt.Sprintf("task %s of service %s does not accept ECS Exec sessions\n  %s", e.Task, e.Service, awsutil.ExecDisabledHint(e.Cluster, e.Service))
+}
+
+// CheckExecEnabled returns an *ExecDisabledError when enableExecuteCommand
+// is off on the task, which keeps the setting of the service when it was
+// started. When the task cannot be described, nil is returned and the
+// session reports its own error.
+func (c *Client) CheckExecEnabled(ctx context.Context, cluster, service, taskID string) error {
+	verbose.Log("ecs:DescribeTasks cluster=%s task=%s", cluster, taskID)
+	resp, err := c.ecs.DescribeTasks(ctx, &ecs.DescribeTasksInput{
+		Cluster: aws.String(cluster),
+		Tasks:   []string{taskID},
+	})
+	if err != nil {
+		verbose.Log("ecs:DescribeTasks failed: %v", err)
+		return nil
+	}
+	return execDisabledError(cluster, service, taskID, resp.Tasks)
+}
+
+// execDisabledError checks the enableExecuteCommand setting of a described
+// task.
+func execDisabledError(cluster, service, taskID string, described []types.Task) error {
+	for _, task := range described {
+		if extractName(aws.ToString(task.TaskArn)) == taskID && !task.EnableExecuteCommand {
+			return &ExecDisabledError{Cluster: cluster, Service: service, Task: taskID}
+		}
+	}
+	return nil
 }
 
 func (c *Client) GetRunningTask(ctx context.Context, cluster, service string) (string, error) {
 	verbose.Log("ecs:ListTasks cluster=%s service=%s status=RUNNING", cluster, service)
-	resp, err := c.ecs.ListTasks(ctx, &ecs.ListTasksInput{
-		Cluster:       aws.String(cluster),
-		ServiceName:   aws.String(service),
-		DesiredStatus: "RUNNING",
-		MaxResults:    aws.Int32(1),
+	var resp *ecs.ListTasksOutput
+	err := retry.Do(ctx, func() error {
+		var listErr error
+		resp, listErr = c.ecs.ListTasks(ctx, &ecs.ListTasksInput{
+			Cluster:       aws.String(cluster),
+			ServiceName:   aws.String(service),
+			DesiredStatus: "RUNNING",
+			MaxResults:    aws.Int32(1),
+		})
+		return listErr
 	})
 	if err != nil {
 		return "", err
@@ -107,7 +314,110 @@ func (c *Client) GetRunningTask(ctx context.Context, cluster, service string) (s
 	return extractID(resp.TaskArns[0]), nil
 }
 
-func (c *Client) ListContainers(ctx context.Context, cluster, taskID string) ([]string, error) {
+// TaskInfo describes a running task of a service.
+type TaskInfo struct {
+	ID          string     `json:"task_id"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	Containers  []string   `json:"containers"`
+	ExecEnabled bool       `json:"exec_enabled"`
+}
+
+// describeTasksBatch is the maximum number of tasks DescribeTasks accepts per call.
+const describeTasksBatch = 100
+
+// ListRunningTasks returns every running task of a service, oldest first.
+func (c *Client) ListRunningTasks(ctx context.Context, cluster, service string) ([]TaskInfo, error) {
+	verbose.Log("ecs:ListTasks cluster=%s service=%s status=RUNNING", cluster, service)
+	var taskArns []string
+	paginator := ecs.NewListTasksPaginator(c.ecs, &ecs.ListTasksInput{
+		Cluster:       aws.String(cluster),
+		ServiceName:   aws.String(service),
+		DesiredStatus: types.DesiredStatusRunning,
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		taskArns = append(taskArns, page.TaskArns...)
+	}
+
+	tasks := make([]TaskInfo, 0, len(taskArns))
+	for start := 0; start < len(taskArns); start += describeTasksBatch {
+		end := min(start+describeTasksBatch, len(taskArns))
+
+		verbose.Log("ecs:DescribeTasks cluster=%s tasks=%d", cluster, end-start)
+		resp, err := c.ecs.DescribeTasks(ctx, &ecs.DescribeTasksInput{
+			Cluster: aws.String(cluster),
+			Tasks:   taskArns[start:end],
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range resp.Tasks {
+			tasks = append(tasks, taskInfo(task))
+		}
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].StartedAt == nil || tasks[j].StartedAt == nil {
+			return tasks[j].StartedAt == nil && tasks[i].StartedAt != nil
+		}
+		return tasks[i].StartedAt.Before(*tasks[j].StartedAt)
+	})
+
+	return tasks, nil
+}
+
+// taskInfo converts an ECS task description to a TaskInfo.
+func taskInfo(task types.Task) TaskInfo {
+	info := TaskInfo{
+		ID:          extractID(aws.ToString(task.TaskArn)),
+		StartedAt:   task.StartedAt,
+		Containers:  []string{},
+		ExecEnabled: task.EnableExecuteCommand,
+	}
+	for _, container := range task.Containers {
+		if container.Name != nil {
+			info.Containers = append(info.Containers, *container.Name)
+		}
+	}
+	sort.Strings(info.Containers)
+	return info
+}
+
+// ContainerInfo describes a container of a task and its runtime state.
+type ContainerInfo struct {
+	Name         string
+	LastStatus   string // e.g. RUNNING, PENDING, STOPPED
+	HealthStatus string // HEALTHY, UNHEALTHY or UNKNOWN
+	Image        string
+}
+
+// Running reports whether the container accepts execute-command sessions.
+func (ci ContainerInfo) Running() bool {
+	return ci.LastStatus == "RUNNING"
+}
+
+// Label renders the container with its state, e.g. "php (RUNNING, HEALTHY)".
+// An unknown health status is left out.
+func (ci ContainerInfo) Label() string {
+	var states []string
+	if ci.LastStatus != "" {
+		states = append(states, ci.LastStatus)
+	}
+	if ci.HealthStatus != "" && ci.HealthStatus != string(types.HealthStatusUnknown) {
+		states = append(states, ci.HealthStatus)
+	}
+	if len(states) == 0 {
+		return ci.Name
+	}
+	return fmt.Sprintf("%s (%s)", ci.Name, strings.Join(states, ", "))
+}
+
+// ListContainers returns the containers of a task with their status, sorted by name.
+func (c *Client) ListContainers(ctx context.Context, cluster, taskID string) ([]ContainerInfo, error) {
 	verbose.Log("ecs:DescribeTasks cluster=%s task=%s", cluster, taskID)
 	resp, err := c.ecs.DescribeTasks(ctx, &ecs.DescribeTasksInput{
 		Cluster: aws.String(cluster),
@@ -121,18 +431,225 @@ func (c *Client) ListContainers(ctx context.Context, cluster, taskID string) ([]
 		return nil, fmt.Errorf("task %s not found", taskID)
 	}
 
-	var names []string
-	for _, container := range resp.Tasks[0].Containers {
-		if container.Name != nil {
-			names = append(names, *container.Name)
-		}
+	return containerInfos(resp.Tasks[0].Containers), nil
+}
+
+// TaskOSFamily returns the operating system family declared by the
+// runtimePlatform of a task's definition (e.g. "LINUX", "WINDOWS_SERVER_2022_CORE"),
+// or "" when the definition does not declare one.
+func (c *Client) TaskOSFamily(ctx context.Context, cluster, taskID string) (string, error) {
+	verbose.Log("ecs:DescribeTasks cluster=%s task=%s", cluster, taskID)
+	tasks, err := c.ecs.DescribeTasks(ctx, &ecs.DescribeTasksInput{
+		Cluster: aws.String(cluster),
+		Tasks:   []string{taskID},
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(tasks.Tasks) == 0 {
+		return "", fmt.Errorf("task %s not found", taskID)
 	}
-	sort.Strings(names)
 
-	return names, nil
+	arn := aws.ToString(tasks.Tasks[0].TaskDefinitionArn)
+	verbose.Log("ecs:DescribeTaskDefinition %s", extractName(arn))
+	resp, err := c.ecs.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: aws.String(arn),
+	})
+	if err != nil {
+		return "", err
+	}
+	if resp.TaskDefinition.RuntimePlatform == nil {
+		return "", nil
+	}
+	return string(resp.TaskDefinition.RuntimePlatform.OperatingSystemFamily), nil
+}
+
+// containerInfos converts ECS container descriptions, sorted by name.
+func containerInfos(containers []types.Container) []ContainerInfo {
+	var infos []ContainerInfo
+	for _, container := range containers {
+		if container.Name == nil {
+			continue
+		}
+		infos = append(infos, ContainerInfo{
+			Name:         *container.Name,
+			LastStatus:   aws.ToString(container.LastStatus),
+			HealthStatus: string(container.HealthStatus),
+			Image:        aws.ToString(container.Image),
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
 }
 
 func (c *Client) ExecInteractive(ctx context.Context, cluster, taskID, container, command, profile string) error {
+	return c.ExecInteractiveTo(ctx, cluster, taskID, container, command, profile, os.Stdout)
+}
+
+// ExecInteractiveTo is ExecInteractive with the session output written to
+// stdout, for instance to keep a copy of it. Cancelling ctx sends SIGTERM so
+// the aws CLI closes the session before exiting.
+func (c *Client) ExecInteractiveTo(ctx context.Context, cluster, taskID, container, command, profile string, stdout io.Writer) error {
+	cmd := c.awsCommand(ctx, c.execArgs(cluster, taskID, container, command, profile))
+	cmd.Cancel = func() error { return cmd.Process.Signal(syscall.SIGTERM) }
+	cmd.WaitDelay = sessionCloseDelay
+	var stderr strings.Builder
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = io.MultiWriter(os.Stderr, &stderr)
+
+	if err := cmd.Run(); err != nil {
+		return SessionError(err, stderr.String())
+	}
+	return nil
+}
+
+// sessionCloseDelay is how long a terminated session may take to close
+// before it is killed.
+const sessionCloseDelay = 5 * time.Second
+
+// SessionError adds the last line the aws CLI printed on stderr to the error
+// of a failed session, so the ECS error code can be recognized.
+func SessionError(err error, stderr string) error {
+	lines := strings.Split(strings.TrimSpace(stderr), "\n")
+	last := strings.TrimSpace(lines[len(lines)-1])
+	if last == "" {
+		return err
+	}
+	return fmt.Errorf("%w: %s", err, last)
+}
+
+// ExecCommandLine returns the full aws command line of an interactive session,
+// so it can be started in another terminal. See EnvFileCommand for how the
+// assumed role credentials are passed; cleanup removes them if the command
+// never runs.
+func (c *Client) ExecCommandLine(cluster, taskID, container, command, profile string) (line []string, cleanup func(), err error) {
+	line, env := c.ExecSession(cluster, taskID, container, command, profile)
+	return EnvFileCommand(line, env)
+}
+
+// EnvFileCommand returns a command line running line with the variables of
+// env. They are written to a file only the user can read, which the command
+// sources and deletes before running line: a command typed into a terminal
+// ends up in the shell history and the process list. cleanup removes the
+// file if the command never runs.
+func EnvFileCommand(line, env []string) (command []string, cleanup func(), err error) {
+	if env == nil {
+		return line, func() {}, nil
+	}
+
+	f, err := os.CreateTemp("", "devcli-env-*")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to write session credentials: %w", err)
+	}
+	cleanup = func() { os.Remove(f.Name()) } //nolint:errcheck
+
+	var script strings.Builder
+	for _, kv := range env {
+		key, value, _ := strings.Cut(kv, "=")
+		fmt.Fprintf(&script, "export %s='%s'\n", key, strings.ReplaceAll(value, "'", `'\''`))
+	}
+	_, err = f.WriteString(script.String())
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("failed to write session credentials: %w", err)
+	}
+
+	command = append([]string{"sh", "-c", `. "$1"; rm -f "$1"; shift; exec "$@"`, "sh", f.Name()}, line...)
+	return command, cleanup, nil
+}
+
+// ExecSession returns the aws command line of an interactive session and the
+// assumed role credentials it must run with, kept apart so they never show
+// up in a process listing or a recording of the command.
+func (c *Client) ExecSession(cluster, taskID, container, command, profile string) (line, env []string) {
+	line = append([]string{"aws"}, c.execArgs(cluster, taskID, container, command, profile)...)
+	return line, c.env
+}
+
+// ExecCapture runs a command in a container and returns its combined output
+// instead of attaching the terminal. Session Manager banner lines are stripped.
+func (c *Client) ExecCapture(ctx context.Context, cluster, taskID, container, command, profile string) (string, error) {
+	args := c.execArgs(cluster, taskID, container, command, profile)
+	out, err := c.awsCommand(ctx, args).CombinedOutput()
+	return cleanSessionOutput(string(out)), err
+}
+
+// exitMarker prefixes the exit status printed after a one-shot command:
+// Session Manager does not report the status of the remote command.
+const exitMarker = "__DEVCLI_EXIT="
+
+// OneShotCommand wraps command so that its exit status is printed last.
+// The command runs in a subshell, on lines of its own, so that an exit or
+// a trailing comment in it does not skip the marker.
+func OneShotCommand(command string) string {
+	script := "(\n" + command + "\n); echo " + exitMarker + "$?"
+	return "sh -c '" + strings.ReplaceAll(script, "'", `'\''`) + "'"
+}
+
+// ExecStream runs a one-shot command in a container, streaming its output
+// to w without the Session Manager banners, and returns its exit status.
+func (c *Client) ExecStream(ctx context.Context, cluster, taskID, container, command, profile string, w io.Writer) (int, error) {
+	cmd := c.awsCommand(ctx, c.execArgs(cluster, taskID, container, OneShotCommand(command), profile))
+	pr, pw := io.Pipe()
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+
+	if err := cmd.Start(); err != nil {
+		return 0, err
+	}
+	waitErr := make(chan error, 1)
+	go func() {
+		err := cmd.Wait()
+		pw.Close() //nolint:errcheck
+		waitErr <- err
+	}()
+
+	status, found := copySessionOutput(pr, w)
+	if err := <-waitErr; err != nil && !found {
+		return 0, fmt.Errorf("exec failed: %w", err)
+	}
+	if !found {
+		return 0, fmt.Errorf("exit status of the command not received")
+	}
+	return status, nil
+}
+
+// copySessionOutput copies the output of a one-shot session to w, dropping
+// the Session Manager banners and the blank lines around the command output,
+// and extracts the exit status it ends with.
+func copySessionOutput(r io.Reader, w io.Writer) (status int, found bool) {
+	started := false
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadString('\n')
+		if line == "" && err != nil {
+			break
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if found || isSessionBanner(line) || (!started && strings.TrimSpace(line) == "") {
+			continue
+		}
+		started = true
+		if i := strings.Index(line, exitMarker); i >= 0 {
+			if code, err := strconv.Atoi(strings.TrimSpace(line[i+len(exitMarker):])); err == nil {
+				if i > 0 {
+					fmt.Fprintln(w, line[:i])
+				}
+				status, found = code, true
+				continue
+			}
+		}
+		fmt.Fprintln(w, line)
+	}
+	return status, found
+}
+
+// execArgs builds the `aws ecs execute-command` arguments for a container.
+func (c *Client) execArgs(cluster, taskID, container, command, profile string) []string {
 	args := []string{"ecs", "execute-command",
 		"--cluster", cluster,
 		"--task", taskID,
@@ -140,20 +657,96 @@ func (c *Client) ExecInteractive(ctx context.Context, cluster, taskID, container
 		"--command", command,
 		"--interactive",
 	}
+	return append(args, c.sessionArgs(profile)...)
+}
 
-	if profile != "" {
+// sessionArgs returns the --profile and --region arguments of aws commands.
+// With assumed role credentials the profile is omitted so the injected
+// environment credentials are used.
+func (c *Client) sessionArgs(profile string) []string {
+	var args []string
+	if profile != "" && c.env == nil {
 		args = append(args, "--profile", profile)
 	}
 	if c.region != "" {
 		args = append(args, "--region", c.region)
 	}
+	return args
+}
 
-	cmd := verbose.Cmd(exec.CommandContext(ctx, "aws", args...))
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+// awsCommand builds an aws command carrying the assumed role credentials, if any.
+func (c *Client) awsCommand(ctx context.Context, args []string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "aws", args...)
+	if c.env != nil {
+		cmd.Env = append(os.Environ(), c.env...)
+	}
+	return verbose.Cmd(cmd)
+}
+
+// ContainerRuntimeID returns the runtime ID of a container, needed to target
+// it with Session Manager port forwarding.
+func (c *Client) ContainerRuntimeID(ctx context.Context, cluster, taskID, container string) (string, error) {
+	verbose.Log("ecs:DescribeTasks cluster=%s task=%s", cluster, taskID)
+	resp, err := c.ecs.DescribeTasks(ctx, &ecs.DescribeTasksInput{
+		Cluster: aws.String(cluster),
+		Tasks:   []string{taskID},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if len(resp.Tasks) == 0 {
+		return "", fmt.Errorf("task %s not found", taskID)
+	}
+
+	for _, ct := range resp.Tasks[0].Containers {
+		if aws.ToString(ct.Name) == container && aws.ToString(ct.RuntimeId) != "" {
+			return aws.ToString(ct.RuntimeId), nil
+		}
+	}
+
+	return "", fmt.Errorf("no runtime ID for container %s in task %s", container, taskID)
+}
+
+// PortForward forwards localPort to remotePort inside a container through
+// Session Manager. It blocks until ctx is cancelled or the session ends.
+func (c *Client) PortForward(ctx context.Context, cluster, taskID, runtimeID string, remotePort, localPort int, profile string) error {
+	args := []string{"ssm", "start-session",
+		"--target", fmt.Sprintf("ecs:%s_%s_%s", cluster, taskID, runtimeID),
+		"--document-name", "AWS-StartPortForwardingSession",
+		"--parameters", fmt.Sprintf(`{"portNumber":["%d"],"localPortNumber":["%d"]}`, remotePort, localPort),
+	}
+	args = append(args, c.sessionArgs(profile)...)
+
+	out, err := c.awsCommand(ctx, args).CombinedOutput()
+	if err != nil && ctx.Err() == nil {
+		return fmt.Errorf("port forwarding failed: %w\n%s", err, cleanSessionOutput(string(out)))
+	}
+	return nil
+}
+
+// cleanSessionOutput removes the Session Manager start/exit banners.
+func cleanSessionOutput(out string) string {
+	var lines []string
+	for _, line := range strings.Split(out, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" && len(lines) == 0 {
+			continue
+		}
+		if isSessionBanner(trimmed) {
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, "\r"))
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n ")
+}
 
-	return cmd.Run()
+// isSessionBanner reports whether a line is a Session Manager start/exit banner.
+func isSessionBanner(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, "The Session Manager plugin was installed successfully") ||
+		strings.HasPrefix(trimmed, "Starting session with SessionId") ||
+		strings.HasPrefix(trimmed, "Exiting session with sessionId")
 }
 
 // extractName returns the last segment after "/" in an ARN.
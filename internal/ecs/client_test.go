@@ -0,0 +1,206 @@
+package ecs
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+// Test: Containers expose their status and health in selection labels
+func TestContainerInfos(t *testing.T) {
+	infos := containerInfos([]types.Container{
+		{Name: aws.String("php"), LastStatus: aws.String("RUNNING"), HealthStatus: types.HealthStatusHealthy},
+		{Name: aws.String("migrate"), LastStatus: aws.String("STOPPED"), HealthStatus: types.HealthStatusUnknown},
+		{Name: aws.String("nginx"), LastStatus: aws.String("RUNNING"), HealthStatus: types.HealthStatusUnhealthy},
+		{LastStatus: aws.String("RUNNING")},
+	})
+
+	tests := []struct {
+		label   string
+		running bool
+	}{
+		{"migrate (STOPPED)", false},
+		{"nginx (RUNNING, UNHEALTHY)", true},
+		{"php (RUNNING, HEALTHY)", true},
+	}
+
+	if len(infos) != len(tests) {
+		t.Fatalf("Expected %d containers, got %d", len(tests), len(infos))
+	}
+	for i, tt := range tests {
+		if got := infos[i].Label(); got != tt.label {
+			t.Errorf("Label() = %q, want %q", got, tt.label)
+		}
+		if got := infos[i].Running(); got != tt.running {
+			t.Errorf("%s Running() = %v, want %v", infos[i].Name, got, tt.running)
+		}
+	}
+
+	if got := (ContainerInfo{Name: "app"}).Label(); got != "app" {
+		t.Errorf("Label() without state = %q, want app", got)
+	}
+
+	t.Log("✓ Container status and health rendered")
+}
+
+// Test: Services show their task definition revision and primary image
+func TestServiceInfo_Detail(t *testing.T) {
+	image := primaryImage([]types.ContainerDefinition{
+		{Name: aws.String("init"), Image: aws.String("busybox:1.36"), Essential: aws.Bool(false)},
+		{Name: aws.String("php"), Image: aws.String("1234.dkr.ecr.eu-west-1.amazonaws.com/team/api:1.4.2")},
+	})
+
+	tests := []struct {
+		info ServiceInfo
+		want string
+	}{
+		{ServiceInfo{Name: "api", TaskDefinition: "api:42", Image: image}, "api:42 · api:1.4.2"},
+		{ServiceInfo{Name: "api", TaskDefinition: "api:42"}, "api:42"},
+		{ServiceInfo{Name: "api"}, ""},
+	}
+
+	for _, tt := range tests {
+		if got := tt.info.Detail(); got != tt.want {
+			t.Errorf("Detail() = %q, want %q", got, tt.want)
+		}
+	}
+
+	t.Log("✓ Service deployment details rendered")
+}
+
+// Test: assumed role credentials stay out of the session command line
+func TestExecSession(t *testing.T) {
+	c := &Client{region: "eu-west-1", env: []string{"AWS_SECRET_ACCESS_KEY=secret"}}
+	line, env := c.ExecSession("prod", "task", "php", "sh", "dev")
+	if strings.Contains(strings.Join(line, " "), "secret") {
+		t.Errorf("ExecSession() line = %q, must not hold credentials", line)
+	}
+	if strings.Contains(strings.Join(line, " "), "--profile") {
+		t.Errorf("ExecSession() line = %q, the profile must be dropped with assumed credentials", line)
+	}
+	if len(env) != 1 || env[0] != "AWS_SECRET_ACCESS_KEY=secret" {
+		t.Errorf("ExecSession() env = %q", env)
+	}
+
+	t.Log("✓ Credentials passed through the environment")
+}
+
+// Test: new tab sessions read assumed role credentials from a private file
+func TestEnvFileCommand(t *testing.T) {
+	line, cleanup, err := EnvFileCommand([]string{"sh", "-c", `printf %s "$AWS_SECRET_ACCESS_KEY"`}, []string{"AWS_SECRET_ACCESS_KEY=it's secret"})
+	if err != nil {
+		t.Fatalf("EnvFileCommand() error = %v", err)
+	}
+	defer cleanup()
+	if strings.Contains(strings.Join(line, " "), "secret") {
+		t.Errorf("EnvFileCommand() line = %q, must not hold credentials", line)
+	}
+	file := line[4]
+	if info, err := os.Stat(file); err != nil || info.Mode().Perm() != 0600 {
+		t.Fatalf("Credentials file %s: %v, %v", file, info, err)
+	}
+
+	out, err := exec.Command(line[0], line[1:]...).Output()
+	if err != nil || string(out) != "it's secret" {
+		t.Errorf("Command printed %q, %v", out, err)
+	}
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Errorf("Credentials file %s not removed: %v", file, err)
+	}
+
+	if line, _, _ := EnvFileCommand([]string{"aws"}, nil); len(line) != 1 {
+		t.Errorf("EnvFileCommand() without credentials = %q", line)
+	}
+
+	t.Log("✓ Credentials kept off the new tab command line")
+}
+
+// Test: one-shot commands are quoted for sh and report their exit status
+func TestOneShotCommand(t *testing.T) {
+	got := OneShotCommand("echo 'hi' && exit 3 # done")
+	want := "sh -c '(\necho '\\''hi'\\'' && exit 3 # done\n); echo __DEVCLI_EXIT=$?'"
+	if got != want {
+		t.Errorf("OneShotCommand() = %s, want %s", got, want)
+	}
+
+	// The marker is printed even when the command exits
+	out, err := exec.Command("sh", "-c", got).Output()
+	if err != nil || string(out) != "hi\n__DEVCLI_EXIT=3\n" {
+		t.Errorf("Wrapped command printed %q, %v", out, err)
+	}
+
+	t.Log("✓ One-shot command wrapped")
+}
+
+// Test: session banners are dropped and the exit marker parsed
+func TestCopySessionOutput(t *testing.T) {
+	out := "\r\nStarting session with SessionId: ecs-execute-command-0abc\r\nCache cleared\r\npartial__DEVCLI_EXIT=2\r\n\r\nExiting session with sessionId: ecs-execute-command-0abc.\r\n"
+
+	var b strings.Builder
+	status, found := copySessionOutput(strings.NewReader(out), &b)
+	if !found || status != 2 {
+		t.Errorf("status = %d, %v, want 2, true", status, found)
+	}
+	if got := b.String(); got != "Cache cleared\npartial\n" {
+		t.Errorf("output = %q", got)
+	}
+
+	// Lines have no length limit
+	long := strings.Repeat("x", 2<<20)
+	b.Reset()
+	if status, found := copySessionOutput(strings.NewReader(long+"\n__DEVCLI_EXIT=0"), &b); !found || status != 0 || b.Len() != len(long)+1 {
+		t.Errorf("Long line: status = %d, %v, %d bytes copied", status, found, b.Len())
+	}
+
+	if _, found := copySessionOutput(strings.NewReader("no marker\n"), &b); found {
+		t.Error("found = true without a marker")
+	}
+
+	t.Log("✓ Session output streamed with exit status")
+}
+
+// Test: Tasks started without enableExecuteCommand get a remediation error
+func TestExecDisabledError(t *testing.T) {
+	described := []types.Task{
+		{TaskArn: aws.String("arn:aws:ecs:eu-west-1:123456789012:task/prod/abc"), EnableExecuteCommand: true},
+		{TaskArn: aws.String("arn:aws:ecs:eu-west-1:123456789012:task/prod/def"), EnableExecuteCommand: false},
+	}
+
+	if err := execDisabledError("prod", "api", "abc", described); err != nil {
+		t.Errorf("Exec-enabled task rejected: %v", err)
+	}
+	if err := execDisabledError("prod", "api", "unknown", described); err != nil {
+		t.Errorf("Undescribed task rejected: %v", err)
+	}
+
+	err := execDisabledError("prod", "api", "def", described)
+	var disabled *ExecDisabledError
+	if !errors.As(err, &disabled) {
+		t.Fatalf("Expected an ExecDisabledError, got %v", err)
+	}
+	if want := "aws ecs update-service --cluster prod --service api --enable-execute-command"; !strings.Contains(err.Error(), want) {
+		t.Errorf("Error %q lacks the remediation %q", err, want)
+	}
+
+	t.Log("✓ Disabled ECS Exec detected")
+}
+
+// Test: Failed sessions carry the last aws CLI error line
+func TestSessionError(t *testing.T) {
+	exitErr := errors.New("exit status 254")
+
+	err := SessionError(exitErr, "\nStarting session...\nAn error occurred (TargetNotConnectedException) when calling the ExecuteCommand operation\n")
+	if !errors.Is(err, exitErr) || !strings.HasSuffix(err.Error(), "(TargetNotConnectedException) when calling the ExecuteCommand operation") {
+		t.Errorf("SessionError() = %v", err)
+	}
+	if err := SessionError(exitErr, "  \n"); err != exitErr {
+		t.Errorf("SessionError() without stderr = %v, want the exit error", err)
+	}
+
+	t.Log("✓ Session errors keep the aws CLI message")
+}
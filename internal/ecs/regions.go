@@ -0,0 +1,128 @@
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	awsutil "github.com/20uf/devcli/internal/aws"
+	"github.com/20uf/devcli/internal/verbose"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+)
+
+// regionTimeout bounds the cluster listing of a single region.
+const regionTimeout = 10 * time.Second
+
+// defaultDiscoveryRegion is used to list regions when the profile has none.
+const defaultDiscoveryRegion = "us-east-1"
+
+// RegionalCluster is a cluster found while scanning every region.
+type RegionalCluster struct {
+	Name   string
+	Region string
+}
+
+// Label renders the cluster with its region, e.g. "prod (eu-west-1)".
+func (rc RegionalCluster) Label() string {
+	return fmt.Sprintf("%s (%s)", rc.Name, rc.Region)
+}
+
+// ListEnabledRegions returns the regions enabled for the account of profile.
+func ListEnabledRegions(ctx context.Context, profile string, creds *awsutil.Credentials) ([]string, error) {
+	cfg, err := loadConfig(profile, "", creds)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Region == "" {
+		cfg.Region = defaultDiscoveryRegion
+	}
+
+	verbose.Log("ec2:DescribeRegions")
+	resp, err := ec2.NewFromConfig(cfg).DescribeRegions(ctx, &ec2.DescribeRegionsInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list regions: %w", err)
+	}
+
+	regions := make([]string, 0, len(resp.Regions))
+	for _, r := range resp.Regions {
+		if name := aws.ToString(r.RegionName); name != "" {
+			regions = append(regions, name)
+		}
+	}
+	sort.Strings(regions)
+	return regions, nil
+}
+
+// ListClustersAllRegions lists the clusters of every enabled region concurrently.
+func ListClustersAllRegions(ctx context.Context, profile string, creds *awsutil.Credentials) ([]RegionalCluster, error) {
+	regions, err := ListEnabledRegions(ctx, profile, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	return discoverClusters(ctx, regions, func(ctx context.Context, region string) ([]string, error) {
+		client, err := NewClientWithCredentials(profile, region, creds)
+		if err != nil {
+			return nil, err
+		}
+		return client.ListClusters(ctx)
+	})
+}
+
+// discoverClusters calls list for each region in parallel, each with its own
+// timeout, and merges the clusters sorted by name then region. Failing regions
+// are skipped; an error is returned only when every region failed.
+func discoverClusters(ctx context.Context, regions []string, list func(ctx context.Context, region string) ([]string, error)) ([]RegionalCluster, error) {
+	type result struct {
+		clusters []RegionalCluster
+		err      error
+	}
+
+	results := make([]result, len(regions))
+	var wg sync.WaitGroup
+	for i, region := range regions {
+		wg.Add(1)
+		go func(i int, region string) {
+			defer wg.Done()
+			regionCtx, cancel := context.WithTimeout(ctx, regionTimeout)
+			defer cancel()
+
+			names, err := list(regionCtx, region)
+			if err != nil {
+				results[i].err = fmt.Errorf("%s: %w", region, err)
+				return
+			}
+			for _, name := range names {
+				results[i].clusters = append(results[i].clusters, RegionalCluster{Name: name, Region: region})
+			}
+		}(i, region)
+	}
+	wg.Wait()
+
+	var clusters []RegionalCluster
+	var lastErr error
+	failed := 0
+	for _, r := range results {
+		if r.err != nil {
+			verbose.Log("ecs:ListClusters %s", r.err)
+			lastErr = r.err
+			failed++
+			continue
+		}
+		clusters = append(clusters, r.clusters...)
+	}
+	if len(regions) > 0 && failed == len(regions) {
+		return nil, fmt.Errorf("failed to list clusters in every region: %w", lastErr)
+	}
+
+	sort.Slice(clusters, func(i, j int) bool {
+		if clusters[i].Name != clusters[j].Name {
+			return clusters[i].Name < clusters[j].Name
+		}
+		return clusters[i].Region < clusters[j].Region
+	})
+	return clusters, nil
+}
@@ -0,0 +1,54 @@
+package ecs
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// Test: clusters from every region are merged and annotated with their region
+func TestDiscoverClusters(t *testing.T) {
+	listed := map[string][]string{
+		"eu-west-1": {"prod", "staging"},
+		"us-east-1": {"prod"},
+	}
+
+	clusters, err := discoverClusters(context.Background(), []string{"us-east-1", "eu-west-1", "ap-south-1"},
+		func(ctx context.Context, region string) ([]string, error) {
+			if _, ok := ctx.Deadline(); !ok {
+				t.Errorf("Region %s listed without timeout", region)
+			}
+			if region == "ap-south-1" {
+				return nil, errors.New("UnrecognizedClientException")
+			}
+			return listed[region], nil
+		})
+	if err != nil {
+		t.Fatalf("discoverClusters() error = %v", err)
+	}
+
+	want := []string{"prod (eu-west-1)", "prod (us-east-1)", "staging (eu-west-1)"}
+	if len(clusters) != len(want) {
+		t.Fatalf("Expected %d clusters, got %v", len(want), clusters)
+	}
+	for i, label := range want {
+		if got := clusters[i].Label(); got != label {
+			t.Errorf("clusters[%d] = %q, want %q", i, got, label)
+		}
+	}
+
+	t.Log("✓ Clusters merged across regions")
+}
+
+// Test: discovery fails only when every region fails
+func TestDiscoverClusters_AllRegionsFail(t *testing.T) {
+	_, err := discoverClusters(context.Background(), []string{"us-east-1", "eu-west-1"},
+		func(ctx context.Context, region string) ([]string, error) {
+			return nil, errors.New("expired token")
+		})
+	if err == nil {
+		t.Errorf("Expected an error when every region fails")
+	}
+
+	t.Log("✓ Discovery error reported when no region answers")
+}
@@ -0,0 +1,115 @@
+package errmap
+
+import (
+	"strings"
+
+	"github.com/20uf/devcli/internal/ui"
+)
+
+// Guidance is an actionable explanation for a known error signature.
+type Guidance struct {
+	Summary string   // What went wrong, in plain words
+	Steps   []string // What to do about it
+}
+
+// rule maps error message fragments (case-insensitive) to guidance.
+type rule struct {
+	markers  []string
+	guidance Guidance
+}
+
+// rules are evaluated in order: the first matching rule wins.
+var rules = []rule{
+	{
+		markers: []string{"targetnotconnected", "target not connected"},
+		guidance: Guidance{
+			Summary: "The container's SSM agent is not connected",
+			Steps: []string{
+				"Check that the service has enableExecuteCommand turned on",
+				"Check that the task role allows ssmmessages:* actions",
+				"Restart the task after changing either setting",
+			},
+		},
+	},
+	{
+		markers: []string{"workflow_dispatch"},
+		guidance: Guidance{
+			Summary: "This workflow cannot be triggered manually",
+			Steps: []string{
+				"Add a `workflow_dispatch:` trigger under `on:` in the workflow file",
+				"Push it to the default branch, then retry",
+			},
+		},
+	},
+	{
+		markers: []string{"gh auth login", "not logged into", "http 401", "bad credentials"},
+		guidance: Guidance{
+			Summary: "GitHub CLI is not authenticated",
+			Steps:   []string{"Run: gh auth login"},
+		},
+	},
+	{
+		markers: []string{"session-manager-plugin"},
+		guidance: Guidance{
+			Summary: "The AWS Session Manager plugin is missing",
+			Steps:   []string{"Install: https://docs.aws.amazon.com/systems-manager/latest/userguide/session-manager-working-with-install-plugin.html"},
+		},
+	},
+	{
+		markers: []string{`exec: "gh"`, "github cli (gh) is required"},
+		guidance: Guidance{
+			Summary: "GitHub CLI (gh) is not installed",
+			Steps:   []string{"Install: https://cli.github.com/"},
+		},
+	},
+	{
+		markers: []string{`exec: "aws"`, "aws cli"},
+		guidance: Guidance{
+			Summary: "AWS CLI is not installed",
+			Steps:   []string{"Install: https://docs.aws.amazon.com/cli/latest/userguide/getting-started-install.html"},
+		},
+	},
+	{
+		markers: []string{"expired", "get credentials", "failed to refresh", "security token", "sso session"},
+		guidance: Guidance{
+			Summary: "AWS credentials are expired or missing",
+			Steps: []string{
+				"Run: aws sso login --profile <profile>",
+				"Or configure a profile with: aws configure sso",
+			},
+		},
+	},
+}
+
+// Lookup returns guidance for err if its message matches a known signature.
+func Lookup(err error) (Guidance, bool) {
+	if err == nil {
+		return Guidance{}, false
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, r := range rules {
+		for _, m := range r.markers {
+			if strings.Contains(msg, m) {
+				return r.guidance, true
+			}
+		}
+	}
+	return Guidance{}, false
+}
+
+// Format renders err with guidance when available, otherwise the raw message.
+func Format(err error) string {
+	g, ok := Lookup(err)
+	if !ok {
+		return err.Error()
+	}
+
+	var b strings.Builder
+	b.WriteString(ui.ErrorStyle.Render("✗ "+g.Summary) + "\n")
+	b.WriteString(ui.MutedStyle.Render("  "+err.Error()) + "\n")
+	for _, step := range g.Steps {
+		b.WriteString("  → " + step + "\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,61 @@
+package errmap
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Test: Representative error strings map to the expected guidance
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     string
+		summary string
+	}{
+		{"Expired SSO", "operation error ECS: ListClusters, failed to refresh cached credentials, the SSO session has expired", "AWS credentials are expired or missing"},
+		{"Missing credentials", "failed to retrieve credentials: get credentials: no valid providers", "AWS credentials are expired or missing"},
+		{"gh missing", `exec: "gh": executable file not found in $PATH`, "GitHub CLI (gh) is not installed"},
+		{"SSM plugin missing", "SessionManagerPlugin is not found. session-manager-plugin must be installed", "The AWS Session Manager plugin is missing"},
+		{"gh not authed", "To get started with GitHub CLI, please run:  gh auth login", "GitHub CLI is not authenticated"},
+		{"gh 401", "HTTP 401: Bad credentials (https://api.github.com/user)", "GitHub CLI is not authenticated"},
+		{"No workflow_dispatch", "could not create workflow dispatch event: HTTP 422: Workflow does not have 'workflow_dispatch' trigger", "This workflow cannot be triggered manually"},
+		{"Target not connected", "An error occurred (TargetNotConnectedException) when calling the ExecuteCommand operation", "The container's SSM agent is not connected"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, ok := Lookup(errors.New(tt.err))
+			if !ok {
+				t.Fatalf("No guidance for %q", tt.err)
+			}
+			if g.Summary != tt.summary {
+				t.Errorf("Got %q, want %q", g.Summary, tt.summary)
+			}
+			if len(g.Steps) == 0 {
+				t.Errorf("Guidance should include at least one step")
+			}
+		})
+	}
+
+	t.Log("✓ Error signatures mapped to guidance")
+}
+
+// Test: Unknown errors are rendered unchanged
+func TestFormat_Unknown(t *testing.T) {
+	err := errors.New("alias prod not found")
+
+	if _, ok := Lookup(err); ok {
+		t.Errorf("Unexpected guidance for unknown error")
+	}
+	if got := Format(err); got != err.Error() {
+		t.Errorf("Got %q, want raw message", got)
+	}
+
+	got := Format(errors.New("HTTP 401: Bad credentials"))
+	if !strings.Contains(got, "gh auth login") || !strings.Contains(got, "HTTP 401") {
+		t.Errorf("Formatted guidance should include the step and the original error: %q", got)
+	}
+
+	t.Log("✓ Unknown errors left as-is")
+}
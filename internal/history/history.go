@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -15,6 +18,17 @@ type Entry struct {
 	Label     string    `json:"label"`
 	Args      []string  `json:"args"`
 	Timestamp time.Time `json:"timestamp"`
+	// Count is the number of identical runs merged into the entry; 0 in
+	// history files written before Deduplicate means 1.
+	Count int `json:"count,omitempty"`
+}
+
+// Runs returns the number of runs the entry stands for.
+func (e Entry) Runs() int {
+	if e.Count < 1 {
+		return 1
+	}
+	return e.Count
 }
 
 type Store struct {
@@ -37,6 +51,9 @@ func Load() (*Store, error) {
 	path := filepath.Join(dir, "history.json")
 	store := &Store{path: path}
 
+	release := lockOrWarn(path, false)
+	defer release()
+
 	data, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -52,7 +69,8 @@ func Load() (*Store, error) {
 	return store, nil
 }
 
-// Save writes the history to disk.
+// Save writes the history to disk, holding an exclusive lock so that
+// concurrent devcli processes never interleave their writes.
 func (s *Store) Save() error {
 	// Keep only the last N entries
 	if len(s.Entries) > maxEntries {
@@ -64,17 +82,47 @@ func (s *Store) Save() error {
 		return err
 	}
 
+	release := lockOrWarn(s.path, true)
+	defer release()
+
 	return os.WriteFile(s.path, data, 0644)
 }
 
-// Add records a new command execution.
+// Add records a new command execution, merged with the previous entry when
+// it repeats it.
 func (s *Store) Add(command, label string, args []string) {
 	s.Entries = append(s.Entries, Entry{
 		Command:   command,
 		Label:     label,
 		Args:      args,
 		Timestamp: time.Now(),
+		Count:     1,
 	})
+	s.Deduplicate()
+}
+
+// Deduplicate merges consecutive entries with the same command and args into
+// the most recent one, whose Count adds up the runs, and returns the number
+// of entries removed.
+func (s *Store) Deduplicate() int {
+	if len(s.Entries) == 0 {
+		return 0
+	}
+
+	merged := s.Entries[:1]
+	for _, e := range s.Entries[1:] {
+		last := &merged[len(merged)-1]
+		if e.Command == last.Command && slices.Equal(e.Args, last.Args) {
+			e.Count = e.Runs() + last.Runs()
+			*last = e
+			continue
+		}
+		merged = append(merged, e)
+	}
+
+	removed := len(s.Entries) - len(merged)
+	s.Entries = merged
+	return removed
 }
 
 // Labels returns display labels for the last N entries (most recent first).
@@ -91,12 +139,23 @@ func (s *Store) Labels(command string) []string {
 			continue
 		}
 		seen[e.Label] = true
-		labels = append(labels, fmt.Sprintf("%s (%s)", e.Label, e.Timestamp.Format("02 Jan 15:04")))
+		labels = append(labels, e.DisplayLabel())
 	}
 
 	return labels
 }
 
+// DisplayLabel formats the entry for the history menus as "label (date)",
+// or "label (×N, date)" for repeated runs. Menus cut the label at the last
+// " (", so the count stays inside the parentheses.
+func (e Entry) DisplayLabel() string {
+	date := e.Timestamp.Format("02 Jan 15:04")
+	if e.Runs() > 1 {
+		return fmt.Sprintf("%s (×%d, %s)", e.Label, e.Runs(), date)
+	}
+	return fmt.Sprintf("%s (%s)", e.Label, date)
+}
+
 // FindByLabel returns the entry matching the given label prefix.
 func (s *Store) FindByLabel(command, labelPrefix string) *Entry {
 	for i := len(s.Entries) - 1; i >= 0; i-- {
@@ -110,3 +169,39 @@ func (s *Store) FindByLabel(command, labelPrefix string) *Entry {
 	}
 	return nil
 }
+
+// Search returns up to limit entries whose label or arguments contain query
+// (case-insensitive), most recent first. Entries sharing a label are reported once.
+// A limit <= 0 returns all matches.
+func (s *Store) Search(command, query string, limit int) []Entry {
+	query = strings.ToLower(strings.TrimSpace(query))
+
+	var matches []Entry
+	for _, e := range s.Entries {
+		if command != "" && e.Command != command {
+			continue
+		}
+		haystack := strings.ToLower(e.Label + " " + strings.Join(e.Args, " "))
+		if strings.Contains(haystack, query) {
+			matches = append(matches, e)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Timestamp.After(matches[j].Timestamp)
+	})
+
+	var results []Entry
+	seen := make(map[string]bool)
+	for _, e := range matches {
+		if seen[e.Label] {
+			continue
+		}
+		seen[e.Label] = true
+		results = append(results, e)
+		if limit > 0 && len(results) == limit {
+			break
+		}
+	}
+	return results
+}
@@ -0,0 +1,91 @@
+package history
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestStore() *Store {
+	base := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	return &Store{Entries: []Entry{
+		{Command: "deploy", Label: "owner/api/Deploy @ main", Args: []string{"--repo", "owner/api", "--branch", "main"}, Timestamp: base},
+		{Command: "connect", Label: "prod → cluster/api/php", Args: []string{"--profile", "prod"}, Timestamp: base.Add(time.Minute)},
+		{Command: "deploy", Label: "owner/web/Deploy @ main", Args: []string{"--repo", "owner/web", "--input", "environment=Staging"}, Timestamp: base.Add(2 * time.Minute)},
+		{Command: "deploy", Label: "owner/api/Deploy @ main", Args: []string{"--repo", "owner/api", "--branch", "main"}, Timestamp: base.Add(3 * time.Minute)},
+	}}
+}
+
+// Test: Search matches labels and args case-insensitively, most recent first
+func TestStore_Search(t *testing.T) {
+	s := newTestStore()
+
+	tests := []struct {
+		name    string
+		command string
+		query   string
+		limit   int
+		want    []string
+	}{
+		{"Label match", "deploy", "API", 0, []string{"owner/api/Deploy @ main"}},
+		{"Args match", "deploy", "staging", 0, []string{"owner/web/Deploy @ main"}},
+		{"Recency order", "deploy", "deploy", 0, []string{"owner/api/Deploy @ main", "owner/web/Deploy @ main"}},
+		{"Limit", "deploy", "owner", 1, []string{"owner/api/Deploy @ main"}},
+		{"Command filter", "connect", "owner", 0, nil},
+		{"All commands", "", "prod", 0, []string{"prod → cluster/api/php"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.Search(tt.command, tt.query, tt.limit)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Got %d results, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i := range got {
+				if got[i].Label != tt.want[i] {
+					t.Errorf("Result %d: got %s, want %s", i, got[i].Label, tt.want[i])
+				}
+			}
+		})
+	}
+
+	t.Log("✓ History search matches labels and args")
+}
+
+// Test: Consecutive identical runs are merged into the most recent entry
+func TestStore_Deduplicate(t *testing.T) {
+	base := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	args := []string{"--repo", "owner/api", "--branch", "main"}
+	s := &Store{Entries: []Entry{
+		{Command: "deploy", Label: "owner/api/Deploy @ main", Args: args, Timestamp: base},
+		{Command: "deploy", Label: "owner/api/Deploy @ main", Args: args, Timestamp: base.Add(time.Minute), Count: 2},
+		{Command: "connect", Label: "prod → cluster/api/php", Args: []string{"--profile", "prod"}, Timestamp: base.Add(2 * time.Minute)},
+		{Command: "deploy", Label: "owner/api/Deploy @ main", Args: args, Timestamp: base.Add(3 * time.Minute)},
+	}}
+
+	if removed := s.Deduplicate(); removed != 1 {
+		t.Errorf("Deduplicate() = %d, want 1", removed)
+	}
+	if len(s.Entries) != 3 {
+		t.Fatalf("Got %d entries, want 3: %+v", len(s.Entries), s.Entries)
+	}
+	if first := s.Entries[0]; first.Runs() != 3 || !first.Timestamp.Equal(base.Add(time.Minute)) {
+		t.Errorf("Merged entry = %+v, want 3 runs at the latest timestamp", first)
+	}
+
+	s.Add("deploy", "owner/api/Deploy @ main", args)
+	if len(s.Entries) != 3 || s.Entries[2].Runs() != 2 {
+		t.Errorf("Add() did not merge the repeated run: %+v", s.Entries)
+	}
+
+	labels := s.Labels("connect")
+	if len(labels) != 1 || labels[0] != "prod → cluster/api/php (01 Jan 10:02)" {
+		t.Errorf("Labels(connect) = %v", labels)
+	}
+	labels = s.Labels("deploy")
+	if len(labels) != 1 || !strings.HasPrefix(labels[0], "owner/api/Deploy @ main (×2, ") {
+		t.Errorf("Labels(deploy) = %v, want the run count", labels)
+	}
+
+	t.Log("✓ Repeated runs merged")
+}
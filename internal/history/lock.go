@@ -0,0 +1,57 @@
+package history
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	// lockTimeout bounds the wait for another devcli process to release the
+	// history, so a stuck process never blocks the current one.
+	lockTimeout       = time.Second
+	lockRetryInterval = 10 * time.Millisecond
+)
+
+var errLockTimeout = errors.New("timed out waiting for the history lock")
+
+// lock locks the sidecar lock file of path, shared for readers and exclusive
+// for writers, and returns the function releasing it.
+func lock(path string, exclusive bool, timeout time.Duration) (func(), error) {
+	f, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		ok, err := tryLock(f, exclusive)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		if ok {
+			return func() {
+				unlock(f) //nolint:errcheck
+				f.Close()
+			}, nil
+		}
+		if time.Now().After(deadline) {
+			f.Close()
+			return nil, errLockTimeout
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// lockOrWarn locks path, or warns and returns a no-op release when the lock
+// cannot be acquired: the history is then read or written best-effort.
+func lockOrWarn(path string, exclusive bool) func() {
+	release, err := lock(path, exclusive, lockTimeout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "! History not locked (%s), continuing anyway\n", err)
+		return func() {}
+	}
+	return release
+}
@@ -0,0 +1,67 @@
+package history
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Test: writers exclude each other and readers, readers share the lock
+func TestLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+
+	release, err := lock(path, true, lockTimeout)
+	if err != nil {
+		t.Fatalf("lock(exclusive) error = %v", err)
+	}
+	if _, err := lock(path, true, 50*time.Millisecond); !errors.Is(err, errLockTimeout) {
+		t.Errorf("second writer error = %v, want a timeout", err)
+	}
+	if _, err := lock(path, false, 50*time.Millisecond); !errors.Is(err, errLockTimeout) {
+		t.Errorf("reader during a write error = %v, want a timeout", err)
+	}
+	release()
+
+	r1, err := lock(path, false, lockTimeout)
+	if err != nil {
+		t.Fatalf("first reader error = %v", err)
+	}
+	r2, err := lock(path, false, 50*time.Millisecond)
+	if err != nil {
+		t.Errorf("second reader error = %v, want a shared lock", err)
+	} else {
+		r2()
+	}
+	r1()
+
+	t.Log("✓ History lock shared by readers, exclusive for writers")
+}
+
+// Test: a held lock does not prevent saving, only delays it
+func TestSave_BestEffortWhenLocked(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	store, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Add("deploy", "owner/api/Deploy @ main", []string{"--repo", "owner/api"})
+
+	release, err := lock(store.path, true, lockTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	reloaded, err := Load()
+	if err != nil || len(reloaded.Entries) != 1 {
+		t.Errorf("reloaded = %v, %v, want the entry written anyway", reloaded, err)
+	}
+
+	t.Log("✓ History written after the lock timeout")
+}
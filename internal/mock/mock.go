@@ -0,0 +1,188 @@
+// Package mock serves canned gh CLI responses over HTTP, so deploy and
+// status can run against fixtures instead of GitHub.
+//
+// A gh command maps to a request path: "gh workflow list" is /workflow/list
+// and "gh api repos/o/r/actions/runs" is /api/repos/o/r/actions/runs. The
+// server answers with the fixture file <dir>/<path>.json, falling back to a
+// built-in fixture for the commands devcli uses most.
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// defaultFixtures answers the common commands when no fixture file exists.
+var defaultFixtures = map[string]string{
+	"/repo/view":     `{"nameWithOwner":"octo/app","description":"Mock repository","defaultBranchRef":{"name":"main"}}`,
+	"/repo/list":     `[{"nameWithOwner":"octo/app","description":"Mock repository"}]`,
+	"/workflow/list": `[{"name":"Deploy","id":1,"path":".github/workflows/deploy.yml","state":"active"}]`,
+	"/workflow/run":  ``,
+	"/run/list":      `[{"databaseId":1001,"number":1,"status":"completed","conclusion":"success","headBranch":"main","event":"workflow_dispatch","workflowName":"Deploy","url":"https://github.com/octo/app/actions/runs/1001","createdAt":"2024-01-01T00:00:00Z"}]`,
+	"/run/view":      `{"databaseId":1001,"status":"completed","conclusion":"success","headBranch":"main","url":"https://github.com/octo/app/actions/runs/1001","jobs":[]}`,
+	"/run/cancel":    ``,
+	"/api/user":      `{"login":"octocat"}`,
+	"/api/user/orgs": `[]`,
+}
+
+// RequestPath returns the request path of a gh command line (without "gh").
+func RequestPath(args []string) string {
+	var words []string
+	for i := 0; i < len(args) && len(words) < 2; i++ {
+		if strings.HasPrefix(args[i], "-") {
+			break
+		}
+		words = append(words, args[i])
+	}
+	if len(words) == 0 {
+		return "/"
+	}
+
+	if words[0] == "api" && len(words) == 2 {
+		endpoint, _, _ := strings.Cut(words[1], "?")
+		return "/api/" + strings.Trim(endpoint, "/")
+	}
+	return "/" + strings.Join(words, "/")
+}
+
+// Handler serves fixtures from dir (may be empty) and logs each request to log.
+func Handler(dir string, log io.Writer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := path.Clean("/" + r.URL.Path)
+		body, source, ok := fixture(dir, p)
+		if !ok {
+			fmt.Fprintf(log, "%s %s %s → 404\n", time.Now().Format("15:04:05"), r.Method, p)
+			http.Error(w, fmt.Sprintf("no fixture for %s (add %s.json)", p, strings.TrimPrefix(p, "/")), http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(log, "%s %s %s → %s\n", time.Now().Format("15:04:05"), r.Method, p, source)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body) //nolint:errcheck
+	})
+}
+
+// fixture returns the response of a request path and where it comes from.
+func fixture(dir, p string) ([]byte, string, bool) {
+	if dir != "" {
+		file := filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(p, "/"))+".json")
+		if data, err := os.ReadFile(file); err == nil {
+			return data, file, true
+		}
+	}
+	if body, ok := defaultFixtures[p]; ok {
+		return []byte(body), "built-in", true
+	}
+	return nil, "", false
+}
+
+// Call runs a gh command line against the mock server at baseURL, writing
+// the response to stdout like gh would. A --jq/-q filter of the form
+// ".field", ".[]" or ".[].field" is applied to the JSON response.
+func Call(client *http.Client, baseURL string, args []string, stdout io.Writer) error {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/") + RequestPath(args))
+	if err != nil {
+		return fmt.Errorf("invalid mock URL: %w", err)
+	}
+	u.RawQuery = url.Values{"arg": args}.Encode()
+
+	resp, err := client.Get(u.String())
+	if err != nil {
+		return fmt.Errorf("mock server unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s", strings.TrimSpace(string(body)))
+	}
+
+	if query := jqFlag(args); query != "" {
+		lines, err := Query(body, query)
+		if err != nil {
+			return err
+		}
+		for _, line := range lines {
+			fmt.Fprintln(stdout, line)
+		}
+		return nil
+	}
+	_, err = stdout.Write(body)
+	return err
+}
+
+// jqFlag returns the value of the --jq/-q flag, if any.
+func jqFlag(args []string) string {
+	for i, arg := range args {
+		switch {
+		case (arg == "-q" || arg == "--jq") && i+1 < len(args):
+			return args[i+1]
+		case strings.HasPrefix(arg, "--jq="):
+			return strings.TrimPrefix(arg, "--jq=")
+		}
+	}
+	return ""
+}
+
+// Query applies a simple jq filter: a dotted path in which "[]" iterates an
+// array, e.g. ".nameWithOwner" or ".[].login". Strings print unquoted.
+func Query(data []byte, query string) ([]string, error) {
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("mock response is not JSON: %w", err)
+	}
+
+	values := []any{doc}
+	for _, part := range strings.Split(strings.TrimPrefix(query, "."), ".") {
+		if part == "" {
+			continue
+		}
+		iterate := strings.HasSuffix(part, "[]")
+		key := strings.TrimSuffix(part, "[]")
+
+		var next []any
+		for _, v := range values {
+			if key != "" {
+				obj, ok := v.(map[string]any)
+				if !ok {
+					return nil, fmt.Errorf("unsupported mock query %q", query)
+				}
+				v = obj[key]
+			}
+			if iterate {
+				arr, ok := v.([]any)
+				if !ok {
+					return nil, fmt.Errorf("unsupported mock query %q", query)
+				}
+				next = append(next, arr...)
+				continue
+			}
+			next = append(next, v)
+		}
+		values = next
+	}
+
+	lines := make([]string, 0, len(values))
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			lines = append(lines, s)
+			continue
+		}
+		out, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, string(out))
+	}
+	return lines, nil
+}
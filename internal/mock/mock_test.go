@@ -0,0 +1,101 @@
+package mock
+
+import (
+	"bytes"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Test: gh command lines map to request paths
+func TestRequestPath(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"workflow", "list", "--repo", "octo/app", "--json", "name"}, "/workflow/list"},
+		{[]string{"run", "view", "1001", "--json", "jobs"}, "/run/view"},
+		{[]string{"api", "repos/octo/app/actions/runs?per_page=5"}, "/api/repos/octo/app/actions/runs"},
+		{[]string{"api", "/user/orgs", "--jq", ".[].login"}, "/api/user/orgs"},
+		{[]string{"--version"}, "/"},
+	}
+
+	for _, tt := range tests {
+		if got := RequestPath(tt.args); got != tt.want {
+			t.Errorf("RequestPath(%v) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+
+	t.Log("✓ Request paths derived from gh commands")
+}
+
+// Test: simple jq filters select fields and iterate arrays
+func TestQuery(t *testing.T) {
+	data := []byte(`[{"login":"octo","id":1},{"login":"cat","id":2}]`)
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{".[].login", []string{"octo", "cat"}},
+		{".[].id", []string{"1", "2"}},
+		{".[]", []string{`{"id":1,"login":"octo"}`, `{"id":2,"login":"cat"}`}},
+	}
+	for _, tt := range tests {
+		got, err := Query(data, tt.query)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Query(%q) = %v, %v, want %v", tt.query, got, err, tt.want)
+		}
+	}
+
+	got, err := Query([]byte(`{"nameWithOwner":"octo/app"}`), ".nameWithOwner")
+	if err != nil || !reflect.DeepEqual(got, []string{"octo/app"}) {
+		t.Errorf("Query(.nameWithOwner) = %v, %v", got, err)
+	}
+	if _, err := Query(data, ".login"); err == nil {
+		t.Error("Query(.login) on an array should fail")
+	}
+
+	t.Log("✓ jq filters applied")
+}
+
+// Test: fixture files override the built-in responses, unknown commands fail
+func TestCall(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "workflow"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	fixture := `[{"name":"Release","id":7,"path":".github/workflows/release.yml","state":"active"}]`
+	if err := os.WriteFile(filepath.Join(dir, "workflow", "list.json"), []byte(fixture), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(Handler(dir, io.Discard))
+	defer server.Close()
+
+	var out bytes.Buffer
+	if err := Call(server.Client(), server.URL, []string{"workflow", "list", "--repo", "octo/app"}, &out); err != nil {
+		t.Fatalf("Call(workflow list) error = %v", err)
+	}
+	if out.String() != fixture {
+		t.Errorf("workflow list = %q, want the fixture file", out.String())
+	}
+
+	out.Reset()
+	if err := Call(server.Client(), server.URL, []string{"repo", "view", "--json", "nameWithOwner", "-q", ".nameWithOwner"}, &out); err != nil {
+		t.Fatalf("Call(repo view) error = %v", err)
+	}
+	if got := strings.TrimSpace(out.String()); got != "octo/app" {
+		t.Errorf("repo view = %q, want the built-in octo/app", got)
+	}
+
+	if err := Call(server.Client(), server.URL, []string{"pr", "list"}, &out); err == nil || !strings.Contains(err.Error(), "pr/list.json") {
+		t.Errorf("Call(pr list) error = %v, want a missing fixture hint", err)
+	}
+
+	t.Log("✓ gh commands answered from fixtures")
+}
@@ -0,0 +1,99 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the project configuration committed at a repository root.
+const FileName = ".devcli.yaml"
+
+// File is the content of .devcli.yaml:
+//
+//	deploy:
+//	  workflow: deploy.yml
+//	  branch: main
+//	  inputs:
+//	    environment: prod
+//	workflows:
+//	  deploy.yml:
+//	    inputs:
+//	      notify_channel:
+//	        if: send_notification == true
+type File struct {
+	// Deploy holds the defaults of `devcli deploy` run inside the repository.
+	Deploy Deploy `yaml:"deploy,omitempty"`
+	// Workflows overrides the input schema of workflows, keyed by file name.
+	Workflows map[string]Workflow `yaml:"workflows,omitempty"`
+
+	path string
+}
+
+// Deploy holds the default workflow, branch and input values of a project.
+type Deploy struct {
+	Workflow string            `yaml:"workflow,omitempty"`
+	Branch   string            `yaml:"branch,omitempty"`
+	Inputs   map[string]string `yaml:"inputs,omitempty"`
+}
+
+// Workflow holds the input overrides of a workflow.
+type Workflow struct {
+	Inputs map[string]Input `yaml:"inputs,omitempty"`
+}
+
+// Input overrides the condition or type of a workflow input.
+type Input struct {
+	If   string `yaml:"if,omitempty"`
+	Type string `yaml:"type,omitempty"`
+}
+
+// Parse decodes the content of a .devcli.yaml file.
+func Parse(data []byte) (*File, error) {
+	f := &File{}
+	if err := yaml.Unmarshal(data, f); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", FileName, err)
+	}
+	return f, nil
+}
+
+// Load reads .devcli.yaml from dir. A missing file yields an empty File.
+func Load(dir string) (*File, error) {
+	path := filepath.Join(dir, FileName)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &File{path: path}, nil
+		}
+		return nil, err
+	}
+
+	f, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	f.path = path
+	return f, nil
+}
+
+// Save writes the file back to the directory it was loaded from.
+func (f *File) Save() error {
+	data, err := yaml.Marshal(f)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(f.path, data, 0644)
+}
+
+// Path returns the location of the file.
+func (f *File) Path() string {
+	return f.path
+}
+
+// HasDeployDefaults reports whether the file sets any deploy default.
+func (f *File) HasDeployDefaults() bool {
+	return f.Deploy.Workflow != "" || f.Deploy.Branch != "" || len(f.Deploy.Inputs) > 0
+}
@@ -0,0 +1,79 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// Test: A missing file loads empty and is created on save
+func TestLoadSave_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+
+	f, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if f.HasDeployDefaults() {
+		t.Errorf("Missing file should have no defaults")
+	}
+
+	f.Deploy = Deploy{Workflow: "deploy.yml", Branch: "main", Inputs: map[string]string{"environment": "prod"}}
+	if err := f.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Deploy, f.Deploy) || loaded.Path() != filepath.Join(dir, FileName) {
+		t.Errorf("Load() = %+v, want %+v", loaded.Deploy, f.Deploy)
+	}
+
+	t.Log("✓ Project file saved and reloaded")
+}
+
+// Test: Deploy defaults and workflow overrides share the file
+func TestParse(t *testing.T) {
+	f, err := Parse([]byte(`
+deploy:
+  workflow: deploy.yml
+  inputs:
+    dry_run: true
+workflows:
+  deploy.yml:
+    inputs:
+      notify_channel:
+        if: send_notification == true
+`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if f.Deploy.Workflow != "deploy.yml" || f.Deploy.Inputs["dry_run"] != "true" {
+		t.Errorf("Unexpected deploy defaults: %+v", f.Deploy)
+	}
+	if f.Workflows["deploy.yml"].Inputs["notify_channel"].If != "send_notification == true" {
+		t.Errorf("Unexpected workflow overrides: %+v", f.Workflows)
+	}
+
+	if _, err := Parse([]byte("deploy: [")); err == nil {
+		t.Error("Invalid YAML should fail")
+	}
+
+	t.Log("✓ Project file parsed")
+}
+
+// Test: An unreadable file is reported
+func TestLoad_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte("deploy: ["), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(dir); err == nil {
+		t.Error("Invalid file should fail to load")
+	}
+
+	t.Log("✓ Invalid project file rejected")
+}
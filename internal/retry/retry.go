@@ -0,0 +1,152 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/20uf/devcli/internal/verbose"
+)
+
+// Policy controls how many times an operation is attempted and how long to
+// wait between attempts. The delay doubles after each failure, up to MaxDelay.
+type Policy struct {
+	Attempts  int
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+// Default retries up to 3 times with 500ms → 1s → 2s backoff.
+var Default = Policy{
+	Attempts:  3,
+	BaseDelay: 500 * time.Millisecond,
+	MaxDelay:  4 * time.Second,
+}
+
+// transientMarkers are substrings of errors worth retrying: network blips,
+// server-side failures from GitHub and throttling from AWS.
+var transientMarkers = []string{
+	"timeout",
+	"timed out",
+	"connection reset",
+	"connection refused",
+	"temporary failure",
+	"tls handshake",
+	"unexpected eof",
+	"http 500",
+	"http 502",
+	"http 503",
+	"http 504",
+	"throttl",
+	"rate exceeded",
+	"toomanyrequests",
+	"requestlimitexceeded",
+	"serviceunavailable",
+}
+
+// authMarkers identify authentication failures. They are never retried:
+// the user has to re-login (gh auth login / aws sso login) first.
+var authMarkers = []string{
+	"get credentials",
+	"failed to refresh",
+	"expired",
+	"imds",
+	"security token",
+	"accessdenied",
+	"http 401",
+	"gh auth login",
+}
+
+// Do runs fn with the Default policy.
+func Do(ctx context.Context, fn func() error) error {
+	return Default.Do(ctx, fn)
+}
+
+// Output runs a command with the Default policy and returns its stdout.
+// newCmd is called for every attempt since an exec.Cmd cannot be reused.
+func Output(newCmd func() *exec.Cmd) ([]byte, error) {
+	var out []byte
+	err := Default.Do(context.Background(), func() error {
+		cmd := newCmd()
+		started := time.Now()
+		var runErr error
+		out, runErr = cmd.Output()
+		verbose.Since(started, "%s", strings.Join(verbose.RedactArgs(cmd.Args), " "))
+		return runErr
+	})
+	return out, err
+}
+
+// Do runs fn until it succeeds, returns a non-transient error, the attempts
+// are exhausted or ctx is cancelled.
+func (p Policy) Do(ctx context.Context, fn func() error) error {
+	delay := p.BaseDelay
+	var err error
+
+	for attempt := 1; ; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+		if attempt >= p.Attempts || !IsTransient(err) {
+			return err
+		}
+
+		verbose.Log("transient error (attempt %d/%d), retrying in %s: %s", attempt, p.Attempts, delay, err)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if p.MaxDelay > 0 && delay > p.MaxDelay {
+			delay = p.MaxDelay
+		}
+	}
+}
+
+// IsTransient reports whether err looks like a temporary failure.
+// The stderr of a failed command is inspected as well.
+func IsTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || IsAuthError(err) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	msg := strings.ToLower(errorText(err))
+	for _, m := range transientMarkers {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAuthError reports whether err is an authentication failure of gh or
+// AWS, which no retry can fix.
+func IsAuthError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(errorText(err))
+	for _, m := range authMarkers {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// errorText returns the error message, including stderr for command failures.
+func errorText(err error) string {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		return err.Error() + ": " + string(exitErr.Stderr)
+	}
+	return err.Error()
+}
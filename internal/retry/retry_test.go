@@ -0,0 +1,117 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/20uf/devcli/internal/verbose"
+)
+
+var fastPolicy = Policy{Attempts: 3, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond}
+
+// Test: Errors are classified as transient or permanent
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"Nil", nil, false},
+		{"Timeout", errors.New("dial tcp: i/o timeout"), true},
+		{"GitHub 502", errors.New("HTTP 502: Bad Gateway (https://api.github.com/user)"), true},
+		{"AWS throttling", errors.New("operation error ECS: ListTasks, ThrottlingException: Rate exceeded"), true},
+		{"Deadline", context.DeadlineExceeded, true},
+		{"Cancelled", context.Canceled, false},
+		{"Not found", errors.New("HTTP 404: Not Found"), false},
+		{"Expired SSO token", errors.New("failed to refresh cached credentials, the SSO session has expired"), false},
+		{"gh auth", errors.New("HTTP 401: Bad credentials, try gh auth login"), false},
+		{"IMDS timeout", fmt.Errorf("failed to refresh cached credentials, no EC2 IMDS role found: %w", context.DeadlineExceeded), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTransient(tt.err); got != tt.want {
+				t.Errorf("IsTransient(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+
+	t.Log("✓ Errors classified")
+}
+
+// Test: Transient failures are retried until success
+func TestPolicy_RetriesTransient(t *testing.T) {
+	calls := 0
+	err := fastPolicy.Do(context.Background(), func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("HTTP 503: Service Unavailable")
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("Expected success after retries, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls)
+	}
+
+	t.Log("✓ Transient failures retried")
+}
+
+// Test: Permanent and auth failures return immediately, attempts are capped
+func TestPolicy_StopsOnPermanent(t *testing.T) {
+	calls := 0
+	err := fastPolicy.Do(context.Background(), func() error {
+		calls++
+		return errors.New("AccessDeniedException: security token expired")
+	})
+	if err == nil || calls != 1 {
+		t.Errorf("Auth error should not be retried (calls=%d)", calls)
+	}
+
+	calls = 0
+	err = fastPolicy.Do(context.Background(), func() error {
+		calls++
+		return errors.New("connection reset by peer")
+	})
+	if err == nil || calls != fastPolicy.Attempts {
+		t.Errorf("Expected %d attempts, got %d", fastPolicy.Attempts, calls)
+	}
+
+	t.Log("✓ Retries stop on permanent errors and after max attempts")
+}
+
+// Test: The timing logged for a command masks its secret arguments
+func TestOutput_RedactsArgs(t *testing.T) {
+	verbose.SetLevel(verbose.LevelDebug)
+	defer verbose.SetLevel(verbose.LevelNormal)
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := os.Stdout
+	os.Stdout = w
+	out, runErr := Output(func() *exec.Cmd { return exec.Command("echo", "--input", "token=s3cr3t") })
+	os.Stdout = orig
+	w.Close()
+	logged, _ := io.ReadAll(r)
+
+	if runErr != nil || !strings.Contains(string(out), "s3cr3t") {
+		t.Fatalf("Output() = %q, %v", out, runErr)
+	}
+	if strings.Contains(string(logged), "s3cr3t") || !strings.Contains(string(logged), "token=") {
+		t.Errorf("Secret logged: %q", logged)
+	}
+
+	t.Log("✓ Command timing redacted")
+}
@@ -0,0 +1,107 @@
+package shells
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultPreference is the order in which shells are preferred when the
+// config does not define connect.shells.
+var DefaultPreference = []string{"bash", "zsh", "sh"}
+
+// ProbeCommand returns the command listing which of the candidate shells
+// exist in a container, one path per line.
+func ProbeCommand(candidates []string) string {
+	return fmt.Sprintf("sh -c 'command -v %s'", strings.Join(candidates, " "))
+}
+
+// ParseProbe reads the output of ProbeCommand into a set of available shells,
+// keyed by name with their path. Lines that are not absolute paths (errors,
+// session banners) are ignored.
+func ParseProbe(out string) map[string]string {
+	available := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "/") || strings.ContainsAny(line, " \t") {
+			continue
+		}
+		name := path.Base(line)
+		if _, ok := available[name]; !ok {
+			available[name] = line
+		}
+	}
+	return available
+}
+
+// Choose returns the path of the first preferred shell that is available.
+func Choose(available map[string]string, preference []string) (string, bool) {
+	for _, name := range preference {
+		if p, ok := available[name]; ok {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// Store caches the shells available in each container image on disk.
+type Store struct {
+	Images map[string]map[string]string `json:"images"`
+	path   string
+}
+
+// Load reads the shell cache from ~/.devcli/shells.json.
+func Load() (*Store, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(home, ".devcli")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, "shells.json")
+	store := &Store{path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return store, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, store); err != nil {
+		return store, nil
+	}
+
+	return store, nil
+}
+
+// Save writes the shell cache to disk.
+func (s *Store) Save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0644)
+}
+
+// Get returns the shells cached for an image.
+func (s *Store) Get(image string) (map[string]string, bool) {
+	available, ok := s.Images[image]
+	return available, ok
+}
+
+// Set records the shells available in an image.
+func (s *Store) Set(image string, available map[string]string) {
+	if s.Images == nil {
+		s.Images = make(map[string]map[string]string)
+	}
+	s.Images[image] = available
+}
@@ -0,0 +1,77 @@
+package shells
+
+import "testing"
+
+// Test: probe output is parsed into the set of available shells
+func TestParseProbe(t *testing.T) {
+	out := "Starting session with SessionId: ecs-execute-command-0abc\r\n/bin/bash\r\n/bin/sh\r\nsh: 1: zsh: not found\r\n/usr/bin/bash\r\n"
+
+	available := ParseProbe(out)
+
+	if len(available) != 2 {
+		t.Fatalf("Expected 2 shells, got %v", available)
+	}
+	if available["bash"] != "/bin/bash" {
+		t.Errorf("bash = %q, want the first path found", available["bash"])
+	}
+	if available["sh"] != "/bin/sh" {
+		t.Errorf("sh = %q", available["sh"])
+	}
+
+	t.Log("✓ Probe output parsed")
+}
+
+// Test: the first available shell in preference order is chosen
+func TestChoose(t *testing.T) {
+	tests := []struct {
+		name       string
+		available  map[string]string
+		preference []string
+		want       string
+		wantOK     bool
+	}{
+		{"bash preferred", map[string]string{"bash": "/bin/bash", "sh": "/bin/sh"}, DefaultPreference, "/bin/bash", true},
+		{"alpine falls back to sh", map[string]string{"sh": "/bin/sh"}, DefaultPreference, "/bin/sh", true},
+		{"config order wins", map[string]string{"bash": "/bin/bash", "zsh": "/bin/zsh"}, []string{"zsh", "bash"}, "/bin/zsh", true},
+		{"nothing available", map[string]string{}, DefaultPreference, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Choose(tt.available, tt.preference)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Choose() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	t.Log("✓ Best available shell chosen")
+}
+
+// Test: detected shells are cached per image
+func TestStore_PerImage(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	store, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	store.Set("app:1.2", map[string]string{"bash": "/bin/bash"})
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	reloaded, _ := Load()
+	if got, ok := reloaded.Get("app:1.2"); !ok || got["bash"] != "/bin/bash" {
+		t.Errorf("Get(app:1.2) = %v, %v", got, ok)
+	}
+	if _, ok := reloaded.Get("app:1.3"); ok {
+		t.Errorf("Another image should not be cached")
+	}
+
+	if got := ProbeCommand([]string{"bash", "sh"}); got != "sh -c 'command -v bash sh'" {
+		t.Errorf("ProbeCommand() = %q", got)
+	}
+
+	t.Log("✓ Shells cached per image")
+}
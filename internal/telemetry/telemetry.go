@@ -0,0 +1,77 @@
+// Package telemetry emits OpenTelemetry trace spans for deploy and connect
+// operations. Tracing is opt-in: without OTEL_EXPORTER_OTLP_ENDPOINT every
+// span is a no-op and nothing leaves the machine.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// EndpointEnv is the standard OTLP variable enabling the exporter.
+const EndpointEnv = "OTEL_EXPORTER_OTLP_ENDPOINT"
+
+const tracerName = "github.com/20uf/devcli"
+
+// Span attribute keys shared by the orchestrators.
+const (
+	ClusterKey  = attribute.Key("ecs.cluster")
+	ServiceKey  = attribute.Key("ecs.service")
+	WorkflowKey = attribute.Key("gh.workflow")
+	BranchKey   = attribute.Key("gh.branch")
+)
+
+// Init installs an OTLP/HTTP trace exporter when OTEL_EXPORTER_OTLP_ENDPOINT
+// is set. The returned function flushes pending spans and must be called
+// before exiting. When the variable is absent Init does nothing.
+func Init(ctx context.Context, version string) (func(context.Context) error, error) {
+	if os.Getenv(EndpointEnv) == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	// The exporter reads the endpoint, headers and protocol options from
+	// the standard OTEL_EXPORTER_OTLP_* variables.
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceName("devcli"),
+		semconv.ServiceVersion(version),
+	))
+	if err != nil {
+		res = resource.Default()
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Start opens a span named name as a child of the span carried by ctx.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End closes span, marking it as failed when err is not nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
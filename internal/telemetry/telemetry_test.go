@@ -0,0 +1,71 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// Test: Init is a no-op without an OTLP endpoint
+func TestInit_NoEndpoint(t *testing.T) {
+	t.Setenv(EndpointEnv, "")
+	before := otel.GetTracerProvider()
+
+	shutdown, err := Init(context.Background(), "dev")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("unexpected shutdown error: %v", err)
+	}
+	if otel.GetTracerProvider() != before {
+		t.Error("tracer provider should not change without an endpoint")
+	}
+
+	t.Log("✓ Tracing stays disabled without OTEL_EXPORTER_OTLP_ENDPOINT")
+}
+
+// Test: Spans carry attributes, parents and error status
+func TestStartEnd_RecordsSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	ctx, parent := Start(context.Background(), "connect", ClusterKey.String("production"))
+	_, child := Start(ctx, "service-list")
+	End(child, errors.New("access denied"))
+	End(parent, nil)
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+
+	list, connect := spans[0], spans[1]
+	if list.Name() != "service-list" || connect.Name() != "connect" {
+		t.Fatalf("unexpected span names %q, %q", list.Name(), connect.Name())
+	}
+	if list.Parent().SpanID() != connect.SpanContext().SpanID() {
+		t.Error("service-list should be a child of connect")
+	}
+	if list.Status().Code != codes.Error || list.Status().Description != "access denied" {
+		t.Errorf("expected error status, got %+v", list.Status())
+	}
+	if connect.Status().Code != codes.Unset {
+		t.Errorf("expected unset status, got %+v", connect.Status())
+	}
+
+	attrs := connect.Attributes()
+	if len(attrs) != 1 || attrs[0].Key != ClusterKey || attrs[0].Value.AsString() != "production" {
+		t.Errorf("unexpected attributes %v", attrs)
+	}
+
+	t.Log("✓ Spans are nested, tagged and marked failed on error")
+}
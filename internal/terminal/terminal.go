@@ -0,0 +1,112 @@
+package terminal
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/20uf/devcli/internal/verbose"
+)
+
+// Emulator identifies a terminal emulator able to open a new tab or window.
+type Emulator string
+
+const (
+	Unknown       Emulator = ""
+	AppleTerminal Emulator = "Apple_Terminal"
+	ITerm2        Emulator = "iTerm.app"
+	GnomeTerminal Emulator = "gnome-terminal"
+	Xterm         Emulator = "xterm"
+)
+
+// Detect returns the terminal emulator devcli is running in.
+func Detect() Emulator {
+	return detect(runtime.GOOS, os.Getenv, exec.LookPath)
+}
+
+// detect resolves the emulator from the platform, TERM_PROGRAM and the
+// binaries available on PATH.
+func detect(goos string, getenv func(string) string, lookPath func(string) (string, error)) Emulator {
+	switch goos {
+	case "darwin":
+		if getenv("TERM_PROGRAM") == string(ITerm2) {
+			return ITerm2
+		}
+		return AppleTerminal
+	case "linux":
+		if getenv("GNOME_TERMINAL_SCREEN") != "" || getenv("GNOME_TERMINAL_SERVICE") != "" {
+			return GnomeTerminal
+		}
+		if _, err := lookPath("gnome-terminal"); err == nil {
+			return GnomeTerminal
+		}
+		if _, err := lookPath("xterm"); err == nil {
+			return Xterm
+		}
+	}
+	return Unknown
+}
+
+// Command builds the command line that runs args in a new tab of the emulator.
+func (e Emulator) Command(args []string) ([]string, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("no command to run")
+	}
+
+	switch e {
+	case AppleTerminal:
+		script := fmt.Sprintf(`tell application "Terminal" to do script "%s"`, appleScriptEscape(shellJoin(args)))
+		return []string{"osascript", "-e", `tell application "Terminal" to activate`, "-e", script}, nil
+	case ITerm2:
+		return []string{"osascript",
+			"-e", `tell application "iTerm2"`,
+			"-e", `tell current window`,
+			"-e", `create tab with default profile`,
+			"-e", fmt.Sprintf(`tell current session to write text "%s"`, appleScriptEscape(shellJoin(args))),
+			"-e", `end tell`,
+			"-e", `end tell`,
+		}, nil
+	case GnomeTerminal:
+		return append([]string{"gnome-terminal", "--tab", "--"}, args...), nil
+	case Xterm:
+		return append([]string{"xterm", "-e"}, args...), nil
+	default:
+		return nil, fmt.Errorf("no supported terminal emulator found.\n  Supported: Terminal.app, iTerm2, gnome-terminal, xterm")
+	}
+}
+
+// OpenTab runs args in a new tab of the detected emulator without blocking.
+func OpenTab(args []string) error {
+	line, err := Detect().Command(args)
+	if err != nil {
+		return err
+	}
+
+	cmd := verbose.Cmd(exec.Command(line[0], line[1:]...))
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open a new terminal tab: %w", err)
+	}
+	go cmd.Wait() //nolint:errcheck
+	return nil
+}
+
+// shellJoin quotes args so they survive being typed into a shell.
+func shellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg != "" && !strings.ContainsAny(arg, " \t\n'\"\\$`;&|<>()*?[]{}!#~") {
+			quoted[i] = arg
+			continue
+		}
+		quoted[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
+
+// appleScriptEscape escapes a string for use inside an AppleScript string literal.
+func appleScriptEscape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return strings.ReplaceAll(s, `"`, `\"`)
+}
@@ -0,0 +1,90 @@
+package terminal
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// Test: the emulator is resolved from the platform, environment and PATH
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name      string
+		goos      string
+		env       map[string]string
+		available []string
+		want      Emulator
+	}{
+		{"macOS Terminal", "darwin", map[string]string{"TERM_PROGRAM": "Apple_Terminal"}, nil, AppleTerminal},
+		{"macOS iTerm2", "darwin", map[string]string{"TERM_PROGRAM": "iTerm.app"}, nil, ITerm2},
+		{"Linux inside gnome-terminal", "linux", map[string]string{"GNOME_TERMINAL_SCREEN": "/org/gnome/Terminal/screen/1"}, nil, GnomeTerminal},
+		{"Linux with gnome-terminal installed", "linux", nil, []string{"gnome-terminal", "xterm"}, GnomeTerminal},
+		{"Linux with xterm only", "linux", nil, []string{"xterm"}, Xterm},
+		{"Linux without emulator", "linux", nil, nil, Unknown},
+		{"Windows", "windows", nil, nil, Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+			lookPath := func(name string) (string, error) {
+				for _, a := range tt.available {
+					if a == name {
+						return "/usr/bin/" + name, nil
+					}
+				}
+				return "", errors.New("not found")
+			}
+
+			if got := detect(tt.goos, getenv, lookPath); got != tt.want {
+				t.Errorf("detect() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Log("✓ Terminal emulator detected")
+}
+
+// Test: each emulator wraps the session command in its own new-tab prefix
+func TestEmulatorCommand(t *testing.T) {
+	args := []string{"aws", "ecs", "execute-command", "--command", "/bin/sh -c 'bash'"}
+
+	tests := []struct {
+		emulator Emulator
+		want     []string
+		wantErr  bool
+	}{
+		{GnomeTerminal, append([]string{"gnome-terminal", "--tab", "--"}, args...), false},
+		{Xterm, append([]string{"xterm", "-e"}, args...), false},
+		{AppleTerminal, []string{"osascript",
+			"-e", `tell application "Terminal" to activate`,
+			"-e", `tell application "Terminal" to do script "aws ecs execute-command --command '/bin/sh -c '\\''bash'\\'''"`,
+		}, false},
+		{Unknown, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.emulator), func(t *testing.T) {
+			got, err := tt.emulator.Command(args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Command() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Command() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Log("✓ New-tab command prefixes built")
+}
+
+// Test: shell quoting keeps arguments intact
+func TestShellJoin(t *testing.T) {
+	got := shellJoin([]string{"aws", "--command", "su - app", "it's", ""})
+	want := `aws --command 'su - app' 'it'\''s' ''`
+	if got != want {
+		t.Errorf("shellJoin() = %s, want %s", got, want)
+	}
+
+	t.Log("✓ Arguments shell-quoted")
+}
@@ -13,6 +13,7 @@ type Run struct {
 	Workflow   string    `json:"workflow"`
 	Branch     string    `json:"branch"`
 	RunID      string    `json:"run_id"`
+	URL        string    `json:"url,omitempty"`
 	Label      string    `json:"label"`
 	Status     string    `json:"status"`     // queued, in_progress, completed
 	Conclusion string    `json:"conclusion"` // success, failure, cancelled, ""
@@ -79,6 +80,39 @@ func (s *Store) Add(repo, workflow, branch, runID, label string) {
 	})
 }
 
+// Upsert adds a run or refreshes the status of an already tracked one.
+// Returns true if the run was newly added.
+func (s *Store) Upsert(run Run) bool {
+	for i := range s.Runs {
+		if s.Runs[i].RunID == run.RunID {
+			s.Runs[i].Status = run.Status
+			s.Runs[i].Conclusion = run.Conclusion
+			if run.URL != "" {
+				s.Runs[i].URL = run.URL
+			}
+			s.Runs[i].UpdatedAt = time.Now()
+			return false
+		}
+	}
+
+	if run.StartedAt.IsZero() {
+		run.StartedAt = time.Now()
+	}
+	run.UpdatedAt = time.Now()
+	s.Runs = append(s.Runs, run)
+	return true
+}
+
+// SetURL records the GitHub URL of a run once it is known.
+func (s *Store) SetURL(runID, url string) {
+	for i := range s.Runs {
+		if s.Runs[i].RunID == runID {
+			s.Runs[i].URL = url
+			return
+		}
+	}
+}
+
 // Update sets the status/conclusion for a run.
 func (s *Store) Update(runID, status, conclusion string) {
 	for i := range s.Runs {
@@ -120,10 +154,16 @@ func (s *Store) All() []Run {
 
 // Cleanup removes completed runs older than 1 hour.
 func (s *Store) Cleanup() {
+	s.CleanupMatching(func(Run) bool { return true })
+}
+
+// CleanupMatching removes the completed runs older than 1 hour for which
+// match returns true, keeping every other run.
+func (s *Store) CleanupMatching(match func(Run) bool) {
 	cutoff := time.Now().Add(-1 * time.Hour)
 	var kept []Run
 	for _, r := range s.Runs {
-		if r.Status != "completed" || r.UpdatedAt.After(cutoff) {
+		if r.Status != "completed" || r.UpdatedAt.After(cutoff) || !match(r) {
 			kept = append(kept, r)
 		}
 	}
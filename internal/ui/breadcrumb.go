@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/20uf/devcli/internal/verbose"
+)
+
+// BreadcrumbStep is a step of a multi-step selection. A step without a
+// Value is the current one.
+type BreadcrumbStep struct {
+	Label string
+	Value string
+	Fixed bool // given by a flag: there is no going back to choose it
+}
+
+// PrintBreadcrumb displays where the user is in a multi-step selection, e.g.
+// "profile: dev → cluster: production → service:". Completed steps show
+// their value; the current step only its label.
+func PrintBreadcrumb(steps []BreadcrumbStep) {
+	if !verbose.Allows(verbose.LevelNormal) || len(steps) == 0 {
+		return
+	}
+	fmt.Println(RenderBreadcrumb(steps))
+}
+
+// RenderBreadcrumb renders the breadcrumb line printed by PrintBreadcrumb.
+func RenderBreadcrumb(steps []BreadcrumbStep) string {
+	parts := make([]string, len(steps))
+	for i, s := range steps {
+		if s.Value == "" {
+			parts[i] = TitleStyle.Render(s.Label + ":")
+			continue
+		}
+		parts[i] = MutedStyle.Render(s.Label+":") + " " + SuccessStyle.Render(s.Value)
+	}
+	return strings.Join(parts, MutedStyle.Render(" → "))
+}
@@ -0,0 +1,29 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+// Test: Breadcrumbs list completed values, then the current step
+func TestRenderBreadcrumb(t *testing.T) {
+	got := RenderBreadcrumb([]BreadcrumbStep{
+		{Label: "profile", Value: "playiad-dev"},
+		{Label: "cluster", Value: "production"},
+		{Label: "service"},
+	})
+
+	for _, want := range []string{"profile:", "playiad-dev", "cluster:", "production", "service:"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderBreadcrumb() = %q, want it to contain %q", got, want)
+		}
+	}
+	if strings.Count(got, "→") != 2 {
+		t.Errorf("RenderBreadcrumb() = %q, want 2 separators", got)
+	}
+	if !strings.HasSuffix(got, "service:") {
+		t.Errorf("RenderBreadcrumb() = %q, want the current step last", got)
+	}
+
+	t.Log("✓ Breadcrumb rendered")
+}
@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-isatty"
+)
+
+// progressBarWidth is the number of cells of a rendered progress bar.
+const progressBarWidth = 20
+
+var (
+	progressFilledStyle = lipgloss.NewStyle().Foreground(Accent)
+	progressEmptyStyle  = lipgloss.NewStyle().Foreground(Muted)
+)
+
+// ProgressMsg updates the position of a ProgressBarModel.
+type ProgressMsg struct {
+	Current int
+	Total   int
+}
+
+// ProgressBarModel is a bubbletea model rendering a progress bar such as
+// "[████████░░░░░░░░░░░░] 8/14 jobs". When stdout is not a terminal it
+// renders plain text instead ("8/14 jobs complete").
+type ProgressBarModel struct {
+	label       string
+	current     int
+	total       int
+	interactive bool
+}
+
+// ProgressBar creates a progress bar for current out of total units of label.
+func ProgressBar(label string, current, total int) ProgressBarModel {
+	fd := os.Stdout.Fd()
+	return ProgressBarModel{
+		label:       label,
+		current:     current,
+		total:       total,
+		interactive: isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd),
+	}
+}
+
+// Current returns the number of completed units.
+func (m ProgressBarModel) Current() int { return m.current }
+
+// Total returns the total number of units.
+func (m ProgressBarModel) Total() int { return m.total }
+
+// Done reports whether every unit is complete.
+func (m ProgressBarModel) Done() bool { return m.total > 0 && m.current >= m.total }
+
+func (m ProgressBarModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update moves the bar on ProgressMsg and quits once it is complete or on ctrl+c.
+func (m ProgressBarModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ProgressMsg:
+		m.current, m.total = msg.Current, msg.Total
+		if m.Done() {
+			return m, tea.Quit
+		}
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m ProgressBarModel) View() string {
+	if !m.interactive {
+		return m.String()
+	}
+
+	current := min(max(m.current, 0), m.total)
+	filled := 0
+	if m.total > 0 {
+		filled = progressBarWidth * current / m.total
+	}
+
+	bar := progressFilledStyle.Render(strings.Repeat("█", filled)) +
+		progressEmptyStyle.Render(strings.Repeat("░", progressBarWidth-filled))
+	return fmt.Sprintf("[%s] %d/%d %s", bar, m.current, m.total, m.label)
+}
+
+// String renders the plain text form used when stdout is not a terminal.
+func (m ProgressBarModel) String() string {
+	return fmt.Sprintf("%d/%d %s complete", m.current, m.total, m.label)
+}
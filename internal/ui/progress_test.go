@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+// Test: the bar fills proportionally and falls back to plain text off a terminal
+func TestProgressBar_View(t *testing.T) {
+	m := ProgressBar("jobs", 8, 14)
+	m.interactive = true
+
+	view := m.View()
+	if !strings.HasSuffix(view, "] 8/14 jobs") {
+		t.Errorf("View() = %q, want a bar ending with 8/14 jobs", view)
+	}
+	if got := strings.Count(view, "█"); got != progressBarWidth*8/14 {
+		t.Errorf("Filled cells = %d, want %d", got, progressBarWidth*8/14)
+	}
+	if got := strings.Count(view, "░"); got != progressBarWidth-progressBarWidth*8/14 {
+		t.Errorf("Empty cells = %d", got)
+	}
+
+	m.interactive = false
+	if got := m.View(); got != "8/14 jobs complete" {
+		t.Errorf("Plain View() = %q", got)
+	}
+
+	t.Log("✓ Progress bar rendered")
+}
+
+// Test: ProgressMsg moves the bar and quits once complete
+func TestProgressBar_Update(t *testing.T) {
+	var m ProgressBarModel = ProgressBar("jobs", 0, 3)
+
+	next, cmd := m.Update(ProgressMsg{Current: 2, Total: 3})
+	m = next.(ProgressBarModel)
+	if m.Current() != 2 || cmd != nil {
+		t.Errorf("Expected current 2 without quitting, got %d (cmd %v)", m.Current(), cmd != nil)
+	}
+
+	next, cmd = m.Update(ProgressMsg{Current: 3, Total: 3})
+	m = next.(ProgressBarModel)
+	if !m.Done() || cmd == nil {
+		t.Errorf("Expected a completed bar to quit")
+	}
+
+	empty := ProgressBar("jobs", 0, 0)
+	empty.interactive = true
+	if strings.Contains(empty.View(), "█") {
+		t.Errorf("Empty total should render an empty bar")
+	}
+
+	t.Log("✓ Progress bar updated by messages")
+}
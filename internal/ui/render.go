@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+)
+
+// OutputFormat selects how RenderList prints its items.
+type OutputFormat string
+
+const (
+	OutputText OutputFormat = "text"
+	OutputJSON OutputFormat = "json"
+)
+
+// ParseOutputFormat validates the value of an --output flag. An empty value
+// means text.
+func ParseOutputFormat(s string) (OutputFormat, error) {
+	switch OutputFormat(strings.ToLower(s)) {
+	case "", OutputText:
+		return OutputText, nil
+	case OutputJSON:
+		return OutputJSON, nil
+	default:
+		return "", fmt.Errorf("invalid output format %q (expected text or json)", s)
+	}
+}
+
+// column is a field of the listed struct, shown as a table column and
+// marshalled under the same JSON key.
+type column struct {
+	index  int
+	header string
+}
+
+// RenderList prints a slice of structs as a styled table or as a JSON array.
+// Both outputs are driven by the json tags of the struct, so a table column
+// always matches a JSON field: the header is the upper-cased key.
+func RenderList(w io.Writer, format OutputFormat, items any) error {
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice {
+		return fmt.Errorf("RenderList expects a slice, got %T", items)
+	}
+
+	if format == OutputJSON {
+		if v.IsNil() {
+			v = reflect.MakeSlice(v.Type(), 0, 0) // [] rather than null
+		}
+		data, err := json.MarshalIndent(v.Interface(), "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+
+	elem := v.Type().Elem()
+	if elem.Kind() != reflect.Struct {
+		return fmt.Errorf("RenderList expects a slice of structs, got %T", items)
+	}
+	columns := listColumns(elem)
+
+	headers := make([]string, len(columns))
+	for i, c := range columns {
+		headers[i] = c.header
+	}
+	rows := make([][]string, v.Len())
+	for r := 0; r < v.Len(); r++ {
+		row := make([]string, len(columns))
+		for i, c := range columns {
+			row[i] = formatCell(v.Index(r).Field(c.index))
+		}
+		rows[r] = row
+	}
+
+	t := table.New().
+		Border(lipgloss.RoundedBorder()).
+		BorderStyle(MutedStyle).
+		Headers(headers...).
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			style := lipgloss.NewStyle().Padding(0, 1)
+			if row == table.HeaderRow {
+				return style.Bold(true).Foreground(Text)
+			}
+			return style
+		})
+	_, err := fmt.Fprintln(w, t.Render())
+	return err
+}
+
+// listColumns returns the exported fields of a struct that are marshalled
+// to JSON, in declaration order.
+func listColumns(t reflect.Type) []column {
+	var columns []column
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		columns = append(columns, column{index: i, header: strings.ToUpper(strings.ReplaceAll(name, "_", " "))})
+	}
+	return columns
+}
+
+// formatCell renders a field value as table text.
+func formatCell(v reflect.Value) string {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	switch x := v.Interface().(type) {
+	case time.Time:
+		if x.IsZero() {
+			return ""
+		}
+		return x.Local().Format("2006-01-02 15:04:05")
+	case fmt.Stringer:
+		return x.String()
+	}
+
+	if v.Kind() == reflect.Slice {
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = formatCell(v.Index(i))
+		}
+		return strings.Join(parts, ", ")
+	}
+	return fmt.Sprint(v.Interface())
+}
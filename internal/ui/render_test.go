@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type renderItem struct {
+	Name     string   `json:"name"`
+	TaskDef  string   `json:"task_definition,omitempty"`
+	Tags     []string `json:"tags"`
+	Replicas int      `json:"replicas"`
+	internal string
+	Ignored  string `json:"-"`
+}
+
+// Test: the same items render as a table and as JSON with matching fields
+func TestRenderList_Parity(t *testing.T) {
+	items := []renderItem{
+		{Name: "api", TaskDef: "api:42", Tags: []string{"web", "php"}, Replicas: 3, internal: "x", Ignored: "y"},
+		{Name: "worker", Replicas: 1},
+	}
+
+	var text bytes.Buffer
+	if err := RenderList(&text, OutputText, items); err != nil {
+		t.Fatalf("RenderList(text) error = %v", err)
+	}
+	for _, want := range []string{"NAME", "TASK DEFINITION", "TAGS", "REPLICAS", "api:42", "web, php", "worker"} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("table missing %q:\n%s", want, text.String())
+		}
+	}
+	if strings.Contains(text.String(), "IGNORED") || strings.Contains(text.String(), "INTERNAL") {
+		t.Errorf("table shows fields hidden from JSON:\n%s", text.String())
+	}
+
+	var out bytes.Buffer
+	if err := RenderList(&out, OutputJSON, items); err != nil {
+		t.Fatalf("RenderList(json) error = %v", err)
+	}
+	var decoded []map[string]any
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+	}
+	if len(decoded) != 2 || decoded[0]["name"] != "api" || decoded[0]["task_definition"] != "api:42" {
+		t.Errorf("decoded = %v", decoded)
+	}
+	for key := range decoded[0] {
+		header := strings.ToUpper(strings.ReplaceAll(key, "_", " "))
+		if !strings.Contains(text.String(), header) {
+			t.Errorf("JSON field %q has no %q column", key, header)
+		}
+	}
+
+	t.Log("✓ Table and JSON outputs match")
+}
+
+// Test: an empty list is an empty JSON array, not null
+func TestRenderList_EmptyJSON(t *testing.T) {
+	var out bytes.Buffer
+	if err := RenderList(&out, OutputJSON, []renderItem(nil)); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(out.String()); got != "[]" {
+		t.Errorf("RenderList(nil) = %q, want []", got)
+	}
+
+	if err := RenderList(&out, OutputText, "not a slice"); err == nil {
+		t.Error("RenderList(string) should fail")
+	}
+
+	t.Log("✓ Empty and invalid lists handled")
+}
+
+// Test: --output accepts text and json only
+func TestParseOutputFormat(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    OutputFormat
+		wantErr bool
+	}{
+		{"", OutputText, false},
+		{"text", OutputText, false},
+		{"JSON", OutputJSON, false},
+		{"yaml", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseOutputFormat(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseOutputFormat(%q) = %q, %v", tt.in, got, err)
+		}
+	}
+
+	t.Log("✓ Output formats validated")
+}
@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"errors"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+)
+
+const (
+	shortcutsBrowsing  = "↑/↓ move · / filter · enter select · esc back · ? hide keys"
+	shortcutsFiltering = "type to filter · backspace erase · esc stop filtering · enter select"
+)
+
+// selectModel runs a select form with a footer listing its shortcuts, which
+// ? shows and hides. The footer replaces the huh help line and is only
+// shown on lists long enough to have a filter bar. Outside the filter, esc
+// aborts the prompt so callers can step back.
+type selectModel struct {
+	form     *huh.Form
+	sel      *huh.Select[string]
+	footer   bool
+	showKeys bool
+	aborted  bool
+}
+
+func newSelectModel(sel *huh.Select[string], count int) *selectModel {
+	footer := count > filterThreshold
+	// Long lists open on their filter: let esc leave it, as it does once /
+	// was pressed, so the browsing keys become reachable.
+	keymap := huh.NewDefaultKeyMap()
+	keymap.Select.SetFilter.SetEnabled(footer)
+	form := huh.NewForm(huh.NewGroup(sel)).WithTheme(devTheme()).WithKeyMap(keymap).WithShowHelp(!footer)
+	form.SubmitCmd = tea.Quit
+	form.CancelCmd = tea.Interrupt
+	return &selectModel{form: form, sel: sel, footer: footer, showKeys: true}
+}
+
+func (m *selectModel) Init() tea.Cmd {
+	return m.form.Init()
+}
+
+func (m *selectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok && !m.sel.GetFiltering() {
+		switch {
+		case key.String() == "esc":
+			m.aborted = true
+			return m, tea.Quit
+		case key.String() == "?" && m.footer:
+			m.showKeys = !m.showKeys
+			return m, nil
+		}
+	}
+
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+	}
+	return m, cmd
+}
+
+func (m *selectModel) View() string {
+	if m.aborted {
+		return ""
+	}
+	view := m.form.View()
+	if !m.footer || m.form.State != huh.StateNormal {
+		return view
+	}
+	if !m.showKeys {
+		return view + "\n" + MutedStyle.Render("  ? show keys")
+	}
+	shortcuts := shortcutsBrowsing
+	if m.sel.GetFiltering() {
+		shortcuts = shortcutsFiltering
+	}
+	return view + "\n" + MutedStyle.Render("  "+shortcuts)
+}
+
+// runSelect runs sel with its shortcuts footer and reports an abort as an
+// error.
+func runSelect(sel *huh.Select[string], count int) error {
+	m := newSelectModel(sel, count)
+	_, err := tea.NewProgram(m, tea.WithOutput(os.Stderr), tea.WithReportFocus()).Run()
+	if m.aborted || errors.Is(err, tea.ErrInterrupted) || m.form.State == huh.StateAborted {
+		return huh.ErrUserAborted
+	}
+	return err
+}
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+)
+
+func newTestSelect(count int) *selectModel {
+	options := make([]huh.Option[string], count)
+	for i := range options {
+		name := fmt.Sprintf("option-%d", i)
+		options[i] = huh.NewOption(name, name)
+	}
+	sel := huh.NewSelect[string]().Title("Pick").Options(options...).Filtering(count > filterThreshold)
+	m := newSelectModel(sel, count)
+	m.Init()
+	return m
+}
+
+// Test: Long lists show their shortcuts, toggled with ?
+func TestSelectModel_Footer(t *testing.T) {
+	m := newTestSelect(12)
+	if !strings.Contains(m.View(), shortcutsFiltering) {
+		t.Error("Expected the filter shortcuts while a long list opens on its filter")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.aborted || !strings.Contains(m.View(), shortcutsBrowsing) {
+		t.Error("Expected esc to leave the filter and show the browsing shortcuts")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'?'}})
+	if view := m.View(); strings.Contains(view, shortcutsBrowsing) || !strings.Contains(view, "? show keys") {
+		t.Errorf("Expected ? to hide the shortcuts, got:\n%s", view)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'?'}})
+	if !strings.Contains(m.View(), shortcutsBrowsing) {
+		t.Error("Expected ? to show the shortcuts again")
+	}
+
+	if short := newTestSelect(3); strings.Contains(short.View(), shortcutsBrowsing) {
+		t.Error("Expected no footer on a short list")
+	}
+
+	t.Log("✓ Shortcuts footer toggled")
+}
+
+// Test: Esc aborts the prompt so the caller can step back
+func TestSelectModel_Esc(t *testing.T) {
+	m := newTestSelect(3)
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc}); cmd == nil || !m.aborted {
+		t.Error("Expected esc to abort the prompt")
+	}
+	if m.View() != "" {
+		t.Error("Expected an aborted prompt to clear its view")
+	}
+
+	t.Log("✓ Esc aborts the prompt")
+}
@@ -3,8 +3,10 @@ package ui
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
+	"github.com/20uf/devcli/internal/verbose"
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -93,9 +95,13 @@ func devTheme() *huh.Theme {
 	return t
 }
 
+// filterThreshold is the option count above which selectors get a filter
+// bar and a shortcuts footer.
+const filterThreshold = 8
+
 func selectHeight(count int) int {
 	// Generous height so all items stay visible
-	if count <= 8 {
+	if count <= filterThreshold {
 		return count + 6
 	}
 	h := count + 5
@@ -112,9 +118,15 @@ type SelectOption struct {
 }
 
 // Select displays an interactive selection prompt.
-// Lists > 8 items have filtering enabled (type to search).
+// Lists > 8 items have filtering enabled (type to search) and a shortcuts
+// footer, toggled with ?.
 func Select(label string, options []string) (string, error) {
-	var selected string
+	return SelectWithDefault(label, options, "")
+}
+
+// SelectWithDefault displays a selection prompt with the cursor on def when it is one of the options.
+func SelectWithDefault(label string, options []string, def string) (string, error) {
+	selected := def
 
 	huhOptions := make([]huh.Option[string], len(options))
 	for i, opt := range options {
@@ -126,10 +138,9 @@ func Select(label string, options []string) (string, error) {
 		Options(huhOptions...).
 		Value(&selected).
 		Height(selectHeight(len(options))).
-		Filtering(len(options) > 8)
+		Filtering(len(options) > filterThreshold)
 
-	err := huh.NewForm(huh.NewGroup(sel)).WithTheme(devTheme()).Run()
-	if err != nil {
+	if err := runSelect(sel, len(options)); err != nil {
 		return "", ErrUserAbort
 	}
 
@@ -150,19 +161,70 @@ func SelectWithOptions(label string, options []SelectOption) (string, error) {
 		Options(huhOptions...).
 		Value(&selected).
 		Height(selectHeight(len(options))).
-		Filtering(len(options) > 8)
+		Filtering(len(options) > filterThreshold)
 
-	err := huh.NewForm(huh.NewGroup(sel)).WithTheme(devTheme()).Run()
-	if err != nil {
+	if err := runSelect(sel, len(options)); err != nil {
 		return "", ErrUserAbort
 	}
 
 	return selected, nil
 }
 
+// OptionBack is the entry SelectWithContext adds to step back in a flow.
+const OptionBack = "← Back"
+
+// backValue is the value of the OptionBack entry, which no option has.
+const backValue = "\x00back"
+
+// StringOptions returns select options displaying their value.
+func StringOptions(values []string) []SelectOption {
+	options := make([]SelectOption, len(values))
+	for i, v := range values {
+		options[i] = SelectOption{Display: v, Value: v}
+	}
+	return options
+}
+
+// SelectWithContext displays a selection prompt of a multi-step flow: the
+// breadcrumb of the flow above it and, when the previous step was chosen
+// interactively, a "← Back" entry at the end of the list. Choosing it returns ErrUserAbort,
+// as ESC does, so callers step back the same way.
+func SelectWithContext(title string, steps []BreadcrumbStep, options []SelectOption) (string, error) {
+	PrintBreadcrumb(steps)
+	selected, err := SelectWithOptions(title, withBackOption(steps, options))
+	if err != nil {
+		return "", err
+	}
+	if selected == backValue {
+		return "", ErrUserAbort
+	}
+	return selected, nil
+}
+
+// withBackOption appends the OptionBack entry when the last completed step
+// was chosen interactively: a step fixed by a flag cannot be chosen again.
+func withBackOption(steps []BreadcrumbStep, options []SelectOption) []SelectOption {
+	for i := len(steps) - 1; i >= 0; i-- {
+		if steps[i].Value == "" {
+			continue
+		}
+		if steps[i].Fixed {
+			return options
+		}
+		back := SelectOption{Display: MutedStyle.Render(OptionBack), Value: backValue}
+		return append(slices.Clone(options), back)
+	}
+	return options
+}
+
 // Confirm displays a yes/no prompt.
 func Confirm(label string) (bool, error) {
-	var confirmed bool
+	return ConfirmWithDefault(label, false)
+}
+
+// ConfirmWithDefault displays a yes/no prompt with def preselected.
+func ConfirmWithDefault(label string, def bool) (bool, error) {
+	confirmed := def
 
 	c := huh.NewConfirm().
 		Title(label).
@@ -178,12 +240,21 @@ func Confirm(label string) (bool, error) {
 
 // Input displays a text input prompt.
 func Input(label, placeholder string) (string, error) {
+	return InputWithValidation(label, placeholder, nil)
+}
+
+// InputWithValidation displays a text input prompt that refuses values
+// rejected by validate. A nil validate accepts anything.
+func InputWithValidation(label, placeholder string, validate func(string) error) (string, error) {
 	var value string
 
 	i := huh.NewInput().
 		Title(label).
 		Placeholder(placeholder).
 		Value(&value)
+	if validate != nil {
+		i = i.Validate(validate)
+	}
 
 	err := huh.NewForm(huh.NewGroup(i)).WithTheme(devTheme()).Run()
 	if err != nil {
@@ -218,10 +289,14 @@ type UpdateResult struct {
 }
 
 // PrintBannerWithUpdateCheck displays the banner with an inline update check.
-func PrintBannerWithUpdateCheck(version string, checkFn func() (string, bool, error)) *UpdateResult {
+// build, the commit and date of the binary, follows the version when set.
+func PrintBannerWithUpdateCheck(version, build string, checkFn func() (string, bool, error)) *UpdateResult {
 	fmt.Println(BannerStyle.Render(bannerArt))
 	fmt.Println()
 
+	if build != "" {
+		version = fmt.Sprintf("%s (%s)", version, build)
+	}
 	versionPart := MutedStyle.Render(fmt.Sprintf("  v%s", version))
 	sloganPart := lipgloss.NewStyle().Foreground(Text).Render(" — Focus on coding, not on tooling.")
 	versionText := versionPart + sloganPart
@@ -276,12 +351,19 @@ func PrintBannerWithUpdateCheck(version string, checkFn func() (string, bool, er
 }
 
 // PrintStep displays a styled step message.
+// Steps, successes and info boxes are hidden at the quiet log level.
 func PrintStep(icon, message string) {
+	if !verbose.Allows(verbose.LevelNormal) {
+		return
+	}
 	fmt.Printf("%s %s\n", TitleStyle.Render(icon), message)
 }
 
 // PrintSuccess displays a success message.
 func PrintSuccess(message string) {
+	if !verbose.Allows(verbose.LevelNormal) {
+		return
+	}
 	fmt.Println(SuccessStyle.Render("✓ " + message))
 }
 
@@ -297,6 +379,9 @@ func PrintError(message string) {
 
 // PrintInfo displays an info box.
 func PrintInfo(title, content string) {
+	if !verbose.Allows(verbose.LevelNormal) {
+		return
+	}
 	header := TitleStyle.Render(title)
 	body := BoxStyle.Render(content)
 	fmt.Printf("%s\n%s\n", header, body)
@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/20uf/devcli/internal/verbose"
+)
+
+// captureStdout returns everything fn prints to stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	orig := os.Stdout
+	os.Stdout = w
+	fn()
+	os.Stdout = orig
+	w.Close()
+
+	out, _ := io.ReadAll(r)
+	return string(out)
+}
+
+// Test: quiet hides progress output but keeps warnings and errors
+func TestPrintGating(t *testing.T) {
+	defer verbose.SetLevel(verbose.LevelNormal)
+
+	tests := []struct {
+		level        verbose.Level
+		wantProgress bool
+	}{
+		{verbose.LevelQuiet, false},
+		{verbose.LevelNormal, true},
+		{verbose.LevelVerbose, true},
+		{verbose.LevelDebug, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			verbose.SetLevel(tt.level)
+			out := captureStdout(t, func() {
+				PrintStep("▶", "step-msg")
+				PrintSuccess("success-msg")
+				PrintInfo("info-title", "info-body")
+				PrintWarning("warning-msg")
+				PrintError("error-msg")
+			})
+
+			for _, msg := range []string{"step-msg", "success-msg", "info-title"} {
+				if got := strings.Contains(out, msg); got != tt.wantProgress {
+					t.Errorf("%s shown = %v, want %v", msg, got, tt.wantProgress)
+				}
+			}
+			for _, msg := range []string{"warning-msg", "error-msg"} {
+				if !strings.Contains(out, msg) {
+					t.Errorf("%s should always be shown", msg)
+				}
+			}
+		})
+	}
+
+	t.Log("✓ Print helpers gated by level")
+}
+
+// Test: A back entry is added once a previous step was chosen interactively
+func TestWithBackOption(t *testing.T) {
+	options := StringOptions([]string{"prod", "staging"})
+
+	first := withBackOption([]BreadcrumbStep{{Label: "profile"}}, options)
+	if len(first) != 2 {
+		t.Errorf("Expected no back entry on the first step, got %v", first)
+	}
+
+	steps := []BreadcrumbStep{{Label: "profile", Value: "dev"}, {Label: "cluster"}}
+	got := withBackOption(steps, options)
+	if len(got) != 3 || got[2].Value != backValue || !strings.Contains(got[2].Display, OptionBack) {
+		t.Errorf("Expected a back entry last, got %v", got)
+	}
+	if len(options) != 2 {
+		t.Error("withBackOption() modified the given options")
+	}
+
+	fixed := []BreadcrumbStep{{Label: "profile", Value: "dev", Fixed: true}, {Label: "cluster"}}
+	if got := withBackOption(fixed, options); len(got) != 2 {
+		t.Errorf("Expected no back entry after a step given by a flag, got %v", got)
+	}
+
+	t.Log("✓ Back entry added after an interactive step")
+}
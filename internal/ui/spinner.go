@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/20uf/devcli/internal/verbose"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-isatty"
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// spinnerInterval is the delay between two frames.
+const spinnerInterval = 100 * time.Millisecond
+
+// Spinner shows a label next to an animated spinner on stderr while a slow
+// operation runs. It wraps a bubbletea program behind a synchronous API, so
+// callers outside bubbletea only call Start, UpdateLabel and Stop.
+//
+// A Spinner draws nothing when stderr is not a terminal, in CI, at the quiet
+// level, or when verbose traces would interleave with it. Frames are left
+// uncolored when NO_COLOR is set.
+type Spinner struct {
+	out     io.Writer
+	enabled bool
+	plain   bool
+
+	mu      sync.Mutex
+	program *tea.Program
+	done    chan struct{}
+}
+
+// NewSpinner creates a stopped spinner for the current terminal.
+func NewSpinner() *Spinner {
+	fd := os.Stderr.Fd()
+	tty := isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+	enabled := tty && os.Getenv("CI") == "" &&
+		verbose.Allows(verbose.LevelNormal) && !verbose.IsEnabled()
+	return &Spinner{out: os.Stderr, enabled: enabled, plain: os.Getenv("NO_COLOR") != ""}
+}
+
+// StartSpinner creates a spinner and starts it with label.
+func StartSpinner(label string) *Spinner {
+	s := NewSpinner()
+	s.Start(label)
+	return s
+}
+
+// Start shows the spinner with label. Starting a running spinner only
+// changes its label.
+func (s *Spinner) Start(label string) {
+	if !s.enabled {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.program != nil {
+		s.program.Send(spinnerLabelMsg(label))
+		return
+	}
+
+	s.program = tea.NewProgram(spinnerModel{label: label, plain: s.plain},
+		tea.WithOutput(s.out), tea.WithInput(nil), tea.WithoutSignalHandler())
+	s.done = make(chan struct{})
+	go func(p *tea.Program, done chan struct{}) {
+		defer close(done)
+		p.Run() //nolint:errcheck
+	}(s.program, s.done)
+}
+
+// UpdateLabel replaces the label of a running spinner.
+func (s *Spinner) UpdateLabel(label string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.program != nil {
+		s.program.Send(spinnerLabelMsg(label))
+	}
+}
+
+// Stop clears the spinner line and returns once it is gone. Stopping a
+// stopped spinner does nothing.
+func (s *Spinner) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.program == nil {
+		return
+	}
+	s.program.Send(spinnerStopMsg{})
+	<-s.done
+	s.program, s.done = nil, nil
+}
+
+type (
+	spinnerTickMsg  struct{}
+	spinnerLabelMsg string
+	spinnerStopMsg  struct{}
+)
+
+// spinnerModel is the bubbletea model animating a Spinner.
+type spinnerModel struct {
+	frame   int
+	label   string
+	plain   bool
+	stopped bool
+}
+
+func spinnerTick() tea.Cmd {
+	return tea.Tick(spinnerInterval, func(time.Time) tea.Msg { return spinnerTickMsg{} })
+}
+
+func (m spinnerModel) Init() tea.Cmd {
+	return spinnerTick()
+}
+
+// Update advances the frame on each tick and quits, clearing the line, on stop.
+func (m spinnerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case spinnerTickMsg:
+		m.frame++
+		return m, spinnerTick()
+	case spinnerLabelMsg:
+		m.label = string(msg)
+	case spinnerStopMsg:
+		m.stopped = true
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m spinnerModel) View() string {
+	if m.stopped {
+		return ""
+	}
+	frame := spinnerFrames[m.frame%len(spinnerFrames)]
+	if !m.plain {
+		frame = MutedStyle.Render(frame)
+	}
+	return frame + " " + m.label
+}
@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer is a bytes.Buffer safe for the spinner goroutine.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// Test: The spinner model animates, relabels and clears itself on stop
+func TestSpinnerModel(t *testing.T) {
+	m := spinnerModel{label: "Loading clusters…", plain: true}
+	if got := m.View(); got != "⠋ Loading clusters…" {
+		t.Errorf("View() = %q", got)
+	}
+
+	next, _ := m.Update(spinnerTickMsg{})
+	next, _ = next.Update(spinnerLabelMsg("Loading services…"))
+	if got := next.View(); got != "⠙ Loading services…" {
+		t.Errorf("View() after tick and relabel = %q", got)
+	}
+
+	next, cmd := next.Update(spinnerStopMsg{})
+	if cmd == nil || next.View() != "" {
+		t.Errorf("Stopped spinner should quit with an empty view, got %q", next.View())
+	}
+
+	t.Log("✓ Spinner model updated")
+}
+
+// Test: Start, UpdateLabel and Stop drive the spinner synchronously
+func TestSpinner_StartStop(t *testing.T) {
+	var out syncBuffer
+	s := &Spinner{out: &out, enabled: true, plain: true}
+
+	s.Start("Loading clusters…")
+	time.Sleep(2 * spinnerInterval)
+	s.UpdateLabel("Loading services…")
+	time.Sleep(2 * spinnerInterval)
+	s.Stop()
+	s.Stop() // stopping twice is a no-op
+
+	got := out.String()
+	for _, want := range []string{"Loading clusters…", "Loading services…"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Spinner output lacks %q", want)
+		}
+	}
+
+	written := len(out.String())
+	time.Sleep(2 * spinnerInterval)
+	if len(out.String()) != written {
+		t.Error("Spinner kept drawing after Stop")
+	}
+
+	t.Log("✓ Spinner started, relabeled and stopped")
+}
+
+// Test: A disabled spinner draws nothing
+func TestSpinner_Disabled(t *testing.T) {
+	var out syncBuffer
+	s := &Spinner{out: &out}
+	s.Start("Loading…")
+	s.UpdateLabel("Still loading…")
+	s.Stop()
+
+	if out.String() != "" {
+		t.Errorf("Disabled spinner wrote %q", out.String())
+	}
+
+	t.Log("✓ Spinner is a no-op outside a terminal")
+}
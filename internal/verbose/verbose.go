@@ -2,40 +2,217 @@ package verbose
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 )
 
+// Level controls how much output devcli prints.
+type Level int
+
+const (
+	// LevelQuiet only prints warnings and errors.
+	LevelQuiet Level = iota
+	// LevelNormal prints steps, results and info boxes.
+	LevelNormal
+	// LevelVerbose also traces executed commands and API calls.
+	LevelVerbose
+	// LevelDebug also prints internal diagnostics.
+	LevelDebug
+)
+
+var levelNames = map[Level]string{
+	LevelQuiet:   "quiet",
+	LevelNormal:  "normal",
+	LevelVerbose: "verbose",
+	LevelDebug:   "debug",
+}
+
 var (
-	enabled bool
+	level = LevelNormal
+	start = time.Now()
+
+	// secretPattern matches argument names that carry credentials.
+	secretPattern = regexp.MustCompile(`(?i)(token|secret|passw(or)?d|pwd|key|credential|auth)`)
+	// sensitiveKeys extends secretPattern with user-configured names.
+	sensitiveKeys []string
 
 	debugStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
 	labelStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#22D3EE")).Bold(true)
 )
 
+// String returns the level name as accepted by --log-level.
+func (l Level) String() string {
+	if name, ok := levelNames[l]; ok {
+		return name
+	}
+	return fmt.Sprintf("level(%d)", int(l))
+}
+
+// ParseLevel converts a --log-level value to a Level.
+func ParseLevel(s string) (Level, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	for l, n := range levelNames {
+		if n == name {
+			return l, nil
+		}
+	}
+	return LevelNormal, fmt.Errorf("invalid log level %q (expected quiet, normal, verbose or debug)", s)
+}
+
+// SetLevel sets the active output level.
+func SetLevel(l Level) { level = l }
+
+// CurrentLevel returns the active output level.
+func CurrentLevel() Level { return level }
+
+// Allows reports whether output of the given level should be printed.
+func Allows(l Level) bool { return level >= l }
+
 // Enable turns verbose logging on.
-func Enable() { enabled = true }
+func Enable() {
+	if level < LevelVerbose {
+		level = LevelVerbose
+	}
+}
 
 // IsEnabled returns whether verbose mode is active.
-func IsEnabled() bool { return enabled }
+func IsEnabled() bool { return Allows(LevelVerbose) }
+
+// Redacted replaces the value of a secret argument.
+const Redacted = "***"
+
+// AddSensitiveKeys marks argument names containing one of keys as secret,
+// on top of the built-in pattern (token, secret, password, key...).
+func AddSensitiveKeys(keys ...string) {
+	for _, key := range keys {
+		if key = strings.ToLower(strings.TrimSpace(key)); key != "" {
+			sensitiveKeys = append(sensitiveKeys, key)
+		}
+	}
+}
 
-// Cmd logs the command being executed and returns it unchanged.
+// IsSensitive reports whether an argument or input name carries a secret.
+func IsSensitive(name string) bool {
+	if secretPattern.MatchString(name) {
+		return true
+	}
+	lower := strings.ToLower(name)
+	for _, key := range sensitiveKeys {
+		if strings.Contains(lower, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// Cmd logs the command being executed and returns it. Secret argument
+// values are always redacted. In debug mode the arguments are dumped with
+// the time elapsed since startup, and the working directory is logged too.
+// When DEVCLI_MOCK_URL is set, gh commands are redirected to the mock server.
 func Cmd(cmd *exec.Cmd) *exec.Cmd {
-	if !enabled {
-		return cmd
+	logCmd(cmd)
+	if MockURL() != "" && len(cmd.Args) > 0 && filepath.Base(cmd.Args[0]) == "gh" {
+		redirectToMock(cmd)
 	}
-	args := strings.Join(cmd.Args, " ")
-	fmt.Printf("%s %s\n", labelStyle.Render("[exec]"), debugStyle.Render(args))
 	return cmd
 }
 
+func logCmd(cmd *exec.Cmd) {
+	if !Allows(LevelVerbose) {
+		return
+	}
+	args := strings.Join(RedactArgs(cmd.Args), " ")
+	if !Allows(LevelDebug) {
+		fmt.Printf("%s %s\n", labelStyle.Render("[exec]"), debugStyle.Render(args))
+		return
+	}
+
+	label := fmt.Sprintf("[exec +%.3fs]", time.Since(start).Seconds())
+	fmt.Printf("%s %s\n", labelStyle.Render(label), debugStyle.Render(args))
+	if cmd.Dir != "" {
+		fmt.Printf("%s %s\n", labelStyle.Render("[debug]"), debugStyle.Render("dir="+cmd.Dir))
+	}
+}
+
+// MockURLEnv points gh commands at a `devcli mock serve` server.
+const MockURLEnv = "DEVCLI_MOCK_URL"
+
+// MockURL returns the mock server gh commands are redirected to, if any.
+func MockURL() string { return os.Getenv(MockURLEnv) }
+
+// redirectToMock turns `gh <args>` into `devcli mock gh <args>`, which
+// answers from the mock server. gh itself does not need to be installed.
+func redirectToMock(cmd *exec.Cmd) {
+	self, err := os.Executable()
+	if err != nil {
+		return
+	}
+	cmd.Path = self
+	cmd.Args = append([]string{self, "mock", "gh"}, cmd.Args[1:]...)
+	cmd.Err = nil // clear a failed lookup of gh
+}
+
+// LookPath is exec.LookPath, except that gh is always found when its
+// commands are redirected to a mock server.
+func LookPath(file string) (string, error) {
+	if file == "gh" && MockURL() != "" {
+		return file, nil
+	}
+	return exec.LookPath(file)
+}
+
+// RedactArgs masks the values of secret-looking arguments: "key=value"
+// pairs such as "--input token=abc" and the value following a flag such as
+// "--password abc".
+func RedactArgs(args []string) []string {
+	redacted := make([]string, len(args))
+	copy(redacted, args)
+
+	for i, arg := range redacted {
+		if strings.HasPrefix(arg, "-") {
+			name, value, hasValue := strings.Cut(arg, "=")
+			if !IsSensitive(name) {
+				continue
+			}
+			if hasValue && value != "" {
+				redacted[i] = name + "=" + Redacted
+			} else if !hasValue && i+1 < len(redacted) && !strings.HasPrefix(redacted[i+1], "-") {
+				redacted[i+1] = Redacted
+			}
+			continue
+		}
+		if key, value, ok := strings.Cut(arg, "="); ok && value != "" && IsSensitive(key) {
+			redacted[i] = key + "=" + Redacted
+		}
+	}
+	return redacted
+}
+
+// Since logs at the debug level how long an operation took.
+func Since(t time.Time, format string, a ...any) {
+	Debug("%s took %s", fmt.Sprintf(format, a...), time.Since(t).Round(time.Millisecond))
+}
+
 // Log prints a debug message when verbose mode is active.
 func Log(format string, a ...any) {
-	if !enabled {
+	if !Allows(LevelVerbose) {
 		return
 	}
 	msg := fmt.Sprintf(format, a...)
 	fmt.Printf("%s %s\n", labelStyle.Render("[debug]"), debugStyle.Render(msg))
 }
+
+// Debug prints an internal diagnostic when the debug level is active.
+func Debug(format string, a ...any) {
+	if !Allows(LevelDebug) {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	fmt.Printf("%s %s\n", labelStyle.Render("[trace]"), debugStyle.Render(msg))
+}
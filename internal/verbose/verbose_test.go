@@ -0,0 +1,196 @@
+package verbose
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// captureStdout returns everything fn prints to stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	orig := os.Stdout
+	os.Stdout = w
+	fn()
+	os.Stdout = orig
+	w.Close()
+
+	out, _ := io.ReadAll(r)
+	return string(out)
+}
+
+// Test: ParseLevel accepts the four level names and rejects anything else
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Level
+		wantErr bool
+	}{
+		{"quiet", LevelQuiet, false},
+		{"normal", LevelNormal, false},
+		{"VERBOSE", LevelVerbose, false},
+		{" debug ", LevelDebug, false},
+		{"loud", LevelNormal, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseLevel(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLevel(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ParseLevel(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+
+	t.Log("✓ Log levels parsed")
+}
+
+// Test: each level gates command tracing, API logs and diagnostics
+func TestLevelGating(t *testing.T) {
+	defer SetLevel(LevelNormal)
+
+	tests := []struct {
+		level     Level
+		wantExec  bool
+		wantLog   bool
+		wantDebug bool
+	}{
+		{LevelQuiet, false, false, false},
+		{LevelNormal, false, false, false},
+		{LevelVerbose, true, true, false},
+		{LevelDebug, true, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			SetLevel(tt.level)
+			out := captureStdout(t, func() {
+				Cmd(exec.Command("gh", "run", "list"))
+				Log("ecs:ListClusters")
+				Debug("cache miss")
+			})
+
+			if got := strings.Contains(out, "gh run list"); got != tt.wantExec {
+				t.Errorf("command tracing shown = %v, want %v", got, tt.wantExec)
+			}
+			if got := strings.Contains(out, "ecs:ListClusters"); got != tt.wantLog {
+				t.Errorf("API log shown = %v, want %v", got, tt.wantLog)
+			}
+			if got := strings.Contains(out, "cache miss"); got != tt.wantDebug {
+				t.Errorf("debug diagnostic shown = %v, want %v", got, tt.wantDebug)
+			}
+		})
+	}
+
+	t.Log("✓ Output categories gated by level")
+}
+
+// Test: Enable maps -v to the verbose level without lowering debug
+func TestEnable(t *testing.T) {
+	defer SetLevel(LevelNormal)
+
+	SetLevel(LevelNormal)
+	Enable()
+	if CurrentLevel() != LevelVerbose || !IsEnabled() {
+		t.Errorf("Enable() from normal = %s, want verbose", CurrentLevel())
+	}
+
+	SetLevel(LevelDebug)
+	Enable()
+	if CurrentLevel() != LevelDebug {
+		t.Errorf("Enable() from debug = %s, want debug", CurrentLevel())
+	}
+
+	t.Log("✓ -v maps to verbose")
+}
+
+// Test: secret argument values are redacted at the verbose and debug levels
+func TestCmd_RedactsSecrets(t *testing.T) {
+	defer SetLevel(LevelNormal)
+
+	cmd := exec.Command("gh", "workflow", "run", "deploy.yml",
+		"-f", "api_token=s3cr3t", "-f", "environment=prod", "--password", "hunter2")
+
+	SetLevel(LevelVerbose)
+	out := captureStdout(t, func() { Cmd(cmd) })
+	if strings.Contains(out, "s3cr3t") || strings.Contains(out, "hunter2") {
+		t.Errorf("verbose output leaks secrets: %s", out)
+	}
+	if !strings.Contains(out, "api_token=***") || !strings.Contains(out, "environment=prod") {
+		t.Errorf("verbose output should mask only secret values: %s", out)
+	}
+
+	SetLevel(LevelDebug)
+	out = captureStdout(t, func() { Cmd(cmd) })
+	if strings.Contains(out, "s3cr3t") || !strings.Contains(out, "environment=prod") || !strings.Contains(out, "[exec +") {
+		t.Errorf("debug output should dump redacted args with timing: %s", out)
+	}
+
+	t.Log("✓ Secrets redacted at verbose and debug levels")
+}
+
+// Test: RedactArgs masks key=value pairs and flag values with secret names
+func TestRedactArgs(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--input", "token=abc"}, "--input token=***"},
+		{[]string{"--input", "tag=v1"}, "--input tag=v1"},
+		{[]string{"--client-secret=abc"}, "--client-secret=***"},
+		{[]string{"--auth-token", "abc", "--repo", "o/r"}, "--auth-token *** --repo o/r"},
+		{[]string{"--role-arn", "arn:aws:iam::1:role/x"}, "--role-arn arn:aws:iam::1:role/x"},
+		{[]string{"--input", "deploy_key=abc"}, "--input deploy_key=***"},
+		{[]string{"--input", "sentry_dsn=abc"}, "--input sentry_dsn=***"},
+	}
+
+	AddSensitiveKeys("DSN")
+	defer func() { sensitiveKeys = nil }()
+
+	for _, tt := range tests {
+		if got := strings.Join(RedactArgs(tt.args), " "); got != tt.want {
+			t.Errorf("RedactArgs(%v) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+
+	t.Log("✓ Secret arguments masked")
+}
+
+// Test: with DEVCLI_MOCK_URL, gh commands run `devcli mock gh` instead
+func TestCmd_MockRedirect(t *testing.T) {
+	t.Setenv(MockURLEnv, "http://localhost:8080")
+
+	cmd := Cmd(exec.Command("gh", "run", "list", "--repo", "octo/app"))
+	self, _ := os.Executable()
+	if cmd.Path != self {
+		t.Errorf("Path = %q, want the running executable", cmd.Path)
+	}
+	if want := []string{self, "mock", "gh", "run", "list", "--repo", "octo/app"}; strings.Join(cmd.Args, " ") != strings.Join(want, " ") {
+		t.Errorf("Args = %v, want %v", cmd.Args, want)
+	}
+	if cmd.Err != nil {
+		t.Errorf("Err = %v, want the gh lookup error cleared", cmd.Err)
+	}
+	if _, err := LookPath("gh"); err != nil {
+		t.Errorf("LookPath(gh) error = %v with a mock server", err)
+	}
+
+	other := Cmd(exec.Command("aws", "--version"))
+	if other.Args[0] != "aws" {
+		t.Errorf("non-gh command redirected: %v", other.Args)
+	}
+
+	t.Setenv(MockURLEnv, "")
+	if plain := Cmd(exec.Command("gh", "run", "list")); plain.Args[0] != "gh" {
+		t.Errorf("gh redirected without a mock URL: %v", plain.Args)
+	}
+
+	t.Log("✓ gh commands redirected to the mock server")
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime/debug"
 	"strings"
 
 	"github.com/20uf/devcli/cmd"
@@ -21,6 +22,14 @@ func main() {
 		if v, err := readVersionFile(); err == nil && v != "" {
 			version = v
 		}
+		// Commit and date set with -ldflags are kept
+		vcsCommit, vcsDate := readBuildVCS()
+		if commit == "none" && vcsCommit != "" {
+			commit = vcsCommit
+		}
+		if date == "unknown" && vcsDate != "" {
+			date = vcsDate
+		}
 	}
 
 	cmd.SetVersionInfo(version, commit, date)
@@ -47,3 +56,21 @@ func readVersionFile() (string, error) {
 
 	return "", fmt.Errorf("VERSION file not found")
 }
+
+// readBuildVCS returns the commit and commit time the go toolchain embedded
+// in the binary, empty when it was built outside of a checkout.
+func readBuildVCS() (commit, date string) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "", ""
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			commit = setting.Value
+		case "vcs.time":
+			date = setting.Value
+		}
+	}
+	return commit, date
+}